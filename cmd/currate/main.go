@@ -0,0 +1,539 @@
+// Command currate - консольный конвертер валют по курсам ЦБ РФ
+// Предназначен для скриптов и CI: вывод в text, JSON или CSV, код возврата по типу ошибки
+//
+// Использование:
+//
+//	currate convert 1000 USD [--date 20.12.2025] [--to EUR] [--format text|json|csv]
+//	currate convert 80000 RUB --to USD
+//	currate rate EUR [--date 20.12.2025] [--format text|json|csv]
+//	currate rates [--date 20.12.2025] [--format text|json|csv]
+//	currate serve [--addr 127.0.0.1:8080]
+//	currate convert 1000 USD --to EUR --source ecb
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/cache"
+	"github.com/bivlked/currate-go/internal/converter"
+	"github.com/bivlked/currate-go/internal/models"
+	"github.com/bivlked/currate-go/internal/parser"
+	"github.com/bivlked/currate-go/internal/server"
+)
+
+// Коды возврата
+const (
+	exitOK                  = 0 // Успешно
+	exitError               = 1 // Прочие ошибки
+	exitUsage               = 2 // Неверные аргументы командной строки
+	exitInvalidAmount       = 3 // converter.ErrInvalidAmount
+	exitDateInFuture        = 4 // converter.ErrDateInFuture
+	exitUnsupportedCurrency = 5 // models.ErrUnsupportedCurrency
+	exitRateNotFound        = 6 // converter.ErrRateNotFound
+	exitNetwork             = 7 // Ошибка обращения к ЦБ РФ (сеть, HTTP статус, повторы)
+)
+
+// dateLayout - формат дат в аргументах и выводе
+const dateLayout = "02.01.2006"
+
+// Ошибки разбора аргументов (код возврата exitUsage)
+var (
+	errUsage = errors.New("неверные аргументы")
+)
+
+// shutdownTimeout - время на завершение активных запросов при остановке serve
+const shutdownTimeout = 5 * time.Second
+
+// cbrClient - клиент всех запросов к ЦБ РФ и ЕЦБ: общие таймаут, User-Agent, повторы
+// и circuit breaker по хосту; прокси - из переменных окружения HTTPS_PROXY, NO_PROXY
+var cbrClient = parser.NewCBRClient(parser.CBRClientOptions{Timeout: parser.DefaultTimeout})
+
+// fetchRates - источник курсов ЦБ РФ
+// Переменная для подмены в тестах
+var fetchRates converter.FetchRatesFunc = cbrClient.FetchRates
+
+// fetchMetalRates - учетные цены драгоценных металлов ЦБ РФ (дополняют курсы источника cbr)
+// Переменная для подмены в тестах
+var fetchMetalRates converter.FetchRatesFunc = cbrClient.FetchMetalRates
+
+// fetchECBRates - источник справочных курсов ЕЦБ (в евро)
+// Переменная для подмены в тестах
+var fetchECBRates converter.FetchRatesFunc = parser.NewECBProvider(models.EUR).WithClient(cbrClient).FetchRates
+
+// rateSource - источник курсов, выбранный флагом --source
+type rateSource struct {
+	title      string // Заголовок вывода команды rates
+	provider   converter.RateProvider
+	currencies *models.Registry // Справочник валют источника (nil - справочник ЦБ РФ)
+}
+
+// lookupSource возвращает источник курсов по значению --source
+// Функция, а не таблица: тесты подменяют fetchRates, fetchMetalRates и fetchECBRates
+func lookupSource(name string) (rateSource, error) {
+	switch name {
+	case "cbr":
+		return rateSource{title: "Курсы ЦБ РФ", provider: converter.NewMergeProvider(fetchRates, fetchMetalRates)}, nil
+	case "ecb":
+		return rateSource{title: "Курсы ЕЦБ", provider: fetchECBRates, currencies: parser.ECBCurrencies()}, nil
+	default:
+		return rateSource{}, fmt.Errorf("%w: неизвестный источник %q (cbr или ecb)", errUsage, name)
+	}
+}
+
+// newConverter создает конвертер с кэшем на время одного запуска
+// Кросс-конвертация берет оба курса из одного ответа источника,
+// а в режиме serve запросы на одну дату обслуживаются без повторного обращения к источнику
+func newConverter(source rateSource) *converter.Converter {
+	conv := converter.NewConverter(source.provider, cache.NewLRUCacheWithPolicy(1000, cache.DefaultDatePolicy))
+	if source.currencies != nil {
+		conv = conv.WithCurrencies(source.currencies)
+	}
+	return conv
+}
+
+const usage = `currate - конвертер валют по курсам ЦБ РФ
+
+Использование:
+  currate convert СУММА ВАЛЮТА [--to ВАЛЮТА] [--date ДД.ММ.ГГГГ] [--format text|json|csv] [--source cbr|ecb]
+  currate rate ВАЛЮТА [--date ДД.ММ.ГГГГ] [--format text|json|csv] [--source cbr|ecb]
+  currate rates [--date ДД.ММ.ГГГГ] [--format text|json|csv] [--source cbr|ecb]
+  currate serve [--addr 127.0.0.1:8080] [--source cbr|ecb]
+
+Источники курсов (--source):
+  cbr - ЦБ РФ (по умолчанию), курсы в рублях и учетные цены металлов XAU, XAG, XPT, XPD за грамм
+  ecb - справочные курсы ЕЦБ в евро: только конвертация между валютами ЕЦБ (--to)
+
+Примеры:
+  currate convert 1000 USD --date 20.12.2025     доллары → рубли
+  currate convert 80000 RUB --to USD             рубли → доллары
+  currate convert 1000 USD --to EUR              кросс-курс через рубль
+  currate rate EUR                               курс евро на сегодня
+  currate convert 100 XAU                        100 граммов золота → рубли
+  currate rates --format csv                     все курсы ЦБ РФ на сегодня
+  currate convert 1000 USD --to EUR --source ecb по справочному курсу ЕЦБ
+  currate serve --addr :8080                     HTTP API: /v1/rates, /v1/rates/{code}, /v1/convert, /health
+
+Коды возврата:
+  0 - успешно, 1 - прочие ошибки, 2 - неверные аргументы,
+  3 - некорректная сумма, 4 - дата в будущем, 5 - неподдерживаемая валюта,
+  6 - курс не опубликован, 7 - ЦБ РФ недоступен
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run выполняет команду и возвращает код возврата
+// Вынесена из main для тестирования
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var err error
+	switch args[0] {
+	case "convert":
+		err = runConvert(ctx, args[1:], stdout, stderr)
+	case "rate":
+		err = runRate(ctx, args[1:], stdout, stderr)
+	case "rates":
+		err = runRates(ctx, args[1:], stdout, stderr)
+	case "serve":
+		err = runServe(ctx, args[1:], stderr)
+	case "help", "-h", "--help":
+		fmt.Fprint(stdout, usage)
+		return exitOK
+	default:
+		err = fmt.Errorf("%w: неизвестная команда %q", errUsage, args[0])
+	}
+
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "currate: %v\n", err)
+		if errors.Is(err, errUsage) {
+			fmt.Fprint(stderr, "Подробнее: currate help\n")
+		}
+	}
+	return exitCode(err)
+}
+
+// exitCode сопоставляет ошибку коду возврата
+// Использует errors.Is, поэтому работает и с обёрнутыми ошибками
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, errUsage):
+		return exitUsage
+	case errors.Is(err, converter.ErrInvalidAmount):
+		return exitInvalidAmount
+	case errors.Is(err, converter.ErrDateInFuture):
+		return exitDateInFuture
+	case errors.Is(err, models.ErrUnsupportedCurrency):
+		return exitUnsupportedCurrency
+	case errors.Is(err, converter.ErrRateNotFound):
+		return exitRateNotFound
+	case errors.Is(err, parser.ErrHTTPFailed),
+		errors.Is(err, parser.ErrInvalidStatus),
+		errors.Is(err, parser.ErrMaxRetries),
+		errors.Is(err, parser.ErrSourceUnavailable),
+		errors.Is(err, converter.ErrAllSourcesFailed):
+		return exitNetwork
+	default:
+		return exitError
+	}
+}
+
+// commonFlags - флаги, общие для всех команд
+type commonFlags struct {
+	date   string
+	format string
+	source string
+}
+
+// newFlagSet создает набор флагов команды с --date, --format и --source
+func newFlagSet(name string, stderr io.Writer) (*flag.FlagSet, *commonFlags) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	common := &commonFlags{}
+	fs.StringVar(&common.date, "date", "", "дата курса ДД.ММ.ГГГГ (по умолчанию - сегодня)")
+	fs.StringVar(&common.format, "format", "text", "формат вывода: text, json или csv")
+	fs.StringVar(&common.source, "source", "cbr", "источник курсов: cbr или ecb")
+	return fs, common
+}
+
+// parseArgs разбирает флаги вперемешку с позиционными аргументами
+// (flag.FlagSet останавливается на первом позиционном: "convert 1000 USD --date ...")
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: %w", errUsage, err)
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// parse проверяет общие флаги и возвращает дату курса и источник курсов
+func (f *commonFlags) parse() (time.Time, rateSource, error) {
+	switch f.format {
+	case "text", "json", "csv":
+	default:
+		return time.Time{}, rateSource{}, fmt.Errorf("%w: неизвестный формат %q (text, json или csv)", errUsage, f.format)
+	}
+
+	source, err := lookupSource(f.source)
+	if err != nil {
+		return time.Time{}, rateSource{}, err
+	}
+
+	if f.date == "" {
+		return time.Now(), source, nil
+	}
+	// Локальная временная зона сохраняет календарную дату, как parseDate в GUI
+	date, err := time.ParseInLocation(dateLayout, f.date, time.Local)
+	if err != nil {
+		return time.Time{}, rateSource{}, fmt.Errorf("%w: неверный формат даты %q, используйте ДД.ММ.ГГГГ", errUsage, f.date)
+	}
+	return date, source, nil
+}
+
+// runConvert выполняет команду convert
+// Направление определяется парой валют: ВАЛЮТА → RUB, RUB → --to, ВАЛЮТА → --to (кросс-курс)
+func runConvert(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs, common := newFlagSet("convert", stderr)
+	to := fs.String("to", "RUB", "целевая валюта")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return fmt.Errorf("%w: convert ожидает СУММА ВАЛЮТА", errUsage)
+	}
+	date, source, err := common.parse()
+	if err != nil {
+		return err
+	}
+
+	amount, err := models.ParseDecimal(positional[0])
+	if err != nil {
+		return fmt.Errorf("%w: %s", converter.ErrInvalidAmount, positional[0])
+	}
+	// Валюты проверяются по справочнику источника: у ЕЦБ нет рубля и металлов
+	conv := newConverter(source)
+	from, err := conv.ParseCurrency(positional[1])
+	if err != nil {
+		return err
+	}
+	target, err := conv.ParseCurrency(*to)
+	if err != nil {
+		return err
+	}
+
+	var result *models.ConversionResult
+	switch {
+	case target == models.RUB:
+		result, err = conv.Convert(ctx, amount, from, date)
+	case from == models.RUB:
+		result, err = conv.ConvertFromRUB(ctx, amount, target, date)
+	default:
+		result, err = conv.ConvertCross(ctx, amount, from, target, date)
+	}
+	if err != nil {
+		return err
+	}
+
+	return writeConversion(stdout, common.format, result)
+}
+
+// runRate выполняет команду rate
+// Курс выводится за единицу валюты в базовой валюте источника (у ЦБ РФ - в рублях)
+func runRate(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs, common := newFlagSet("rate", stderr)
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("%w: rate ожидает ВАЛЮТА", errUsage)
+	}
+	date, source, err := common.parse()
+	if err != nil {
+		return err
+	}
+
+	conv := newConverter(source)
+	currency, err := conv.ParseCurrency(positional[0])
+	if err != nil {
+		return err
+	}
+
+	rateData, err := conv.GetRates(ctx, date)
+	if err != nil {
+		return err
+	}
+
+	rate := models.NewDecimalFromInt(1)
+	if currency != rateData.BaseCurrency() {
+		exchangeRate, exists := rateData.Rates[currency]
+		if !exists {
+			return fmt.Errorf("%w: %s", converter.ErrRateNotFound, currency)
+		}
+		rate = converter.UnitRate(exchangeRate)
+	}
+
+	return writeRates(stdout, common.format, source.title, rateData.Date, rateData.BaseCurrency(), []rateRow{{Currency: currency, Nominal: 1, Rate: rate}})
+}
+
+// runRates выполняет команду rates: все курсы ЦБ РФ на дату
+func runRates(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs, common := newFlagSet("rates", stderr)
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return fmt.Errorf("%w: rates не принимает позиционных аргументов", errUsage)
+	}
+	date, source, err := common.parse()
+	if err != nil {
+		return err
+	}
+	if err := converter.ValidateDate(date); err != nil {
+		return err
+	}
+
+	rateData, err := source.provider.FetchRates(ctx, date)
+	if err != nil {
+		return fmt.Errorf("failed to fetch rates: %w", err)
+	}
+	if rateData == nil {
+		return errors.New("rate provider returned nil data")
+	}
+
+	rows := make([]rateRow, 0, len(rateData.Rates))
+	for _, rate := range rateData.Rates {
+		rows = append(rows, rateRow{Currency: rate.Currency, Nominal: rate.Nominal, Rate: rate.Rate})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Currency < rows[j].Currency })
+
+	return writeRates(stdout, common.format, source.title, rateData.Date, rateData.BaseCurrency(), rows)
+}
+
+// runServe выполняет команду serve: HTTP API до прерывания (Ctrl+C)
+func runServe(ctx context.Context, args []string, stderr io.Writer) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	addr := fs.String("addr", "127.0.0.1:8080", "адрес HTTP сервера")
+	sourceName := fs.String("source", "cbr", "источник курсов: cbr или ecb")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return fmt.Errorf("%w: serve не принимает позиционных аргументов", errUsage)
+	}
+	source, err := lookupSource(*sourceName)
+	if err != nil {
+		return err
+	}
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           server.New(newConverter(source)),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.ListenAndServe()
+	}()
+	fmt.Fprintf(stderr, "currate: HTTP API на %s (Ctrl+C - остановка)\n", *addr)
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("failed to start HTTP server: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shutdown HTTP server: %w", err)
+	}
+	return nil
+}
+
+// conversionOutput - результат конвертации для JSON
+type conversionOutput struct {
+	SourceCurrency models.Currency `json:"sourceCurrency"`
+	TargetCurrency models.Currency `json:"targetCurrency"`
+	SourceAmount   models.Decimal  `json:"sourceAmount"`
+	TargetAmount   models.Decimal  `json:"targetAmount"`
+	Rate           models.Decimal  `json:"rate"`
+	Date           string          `json:"date"`
+	Formatted      string          `json:"formatted"`
+	AmountInWords  string          `json:"amountInWords"`
+}
+
+// writeConversion выводит результат конвертации в выбранном формате
+func writeConversion(w io.Writer, format string, result *models.ConversionResult) error {
+	switch format {
+	case "json":
+		return writeJSON(w, conversionOutput{
+			SourceCurrency: result.SourceCurrency,
+			TargetCurrency: result.TargetCurrency,
+			SourceAmount:   result.SourceAmount,
+			TargetAmount:   result.TargetAmount,
+			Rate:           result.Rate,
+			Date:           result.Date.Format(dateLayout),
+			Formatted:      result.FormattedStr,
+			AmountInWords:  result.AmountInWords,
+		})
+	case "csv":
+		return writeCSV(w, [][]string{
+			{"source_currency", "target_currency", "source_amount", "target_amount", "rate", "date"},
+			{
+				string(result.SourceCurrency), string(result.TargetCurrency),
+				result.SourceAmount.String(), result.TargetAmount.String(),
+				result.Rate.String(), result.Date.Format(dateLayout),
+			},
+		})
+	default:
+		_, err := fmt.Fprintln(w, result.FormattedStr)
+		return err
+	}
+}
+
+// rateRow - курс валюты в выводе команд rate и rates
+type rateRow struct {
+	Currency models.Currency `json:"currency"`
+	Nominal  int             `json:"nominal"`
+	Rate     models.Decimal  `json:"rate"` // Единиц базовой валюты (у ЦБ РФ - рублей) за Nominal единиц валюты
+}
+
+// ratesOutput - курсы на дату для JSON
+type ratesOutput struct {
+	Date  string          `json:"date"`
+	Base  models.Currency `json:"base"` // Валюта, в которой выражены курсы
+	Rates []rateRow       `json:"rates"`
+}
+
+// writeRates выводит курсы в выбранном формате
+// title - заголовок текстового вывода ("Курсы ЦБ РФ"), base - валюта, в которой выражены курсы
+func writeRates(w io.Writer, format, title string, date time.Time, base models.Currency, rows []rateRow) error {
+	switch format {
+	case "json":
+		return writeJSON(w, ratesOutput{Date: date.Format(dateLayout), Base: base, Rates: rows})
+	case "csv":
+		records := [][]string{{"date", "currency", "nominal", "rate"}}
+		for _, row := range rows {
+			records = append(records, []string{
+				date.Format(dateLayout), string(row.Currency), strconv.Itoa(row.Nominal), row.Rate.String(),
+			})
+		}
+		return writeCSV(w, records)
+	default:
+		if base == models.RUB {
+			fmt.Fprintf(w, "%s на %s\n", title, date.Format(dateLayout))
+		} else {
+			fmt.Fprintf(w, "%s на %s (в %s)\n", title, date.Format(dateLayout), base)
+		}
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, row := range rows {
+			fmt.Fprintf(tw, "%s\t%d\t%s\t%s\n",
+				row.Currency, row.Nominal, strings.ReplaceAll(row.Rate.String(), ".", ","), row.Currency.Name())
+		}
+		return tw.Flush()
+	}
+}
+
+// writeJSON выводит значение как JSON с отступами
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(v)
+}
+
+// writeCSV выводит записи как CSV
+func writeCSV(w io.Writer, records [][]string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(records); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,331 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+	"github.com/bivlked/currate-go/internal/parser"
+)
+
+// testRateDate - дата курсов в моке источника
+var testRateDate = time.Date(2025, 12, 20, 0, 0, 0, 0, time.Local)
+
+// setTestFetchRates подменяет источник курсов на время теста
+// Цены металлов по умолчанию недоступны (см. setTestMetalRates)
+func setTestFetchRates(t *testing.T, fn func(ctx context.Context, date time.Time) (*models.RateData, error)) {
+	t.Helper()
+	original, originalMetals := fetchRates, fetchMetalRates
+	fetchRates = fn
+	fetchMetalRates = func(_ context.Context, _ time.Time) (*models.RateData, error) {
+		return nil, parser.ErrNoXMLRates
+	}
+	t.Cleanup(func() {
+		fetchRates, fetchMetalRates = original, originalMetals
+	})
+}
+
+// setTestMetalRates подменяет учетные цены металлов ценой золота
+// Вызывается после setTestRates
+func setTestMetalRates(t *testing.T) {
+	t.Helper()
+	fetchMetalRates = func(_ context.Context, _ time.Time) (*models.RateData, error) {
+		rateData := models.NewRateData(testRateDate)
+		rateData.AddRate(models.ExchangeRate{Currency: models.XAU, Rate: models.MustParseDecimal("10712.04"), Nominal: 1, Date: testRateDate})
+		return rateData, nil
+	}
+}
+
+// setTestRates подменяет источник курсов фиксированными курсами USD и EUR
+func setTestRates(t *testing.T) {
+	t.Helper()
+	setTestFetchRates(t, func(_ context.Context, _ time.Time) (*models.RateData, error) {
+		rateData := models.NewRateData(testRateDate)
+		rateData.AddRate(models.ExchangeRate{Currency: models.USD, Rate: models.MustParseDecimal("80.7220"), Nominal: 1, Date: testRateDate})
+		rateData.AddRate(models.ExchangeRate{Currency: models.EUR, Rate: models.MustParseDecimal("88.1234"), Nominal: 1, Date: testRateDate})
+		rateData.AddRate(models.ExchangeRate{Currency: "JPY", Rate: models.MustParseDecimal("51.2345"), Nominal: 100, Date: testRateDate})
+		return rateData, nil
+	})
+}
+
+// runCLI запускает команду и возвращает код возврата, stdout и stderr
+func runCLI(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun_Convert(t *testing.T) {
+	setTestRates(t)
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "Валюта → рубли",
+			args: []string{"convert", "1000", "USD", "--date", "20.12.2025"},
+			want: "80 722,00 руб. ($1 000,00 по курсу 80,7220)\n",
+		},
+		{
+			name: "Флаги до позиционных аргументов",
+			args: []string{"convert", "--date", "20.12.2025", "1000,5", "usd"},
+			want: "80 762,36 руб. ($1 000,50 по курсу 80,7220)\n",
+		},
+		{
+			name: "Рубли → валюта",
+			args: []string{"convert", "80722", "RUB", "--to", "USD", "--date", "20.12.2025"},
+			want: "$1 000,00 (80 722,00 руб. по курсу 80,7220)\n",
+		},
+		{
+			name: "Кросс-конвертация",
+			args: []string{"convert", "1000", "USD", "--to", "EUR", "--date", "20.12.2025"},
+			want: "€916,01 ($1 000,00 по кросс-курсу 0,9160; USD 80,7220 руб., EUR 88,1234 руб.)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, stdout, stderr := runCLI(tt.args...)
+			if code != exitOK {
+				t.Fatalf("run() = %d, want %d; stderr: %s", code, exitOK, stderr)
+			}
+			if stdout != tt.want {
+				t.Errorf("stdout = %q, want %q", stdout, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_ConvertJSON(t *testing.T) {
+	setTestRates(t)
+
+	code, stdout, stderr := runCLI("convert", "1000", "USD", "--date", "20.12.2025", "--format", "json")
+	if code != exitOK {
+		t.Fatalf("run() = %d, want %d; stderr: %s", code, exitOK, stderr)
+	}
+
+	var got struct {
+		SourceCurrency string  `json:"sourceCurrency"`
+		TargetCurrency string  `json:"targetCurrency"`
+		TargetAmount   float64 `json:"targetAmount"`
+		Rate           float64 `json:"rate"`
+		Date           string  `json:"date"`
+		AmountInWords  string  `json:"amountInWords"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("Некорректный JSON: %v\n%s", err, stdout)
+	}
+
+	if got.SourceCurrency != "USD" || got.TargetCurrency != "RUB" {
+		t.Errorf("Валюты = %s → %s, want USD → RUB", got.SourceCurrency, got.TargetCurrency)
+	}
+	if got.TargetAmount != 80722 || got.Rate != 80.722 || got.Date != "20.12.2025" {
+		t.Errorf("Результат = %v по курсу %v на %s, want 80722 по курсу 80.722 на 20.12.2025", got.TargetAmount, got.Rate, got.Date)
+	}
+	if got.AmountInWords != "Восемьдесят тысяч семьсот двадцать два рубля 00 копеек" {
+		t.Errorf("AmountInWords = %q", got.AmountInWords)
+	}
+}
+
+func TestRun_ConvertCSV(t *testing.T) {
+	setTestRates(t)
+
+	code, stdout, stderr := runCLI("convert", "1000", "USD", "--date", "20.12.2025", "--format", "csv")
+	if code != exitOK {
+		t.Fatalf("run() = %d, want %d; stderr: %s", code, exitOK, stderr)
+	}
+
+	want := "source_currency,target_currency,source_amount,target_amount,rate,date\n" +
+		"USD,RUB,1000,80722.00,80.7220,20.12.2025\n"
+	if stdout != want {
+		t.Errorf("stdout = %q, want %q", stdout, want)
+	}
+}
+
+func TestRun_Rate(t *testing.T) {
+	setTestRates(t)
+
+	// На воскресенье действует курс субботы: выводится фактическая дата курса
+	code, stdout, stderr := runCLI("rate", "JPY", "--date", "21.12.2025", "--format", "csv")
+	if code != exitOK {
+		t.Fatalf("run() = %d, want %d; stderr: %s", code, exitOK, stderr)
+	}
+
+	// Курс приведен к единице валюты, как в GetRate
+	want := "date,currency,nominal,rate\n20.12.2025,JPY,1,0.512345\n"
+	if stdout != want {
+		t.Errorf("stdout = %q, want %q", stdout, want)
+	}
+}
+
+func TestRun_Rates(t *testing.T) {
+	setTestRates(t)
+
+	code, stdout, stderr := runCLI("rates", "--date", "20.12.2025")
+	if code != exitOK {
+		t.Fatalf("run() = %d, want %d; stderr: %s", code, exitOK, stderr)
+	}
+
+	lines := strings.Split(strings.TrimRight(stdout, "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Строк = %d, want 4 (заголовок + 3 валюты):\n%s", len(lines), stdout)
+	}
+	if lines[0] != "Курсы ЦБ РФ на 20.12.2025" {
+		t.Errorf("Заголовок = %q", lines[0])
+	}
+	// Валюты отсортированы по коду, номинал - как в XML ЦБ РФ
+	for i, prefix := range []string{"EUR  1    88,1234", "JPY  100  51,2345", "USD  1    80,7220"} {
+		if !strings.HasPrefix(lines[i+1], prefix) {
+			t.Errorf("Строка %d = %q, want prefix %q", i+1, lines[i+1], prefix)
+		}
+	}
+}
+
+func TestRun_RatesJSON(t *testing.T) {
+	setTestRates(t)
+
+	code, stdout, stderr := runCLI("rates", "--date", "20.12.2025", "--format", "json")
+	if code != exitOK {
+		t.Fatalf("run() = %d, want %d; stderr: %s", code, exitOK, stderr)
+	}
+
+	var got struct {
+		Date  string `json:"date"`
+		Rates []struct {
+			Currency string  `json:"currency"`
+			Nominal  int     `json:"nominal"`
+			Rate     float64 `json:"rate"`
+		} `json:"rates"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("Некорректный JSON: %v\n%s", err, stdout)
+	}
+	if got.Date != "20.12.2025" || len(got.Rates) != 3 {
+		t.Fatalf("date = %s, len(rates) = %d, want 20.12.2025 и 3", got.Date, len(got.Rates))
+	}
+	if got.Rates[1].Currency != "JPY" || got.Rates[1].Nominal != 100 || got.Rates[1].Rate != 51.2345 {
+		t.Errorf("rates[1] = %+v, want JPY 100 51.2345", got.Rates[1])
+	}
+}
+
+func TestRun_ConvertMetal(t *testing.T) {
+	setTestRates(t)
+	setTestMetalRates(t)
+
+	code, stdout, stderr := runCLI("convert", "100", "XAU", "--date", "20.12.2025")
+	if code != exitOK {
+		t.Fatalf("run() = %d, want %d; stderr: %s", code, exitOK, stderr)
+	}
+	if want := "1 071 204,00 руб. (100,00 XAU по курсу 10712,0400)\n"; stdout != want {
+		t.Errorf("stdout = %q, want %q", stdout, want)
+	}
+
+	// Граммы золота в доллары по курсам из одного снимка
+	code, stdout, stderr = runCLI("convert", "1", "XAU", "--to", "USD", "--date", "20.12.2025")
+	if code != exitOK {
+		t.Fatalf("run() = %d, want %d; stderr: %s", code, exitOK, stderr)
+	}
+	if !strings.HasPrefix(stdout, "$132,70 (1,00 XAU по кросс-курсу 132,7029") {
+		t.Errorf("stdout = %q", stdout)
+	}
+}
+
+func TestRun_SourceECB(t *testing.T) {
+	setTestRates(t)
+	original := fetchECBRates
+	fetchECBRates = func(_ context.Context, _ time.Time) (*models.RateData, error) {
+		rateData := models.NewRateData(testRateDate)
+		rateData.Base = models.EUR
+		rateData.AddRate(models.ExchangeRate{Currency: models.USD, Rate: models.MustParseDecimal("0.8531"), Nominal: 1, Date: testRateDate})
+		return rateData, nil
+	}
+	t.Cleanup(func() {
+		fetchECBRates = original
+	})
+
+	code, stdout, stderr := runCLI("convert", "1000", "USD", "--to", "EUR", "--date", "20.12.2025", "--source", "ecb")
+	if code != exitOK {
+		t.Fatalf("run() = %d, want %d; stderr: %s", code, exitOK, stderr)
+	}
+	if !strings.HasPrefix(stdout, "€853,10 ($1 000,00 по кросс-курсу 0,8531") {
+		t.Errorf("stdout = %q", stdout)
+	}
+
+	// Заголовок с базовой валютой ЕЦБ
+	code, stdout, stderr = runCLI("rates", "--date", "20.12.2025", "--source", "ecb")
+	if code != exitOK {
+		t.Fatalf("run() = %d, want %d; stderr: %s", code, exitOK, stderr)
+	}
+	if first, _, _ := strings.Cut(stdout, "\n"); first != "Курсы ЕЦБ на 20.12.2025 (в EUR)" {
+		t.Errorf("Заголовок = %q", first)
+	}
+
+	// Валюты проверяются по справочнику источника: у ЕЦБ нет рубля, у ЦБ РФ - исландской кроны
+	for _, args := range [][]string{
+		{"convert", "1000", "USD", "--date", "20.12.2025", "--source", "ecb"},
+		{"convert", "1000", "ISK", "--date", "20.12.2025"},
+	} {
+		if code, _, stderr := runCLI(args...); code != exitUnsupportedCurrency {
+			t.Errorf("run(%q) = %d, want %d; stderr: %s", args, code, exitUnsupportedCurrency, stderr)
+		}
+	}
+}
+
+func TestRun_ExitCodes(t *testing.T) {
+	future := time.Now().AddDate(0, 0, 2).Format(dateLayout)
+
+	tests := []struct {
+		name     string
+		fetchErr error
+		args     []string
+		wantCode int
+	}{
+		{"Без аргументов", nil, nil, exitUsage},
+		{"Справка", nil, []string{"help"}, exitOK},
+		{"Справка команды", nil, []string{"convert", "-h"}, exitOK},
+		{"Неизвестная команда", nil, []string{"exchange"}, exitUsage},
+		{"Неизвестный флаг", nil, []string{"rate", "USD", "--bank", "ecb"}, exitUsage},
+		{"Не хватает аргументов", nil, []string{"convert", "1000"}, exitUsage},
+		{"Неизвестный формат", nil, []string{"rates", "--format", "xml"}, exitUsage},
+		{"Неизвестный источник", nil, []string{"rates", "--source", "fed"}, exitUsage},
+		{"Неверный формат даты", nil, []string{"rate", "USD", "--date", "2025-12-20"}, exitUsage},
+		{"Сумма не число", nil, []string{"convert", "abc", "USD"}, exitInvalidAmount},
+		{"Нулевая сумма", nil, []string{"convert", "0", "USD", "--date", "20.12.2025"}, exitInvalidAmount},
+		{"Дата в будущем", nil, []string{"convert", "1000", "USD", "--date", future}, exitDateInFuture},
+		{"Дата в будущем для rates", nil, []string{"rates", "--date", future}, exitDateInFuture},
+		{"Неподдерживаемая валюта", nil, []string{"rate", "XYZ"}, exitUnsupportedCurrency},
+		{"Курс не опубликован", nil, []string{"rate", "CNY", "--date", "20.12.2025"}, exitRateNotFound},
+		{"ЦБ РФ недоступен", fmt.Errorf("%w: timeout", parser.ErrMaxRetries), []string{"rate", "USD", "--date", "20.12.2025"}, exitNetwork},
+		{"Circuit breaker открыт", fmt.Errorf("%w: circuit breaker open", parser.ErrSourceUnavailable), []string{"rate", "USD", "--date", "20.12.2025"}, exitNetwork},
+		{"Лишний аргумент serve", nil, []string{"serve", "extra"}, exitUsage},
+		{"Некорректный адрес serve", nil, []string{"serve", "--addr", "127.0.0.1:99999"}, exitError},
+		{"Прочая ошибка", fmt.Errorf("%w: bad", parser.ErrInvalidXML), []string{"rates", "--date", "20.12.2025"}, exitError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.fetchErr != nil {
+				setTestFetchRates(t, func(_ context.Context, _ time.Time) (*models.RateData, error) {
+					return nil, tt.fetchErr
+				})
+			} else {
+				setTestRates(t)
+			}
+
+			code, _, stderr := runCLI(tt.args...)
+			if code != tt.wantCode {
+				t.Errorf("run(%v) = %d, want %d; stderr: %s", tt.args, code, tt.wantCode, stderr)
+			}
+			if tt.wantCode != exitOK && stderr == "" {
+				t.Error("При ошибке stderr не должен быть пустым")
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ function initApp() {
             initCalendar(); // Сначала календарь
             initDateInput(); // Затем поле даты (вызывает updateRatePreview)
             initCurrencySelection();
+            initSourceSelection();
             initAmountInput();
             initConvertButton();
             initCopyButton();
@@ -201,6 +202,72 @@ function initCurrencySelection() {
     });
 }
 
+/**
+ * Инициализация переключателя источника курса (ЦБ РФ / ЕЦБ)
+ * Переключатель скрывается, если приложение не подключило справочные курсы ЕЦБ
+ */
+async function initSourceSelection() {
+    const sourceSwitch = document.getElementById('source-switch');
+    if (!sourceSwitch) return;
+
+    const targetGroup = document.getElementById('target-group');
+
+    document.querySelectorAll('input[name="source"]').forEach(input => {
+        input.addEventListener('change', () => {
+            document.getElementById('result-card')?.classList.add('hidden');
+            // Валюта результата выбирается только для ЕЦБ: ЦБ РФ конвертирует в рубли
+            targetGroup?.classList.toggle('hidden', getSelectedSource() === 'cbr');
+            updateRatePreview();
+        });
+    });
+
+    document.getElementById('target-select')?.addEventListener('change', () => {
+        document.getElementById('result-card')?.classList.add('hidden');
+    });
+
+    try {
+        const sources = typeof appInstance.GetSources === 'function' ? await appInstance.GetSources() : [];
+        if (!sources.includes('ecb')) {
+            sourceSwitch.classList.add('hidden');
+        }
+    } catch (error) {
+        console.warn('GetSources error:', error);
+        sourceSwitch.classList.add('hidden');
+    }
+}
+
+/**
+ * Возвращает выбранный источник курса: 'cbr' (по умолчанию) или 'ecb'
+ */
+function getSelectedSource() {
+    const sourceInput = document.querySelector('input[name="source"]:checked');
+    return sourceInput ? sourceInput.value : 'cbr';
+}
+
+/**
+ * Возвращает валюту результата для справочного курса ЕЦБ (по умолчанию EUR)
+ */
+function getTargetCurrency() {
+    const targetSelect = document.getElementById('target-select');
+    return targetSelect && targetSelect.value ? targetSelect.value : 'EUR';
+}
+
+/**
+ * Возвращает символ базовой валюты курса для live preview
+ */
+function baseSymbol(base) {
+    switch (base) {
+        case 'EUR':
+            return '€';
+        case 'RUB':
+        case '':
+        case undefined:
+            return '₽';
+        default:
+            return base;
+    }
+}
+
 /**
  * Инициализация поля ввода суммы
  */
@@ -258,6 +325,24 @@ function initCopyButton() {
             showError('Не удалось скопировать в буфер обмена');
         }
     });
+
+    const copyWordsBtn = document.getElementById('copy-words-btn');
+    if (!copyWordsBtn) return;
+
+    copyWordsBtn.addEventListener('click', async () => {
+        const resultWords = document.getElementById('result-words');
+        if (!resultWords || !resultWords.textContent) {
+            showWarning('Нет суммы прописью для копирования');
+            return;
+        }
+
+        const success = await copyToClipboard(resultWords.textContent);
+        if (success) {
+            showSuccess('Сумма прописью скопирована в буфер обмена', 2000);
+        } else {
+            showError('Не удалось скопировать в буфер обмена');
+        }
+    });
 }
 
 /**
@@ -360,8 +445,9 @@ async function performConvert() {
         return;
     }
     
-    // Получаем валюту
+    // Получаем валюту и источник курса
     const currency = currencyInput.value;
+    const source = getSelectedSource();
     
     // Блокируем кнопку
     convertBtn.disabled = true;
@@ -370,41 +456,71 @@ async function performConvert() {
     
     try {
         // Вызываем метод Go через Wails bindings
-        const response = await appInstance.Convert({
-            amount: amount,
-            currency: currency,
-            date: dateStr
-        });
+        // ЕЦБ не публикует курс рубля: по его курсам сумма конвертируется в выбранную валюту результата
+        const response = source === 'cbr'
+            ? await appInstance.Convert({
+                amount: amount,
+                currency: currency,
+                date: dateStr,
+                source: source
+            })
+            : await appInstance.ConvertCross({
+                amount: amount,
+                from: currency,
+                to: getTargetCurrency(),
+                date: dateStr,
+                source: source
+            });
         
         if (response.success) {
             // Храним полную строку результата для копирования
             resultText.textContent = response.result;
 
+            // Сумма прописью (для счетов и актов)
+            const resultWordsEl = document.getElementById('result-words');
+            if (resultWordsEl) {
+                resultWordsEl.textContent = response.amountInWords || '';
+            }
+
             // Заполняем улучшенный UI результата (если элементы доступны)
             const resultAmountEl = document.getElementById('result-amount');
             const resultMetaEl = document.getElementById('result-meta');
 
             if (resultAmountEl && resultMetaEl) {
-                const amountRub = typeof response.targetAmountRUB === 'number'
-                    ? response.targetAmountRUB
-                    : NaN;
-                const rate = typeof response.rate === 'number' ? response.rate : NaN;
                 const srcAmount = typeof response.sourceAmount === 'number' ? response.sourceAmount : NaN;
-                const symbol = response.currencySymbol || '';
                 const requestedDate = response.requestedDate || '';
                 const actualDate = response.actualDate || '';
 
-                resultAmountEl.textContent = `${formatNumber(amountRub, 2)} ₽`;
-
                 const dateLine = (actualDate && requestedDate && actualDate !== requestedDate)
                     ? `Курс фактически за ${actualDate} (запрошено ${requestedDate})`
                     : (actualDate ? `Курс за ${actualDate}` : '');
 
-                resultMetaEl.textContent = `${dateLine}${dateLine ? ' · ' : ''}${symbol}${formatNumber(srcAmount, 2)} по курсу ${formatNumber(rate, 4)} ₽`;
+                if (source === 'cbr') {
+                    const amountRub = typeof response.targetAmountRUB === 'number'
+                        ? response.targetAmountRUB
+                        : NaN;
+                    const rate = typeof response.rate === 'number' ? response.rate : NaN;
+                    const symbol = response.currencySymbol || '';
+
+                    resultAmountEl.textContent = `${formatNumber(amountRub, 2)} ₽`;
+                    resultMetaEl.textContent = `${dateLine}${dateLine ? ' · ' : ''}${symbol}${formatNumber(srcAmount, 2)} по курсу ${formatNumber(rate, 4)} ₽`;
+                } else {
+                    const targetAmount = typeof response.targetAmount === 'number' ? response.targetAmount : NaN;
+                    const crossRate = typeof response.crossRate === 'number' ? response.crossRate : NaN;
+
+                    resultAmountEl.textContent = `${formatNumber(targetAmount, 2)} ${response.targetSymbol || ''}`;
+                    resultMetaEl.textContent = `${dateLine}${dateLine ? ' · ' : ''}${response.sourceSymbol || ''}${formatNumber(srcAmount, 2)} по курсу ЕЦБ ${formatNumber(crossRate, 4)}`;
+                }
             }
 
             resultCard.classList.remove('hidden');
-            showSuccess('Конвертация выполнена успешно', 2000);
+            if (response.stale) {
+                // Курс из истекшего кэша: источник недоступен, свежие курсы загружаются в фоне
+                const sourceTitle = source === 'cbr' ? 'ЦБ РФ' : 'ЕЦБ';
+                showWarning(`${sourceTitle} недоступен: курс из кэша, данные могут быть устаревшими`);
+            } else {
+                showSuccess('Конвертация выполнена успешно', 2000);
+            }
         } else {
             showError(response.error || 'Ошибка конвертации');
             resultCard.classList.add('hidden');
@@ -456,7 +572,7 @@ const updateRatePreview = debounce(async () => {
     const currentRequestId = ++ratePreviewRequestId;
 
     try {
-        const response = await appInstance.GetRate(currency, dateStr);
+        const response = await appInstance.GetRate(currency, dateStr, getSelectedSource());
 
         // Отбрасываем устаревший ответ, если за время ожидания был отправлен новый запрос
         if (currentRequestId !== ratePreviewRequestId) {
@@ -464,7 +580,7 @@ const updateRatePreview = debounce(async () => {
         }
 
         if (response.success) {
-            rateValue.textContent = `${formatNumber(response.rate, 4)} ₽`;
+            rateValue.textContent = `${formatNumber(response.rate, 4)} ${baseSymbol(response.base)}`;
             // ratePreview всегда видим (display: flex в CSS), не нужно менять display
         } else {
             hideRatePreview(); // Показывает '—' вместо скрытия
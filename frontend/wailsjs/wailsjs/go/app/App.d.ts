@@ -5,7 +5,17 @@ import {context} from '../models';
 
 export function Convert(arg1:app.ConvertRequest):Promise<app.ConvertResponse>;
 
-export function GetRate(arg1:string,arg2:string):Promise<app.RateResponse>;
+export function ConvertCross(arg1:app.CrossConvertRequest):Promise<app.CrossConvertResponse>;
+
+export function GetKeyRate(arg1:string):Promise<app.KeyRateResponse>;
+
+export function GetKeyRateHistory(arg1:string,arg2:string):Promise<app.KeyRateHistoryResponse>;
+
+export function GetRate(arg1:string,arg2:string,arg3:string):Promise<app.RateResponse>;
+
+export function GetRateHistory(arg1:string,arg2:string,arg3:string,arg4:string):Promise<app.RateHistoryResponse>;
+
+export function GetSources():Promise<Array<string>>;
 
 export function SendStar():Promise<app.SendStarResponse>;
 
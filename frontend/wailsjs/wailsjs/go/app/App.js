@@ -6,8 +6,28 @@ export function Convert(arg1) {
   return window['go']['app']['App']['Convert'](arg1);
 }
 
-export function GetRate(arg1, arg2) {
-  return window['go']['app']['App']['GetRate'](arg1, arg2);
+export function ConvertCross(arg1) {
+  return window['go']['app']['App']['ConvertCross'](arg1);
+}
+
+export function GetKeyRate(arg1) {
+  return window['go']['app']['App']['GetKeyRate'](arg1);
+}
+
+export function GetKeyRateHistory(arg1, arg2) {
+  return window['go']['app']['App']['GetKeyRateHistory'](arg1, arg2);
+}
+
+export function GetRate(arg1, arg2, arg3) {
+  return window['go']['app']['App']['GetRate'](arg1, arg2, arg3);
+}
+
+export function GetRateHistory(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['GetRateHistory'](arg1, arg2, arg3, arg4);
+}
+
+export function GetSources() {
+  return window['go']['app']['App']['GetSources']();
 }
 
 export function SendStar() {
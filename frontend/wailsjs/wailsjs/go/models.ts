@@ -4,6 +4,10 @@ export namespace app {
 	    amount: number;
 	    currency: string;
 	    date: string;
+	    direction: string;
+	    rounding: string;
+	    places?: number;
+	    source: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new ConvertRequest(source);
@@ -14,6 +18,10 @@ export namespace app {
 	        this.amount = source["amount"];
 	        this.currency = source["currency"];
 	        this.date = source["date"];
+	        this.direction = source["direction"];
+	        this.rounding = source["rounding"];
+	        this.places = source["places"];
+	        this.source = source["source"];
 	    }
 	}
 	export class ConvertResponse {
@@ -21,12 +29,17 @@ export namespace app {
 	    result: string;
 	    error: string;
 	    sourceAmount: number;
+	    targetAmount: number;
 	    targetAmountRUB: number;
 	    rate: number;
 	    currency: string;
 	    currencySymbol: string;
+	    direction: string;
 	    requestedDate: string;
 	    actualDate: string;
+	    amountInWords: string;
+	    stale: boolean;
+	    source: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new ConvertResponse(source);
@@ -38,17 +51,225 @@ export namespace app {
 	        this.result = source["result"];
 	        this.error = source["error"];
 	        this.sourceAmount = source["sourceAmount"];
+	        this.targetAmount = source["targetAmount"];
 	        this.targetAmountRUB = source["targetAmountRUB"];
 	        this.rate = source["rate"];
 	        this.currency = source["currency"];
 	        this.currencySymbol = source["currencySymbol"];
+	        this.direction = source["direction"];
 	        this.requestedDate = source["requestedDate"];
 	        this.actualDate = source["actualDate"];
+	        this.amountInWords = source["amountInWords"];
+	        this.stale = source["stale"];
+	        this.source = source["source"];
 	    }
 	}
+	export class CrossConvertRequest {
+	    amount: number;
+	    from: string;
+	    to: string;
+	    date: string;
+	    rounding: string;
+	    places?: number;
+	    source: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CrossConvertRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.amount = source["amount"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.date = source["date"];
+	        this.rounding = source["rounding"];
+	        this.places = source["places"];
+	        this.source = source["source"];
+	    }
+	}
+	export class CrossConvertResponse {
+	    success: boolean;
+	    result: string;
+	    error: string;
+	    sourceAmount: number;
+	    targetAmount: number;
+	    sourceCurrency: string;
+	    targetCurrency: string;
+	    sourceSymbol: string;
+	    targetSymbol: string;
+	    crossRate: number;
+	    sourceRate: number;
+	    targetRate: number;
+	    requestedDate: string;
+	    actualDate: string;
+	    amountInWords: string;
+	    stale: boolean;
+	    source: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CrossConvertResponse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.result = source["result"];
+	        this.error = source["error"];
+	        this.sourceAmount = source["sourceAmount"];
+	        this.targetAmount = source["targetAmount"];
+	        this.sourceCurrency = source["sourceCurrency"];
+	        this.targetCurrency = source["targetCurrency"];
+	        this.sourceSymbol = source["sourceSymbol"];
+	        this.targetSymbol = source["targetSymbol"];
+	        this.crossRate = source["crossRate"];
+	        this.sourceRate = source["sourceRate"];
+	        this.targetRate = source["targetRate"];
+	        this.requestedDate = source["requestedDate"];
+	        this.actualDate = source["actualDate"];
+	        this.amountInWords = source["amountInWords"];
+	        this.stale = source["stale"];
+	        this.source = source["source"];
+	    }
+	}
+	export class KeyRatePoint {
+	    date: string;
+	    rate: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new KeyRatePoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.rate = source["rate"];
+	    }
+	}
+	export class KeyRateHistoryResponse {
+	    success: boolean;
+	    error: string;
+	    from: string;
+	    to: string;
+	    points: KeyRatePoint[];
+	
+	    static createFrom(source: any = {}) {
+	        return new KeyRateHistoryResponse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.error = source["error"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.points = this.convertValues(source["points"], KeyRatePoint);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class KeyRateResponse {
+	    success: boolean;
+	    error: string;
+	    date: string;
+	    rate: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new KeyRateResponse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.error = source["error"];
+	        this.date = source["date"];
+	        this.rate = source["rate"];
+	    }
+	}
+	export class RateHistoryPoint {
+	    date: string;
+	    rate: number;
+	    change: number;
+	    changePercent: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RateHistoryPoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.rate = source["rate"];
+	        this.change = source["change"];
+	        this.changePercent = source["changePercent"];
+	    }
+	}
+	export class RateHistoryResponse {
+	    success: boolean;
+	    error: string;
+	    currency: string;
+	    from: string;
+	    to: string;
+	    points: RateHistoryPoint[];
+	    min: number;
+	    max: number;
+	    average: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RateHistoryResponse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.error = source["error"];
+	        this.currency = source["currency"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.points = this.convertValues(source["points"], RateHistoryPoint);
+	        this.min = source["min"];
+	        this.max = source["max"];
+	        this.average = source["average"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class RateResponse {
 	    success: boolean;
 	    rate: number;
+	    base: string;
 	    error: string;
 	
 	    static createFrom(source: any = {}) {
@@ -59,6 +280,7 @@ export namespace app {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.success = source["success"];
 	        this.rate = source["rate"];
+	        this.base = source["base"];
 	        this.error = source["error"];
 	    }
 	}
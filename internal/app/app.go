@@ -4,31 +4,83 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/bivlked/currate-go/internal/converter"
 	"github.com/bivlked/currate-go/internal/models"
+	"github.com/bivlked/currate-go/internal/parser"
 	"github.com/bivlked/currate-go/internal/telegram"
 )
 
 // AppVersion - версия приложения
 const AppVersion = "1.2.0"
 
+// DefaultSource - имя основного источника курсов (конвертер из NewApp)
+const DefaultSource = "cbr"
+
+// Ошибки App
+var (
+	ErrUnknownSource = errors.New("неизвестный источник курсов")
+)
+
 // App - основной backend для GUI приложения
 // Предоставляет методы для взаимодействия с frontend через Wails bindings
 type App struct {
 	ctx       context.Context
 	converter *converter.Converter
+	sources   map[string]*converter.Converter // Дополнительные источники курсов (поле source запроса)
+	keyRates  *converter.KeyRates             // Ключевая ставка ЦБ РФ (nil - не настроена)
+}
+
+// Option - настройка App при создании
+type Option func(*App)
+
+// WithSource добавляет источник курсов, который frontend выбирает полем source запроса
+// Например, справочные курсы ЕЦБ для сверки с европейскими контрагентами
+//
+// Пример использования:
+//
+//	ecb := converter.NewConverter(parser.NewECBProvider(models.EUR), cache.NewLRUCache(1000, time.Hour))
+//	appInstance := app.NewApp(conv, app.WithSource("ecb", ecb))
+func WithSource(name string, conv *converter.Converter) Option {
+	if conv == nil {
+		panic("WithSource: converter must not be nil")
+	}
+	return func(a *App) {
+		a.sources[name] = conv
+	}
+}
+
+// WithKeyRates подключает ключевую ставку ЦБ РФ для GetKeyRate и GetKeyRateHistory
+//
+// Пример использования:
+//
+//	keyRates := converter.NewKeyRates(parser.NewSOAPClient(parser.CBRSOAPURL))
+//	appInstance := app.NewApp(conv, app.WithKeyRates(keyRates))
+func WithKeyRates(keyRates *converter.KeyRates) Option {
+	if keyRates == nil {
+		panic("WithKeyRates: key rates must not be nil")
+	}
+	return func(a *App) {
+		a.keyRates = keyRates
+	}
 }
 
 // NewApp создает новый экземпляр App
-func NewApp(conv *converter.Converter) *App {
+// conv - основной источник курсов (DefaultSource)
+func NewApp(conv *converter.Converter, opts ...Option) *App {
 	if conv == nil {
 		panic("NewApp: converter must not be nil")
 	}
-	return &App{
+	a := &App{
 		converter: conv,
+		sources:   make(map[string]*converter.Converter),
+	}
+	for _, opt := range opts {
+		opt(a)
 	}
+	return a
 }
 
 // Startup вызывается при запуске приложения (из OnStartup в main_gui.go)
@@ -43,9 +95,13 @@ func (a *App) Startup(ctx context.Context) {
 
 // ConvertRequest - запрос на конвертацию из JavaScript
 type ConvertRequest struct {
-	Amount   float64 `json:"amount"`   // Сумма для конвертации
-	Currency string  `json:"currency"` // "USD", "EUR" или "RUB"
-	Date     string  `json:"date"`     // "DD.MM.YYYY"
+	Amount    float64 `json:"amount"`           // Сумма для конвертации
+	Currency  string  `json:"currency"`         // Код валюты ЦБ РФ: "USD", "EUR", "CNY", "RUB" и т.д.
+	Date      string  `json:"date"`             // "DD.MM.YYYY"
+	Direction string  `json:"direction"`        // "toRUB" (по умолчанию) или "fromRUB"
+	Rounding  string  `json:"rounding"`         // "halfUp" (по умолчанию), "halfEven", "down" или "up"
+	Places    *int    `json:"places,omitempty"` // Знаков после запятой в результате (по умолчанию 2, 0 - до целых)
+	Source    string  `json:"source"`           // Источник курсов: "cbr" (по умолчанию) или добавленный через WithSource
 }
 
 // ConvertResponse - ответ на конвертацию для JavaScript
@@ -56,21 +112,107 @@ type ConvertResponse struct {
 
 	// Дополнительные поля для более богатого UI
 	SourceAmount    float64 `json:"sourceAmount"`
-	TargetAmountRUB float64 `json:"targetAmountRUB"`
+	TargetAmount    float64 `json:"targetAmount"`    // Результат в целевой валюте
+	TargetAmountRUB float64 `json:"targetAmountRUB"` // Сумма в рублях (при fromRUB совпадает с sourceAmount)
 	Rate            float64 `json:"rate"`
-	Currency        string  `json:"currency"`
+	Currency        string  `json:"currency"` // Иностранная валюта (в обоих направлениях)
 	CurrencySymbol  string  `json:"currencySymbol"`
+	Direction       string  `json:"direction"`
 	RequestedDate   string  `json:"requestedDate"`
 	ActualDate      string  `json:"actualDate"`
+	AmountInWords   string  `json:"amountInWords"` // Результат прописью для счетов и актов
+	Stale           bool    `json:"stale"`         // Курс из устаревшего кэша: ЦБ РФ недоступен, данные могут быть неактуальны
+	Source          string  `json:"source"`        // Источник курса: "cbr-xml", "file" и т.д. (пусто - не указан)
+}
+
+// CrossConvertRequest - запрос на кросс-конвертацию (валюта → валюта) из JavaScript
+type CrossConvertRequest struct {
+	Amount float64 `json:"amount"` // Сумма в исходной валюте
+	From   string  `json:"from"`   // Исходная валюта: "USD", "CNY" и т.д.
+	To     string  `json:"to"`     // Целевая валюта: "EUR", "KZT" и т.д.
+	Date   string  `json:"date"`   // "DD.MM.YYYY"
+
+	Rounding string `json:"rounding"`         // Режим округления, как в ConvertRequest
+	Places   *int   `json:"places,omitempty"` // Знаков после запятой в результате
+	Source   string `json:"source"`           // Источник курсов, как в ConvertRequest
+}
+
+// CrossConvertResponse - ответ на кросс-конвертацию для JavaScript
+type CrossConvertResponse struct {
+	Success bool   `json:"success"` // Успешность операции
+	Result  string `json:"result"`  // Отформатированный результат
+	Error   string `json:"error"`   // Сообщение об ошибке (если success=false)
+
+	SourceAmount   float64 `json:"sourceAmount"`
+	TargetAmount   float64 `json:"targetAmount"`
+	SourceCurrency string  `json:"sourceCurrency"`
+	TargetCurrency string  `json:"targetCurrency"`
+	SourceSymbol   string  `json:"sourceSymbol"`
+	TargetSymbol   string  `json:"targetSymbol"`
+	CrossRate      float64 `json:"crossRate"`  // Единиц целевой валюты за единицу исходной
+	SourceRate     float64 `json:"sourceRate"` // Рублей за единицу исходной валюты
+	TargetRate     float64 `json:"targetRate"` // Рублей за единицу целевой валюты
+	RequestedDate  string  `json:"requestedDate"`
+	ActualDate     string  `json:"actualDate"`
+	AmountInWords  string  `json:"amountInWords"` // Результат прописью в целевой валюте
+	Stale          bool    `json:"stale"`         // Курсы из устаревшего кэша: ЦБ РФ недоступен, данные могут быть неактуальны
+	Source         string  `json:"source"`        // Источник курсов (как в ConvertResponse)
 }
 
 // RateResponse - ответ для получения курса (live preview)
 type RateResponse struct {
 	Success bool    `json:"success"` // Успешность операции
-	Rate    float64 `json:"rate"`    // Курс валюты (если success=true)
+	Rate    float64 `json:"rate"`    // Курс валюты (если success=true) в базовой валюте источника
+	Base    string  `json:"base"`    // Базовая валюта курса: "RUB" для ЦБ РФ, "EUR" для ЕЦБ
 	Error   string  `json:"error"`   // Сообщение об ошибке (если success=false)
 }
 
+// RateHistoryPoint - точка истории курса для графика
+type RateHistoryPoint struct {
+	Date          string  `json:"date"`          // "DD.MM.YYYY" - дата установления курса ЦБ РФ
+	Rate          float64 `json:"rate"`          // Рублей за единицу валюты
+	Change        float64 `json:"change"`        // Изменение к предыдущей точке
+	ChangePercent float64 `json:"changePercent"` // Изменение в процентах
+}
+
+// RateHistoryResponse - ответ с историей курса за период для JavaScript
+type RateHistoryResponse struct {
+	Success bool   `json:"success"` // Успешность операции
+	Error   string `json:"error"`   // Сообщение об ошибке (если success=false)
+
+	Currency string             `json:"currency"`
+	From     string             `json:"from"`
+	To       string             `json:"to"`
+	Points   []RateHistoryPoint `json:"points"` // По возрастанию даты
+	Min      float64            `json:"min"`
+	Max      float64            `json:"max"`
+	Average  float64            `json:"average"`
+}
+
+// KeyRateResponse - ответ с ключевой ставкой на дату для JavaScript
+type KeyRateResponse struct {
+	Success bool    `json:"success"` // Успешность операции
+	Error   string  `json:"error"`   // Сообщение об ошибке (если success=false)
+	Date    string  `json:"date"`    // "DD.MM.YYYY"
+	Rate    float64 `json:"rate"`    // Процентов годовых
+}
+
+// KeyRatePoint - ключевая ставка на один календарный день
+type KeyRatePoint struct {
+	Date string  `json:"date"` // "DD.MM.YYYY"
+	Rate float64 `json:"rate"` // Процентов годовых
+}
+
+// KeyRateHistoryResponse - ответ с ключевой ставкой на каждый день периода для JavaScript
+type KeyRateHistoryResponse struct {
+	Success bool   `json:"success"` // Успешность операции
+	Error   string `json:"error"`   // Сообщение об ошибке (если success=false)
+
+	From   string         `json:"from"`
+	To     string         `json:"to"`
+	Points []KeyRatePoint `json:"points"` // По календарным дням, по возрастанию даты
+}
+
 // Convert конвертирует валюту
 // Вызывается из JavaScript для выполнения конвертации
 func (a *App) Convert(req ConvertRequest) ConvertResponse {
@@ -82,7 +224,7 @@ func (a *App) Convert(req ConvertRequest) ConvertResponse {
 	}
 
 	// Парсим валюту
-	currency, err := models.ParseCurrency(req.Currency)
+	currency, err := a.parseCurrency(req.Source, req.Currency)
 	if err != nil {
 		return ConvertResponse{
 			Success: false,
@@ -90,6 +232,15 @@ func (a *App) Convert(req ConvertRequest) ConvertResponse {
 		}
 	}
 
+	// Парсим направление (пустое значение - валюта → рубли)
+	direction, err := models.ParseDirection(req.Direction)
+	if err != nil {
+		return ConvertResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Неизвестное направление конвертации: %s", req.Direction),
+		}
+	}
+
 	// Парсим дату (формат DD.MM.YYYY)
 	date, err := parseDate(req.Date)
 	if err != nil {
@@ -99,32 +250,141 @@ func (a *App) Convert(req ConvertRequest) ConvertResponse {
 		}
 	}
 
+	// Сумма приходит из JSON как число - переводим в точное десятичное представление
+	amount, err := models.NewDecimalFromFloat(req.Amount)
+	if err != nil {
+		return ConvertResponse{
+			Success: false,
+			Error:   translateError(converter.ErrInvalidAmount),
+		}
+	}
+
+	// Политика округления из запроса (по умолчанию - до копеек, половина от нуля)
+	conv, err := a.converterFor(req.Source, req.Rounding, req.Places)
+	if err != nil {
+		return ConvertResponse{
+			Success: false,
+			Error:   translateError(err),
+		}
+	}
+
 	// Выполняем конвертацию
-	result, err := a.converter.Convert(a.ctx, req.Amount, currency, date)
+	result, err := conv.ConvertDirection(a.ctx, amount, currency, direction, date)
 	if err != nil {
 		// Преобразуем ошибку в понятное сообщение на русском
 		return ConvertResponse{
 			Success: false,
-			Error:   translateError(err),
+			Error:   translateSourceError(err, req.Source),
 		}
 	}
 
+	amountRUB := result.TargetAmount
+	if direction == models.FromRUB {
+		amountRUB = result.SourceAmount
+	}
+
 	return ConvertResponse{
 		Success:         true,
 		Result:          result.FormattedStr,
-		SourceAmount:    result.SourceAmount,
-		TargetAmountRUB: result.TargetAmount,
-		Rate:            result.Rate,
-		Currency:        string(result.SourceCurrency),
-		CurrencySymbol:  result.SourceCurrency.Symbol(),
+		SourceAmount:    result.SourceAmount.Float64(),
+		TargetAmount:    result.TargetAmount.Float64(),
+		TargetAmountRUB: amountRUB.Float64(),
+		Rate:            result.Rate.Float64(),
+		Currency:        string(currency),
+		CurrencySymbol:  currency.Symbol(),
+		Direction:       string(result.Direction),
 		RequestedDate:   req.Date,
 		ActualDate:      result.Date.Format("02.01.2006"),
+		AmountInWords:   result.AmountInWords,
+		Stale:           result.Stale,
+		Source:          result.Source,
+	}
+}
+
+// ConvertCross конвертирует сумму между двумя любыми валютами по кросс-курсу ЦБ РФ
+// Вызывается из JavaScript для конвертации без участия рубля (EUR→USD, CNY→EUR и т.д.)
+func (a *App) ConvertCross(req CrossConvertRequest) CrossConvertResponse {
+	if a.ctx == nil {
+		return CrossConvertResponse{
+			Success: false,
+			Error:   "Приложение не инициализировано",
+		}
+	}
+
+	// Парсим валюты
+	from, err := a.parseCurrency(req.Source, req.From)
+	if err != nil {
+		return CrossConvertResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Неподдерживаемая валюта: %s", req.From),
+		}
+	}
+
+	to, err := a.parseCurrency(req.Source, req.To)
+	if err != nil {
+		return CrossConvertResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Неподдерживаемая валюта: %s", req.To),
+		}
+	}
+
+	// Парсим дату (формат DD.MM.YYYY)
+	date, err := parseDate(req.Date)
+	if err != nil {
+		return CrossConvertResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Неверный формат даты: %s. Используйте формат ДД.ММ.ГГГГ", req.Date),
+		}
+	}
+
+	amount, err := models.NewDecimalFromFloat(req.Amount)
+	if err != nil {
+		return CrossConvertResponse{
+			Success: false,
+			Error:   translateError(converter.ErrInvalidAmount),
+		}
+	}
+
+	conv, err := a.converterFor(req.Source, req.Rounding, req.Places)
+	if err != nil {
+		return CrossConvertResponse{
+			Success: false,
+			Error:   translateError(err),
+		}
+	}
+
+	result, err := conv.ConvertCross(a.ctx, amount, from, to, date)
+	if err != nil {
+		return CrossConvertResponse{
+			Success: false,
+			Error:   translateSourceError(err, req.Source),
+		}
+	}
+
+	return CrossConvertResponse{
+		Success:        true,
+		Result:         result.FormattedStr,
+		SourceAmount:   result.SourceAmount.Float64(),
+		TargetAmount:   result.TargetAmount.Float64(),
+		SourceCurrency: string(from),
+		TargetCurrency: string(to),
+		SourceSymbol:   from.Symbol(),
+		TargetSymbol:   to.Symbol(),
+		CrossRate:      result.Rate.Float64(),
+		SourceRate:     result.SourceRate.Float64(),
+		TargetRate:     result.TargetRate.Float64(),
+		RequestedDate:  req.Date,
+		ActualDate:     result.Date.Format("02.01.2006"),
+		AmountInWords:  result.AmountInWords,
+		Stale:          result.Stale,
+		Source:         result.Source,
 	}
 }
 
 // GetRate получает курс валюты на указанную дату (для live preview)
 // Вызывается из JavaScript при изменении даты для автоматического отображения курса
-func (a *App) GetRate(currencyStr string, dateStr string) RateResponse {
+// source - источник курсов, как в ConvertRequest (пусто - DefaultSource); курс - в базовой валюте источника
+func (a *App) GetRate(currencyStr string, dateStr string, source string) RateResponse {
 	if a.ctx == nil {
 		return RateResponse{
 			Success: false,
@@ -133,7 +393,7 @@ func (a *App) GetRate(currencyStr string, dateStr string) RateResponse {
 	}
 
 	// Парсим валюту
-	currency, err := models.ParseCurrency(currencyStr)
+	currency, err := a.parseCurrency(source, currencyStr)
 	if err != nil {
 		return RateResponse{
 			Success: false,
@@ -150,28 +410,252 @@ func (a *App) GetRate(currencyStr string, dateStr string) RateResponse {
 		}
 	}
 
-	// Для RUB всегда возвращаем 1.0
-	if currency == models.RUB {
+	conv, err := a.converterFor(source, "", nil)
+	if err != nil {
 		return RateResponse{
-			Success: true,
-			Rate:    1.0,
+			Success: false,
+			Error:   translateError(err),
 		}
 	}
 
-	// Используем оптимизированный метод GetRate для получения курса без форматирования
-	// Это избегает лишних вычислений и аллокаций для live preview
-	rate, err := a.converter.GetRate(a.ctx, currency, date)
+	// Получаем курс без форматирования: это избегает лишних вычислений и аллокаций для live preview
+	// Курс - в базовой валюте источника: ЕЦБ не публикует рублевых курсов
+	// Курс самой базовой валюты (RUB для ЦБ РФ, EUR для ЕЦБ) равен 1
+	quote, err := conv.GetRateInBase(a.ctx, currency, date)
 	if err != nil {
 		return RateResponse{
 			Success: false,
-			Error:   translateError(err),
+			Error:   translateSourceError(err, source),
 		}
 	}
 
 	return RateResponse{
 		Success: true,
-		Rate:    rate,
+		Rate:    quote.Rate.Float64(),
+		Base:    string(quote.Base),
+	}
+}
+
+// GetRateHistory получает историю курса валюты за период (для графика курса)
+// Вызывается из JavaScript с датами в формате "DD.MM.YYYY"
+// Курсы за период загружаются одним запросом и кэшируются, поэтому последующие
+// Convert и GetRate на даты периода не обращаются к ЦБ РФ
+// source - источник курсов, как в ConvertRequest (пусто - DefaultSource)
+func (a *App) GetRateHistory(currencyStr string, fromStr string, toStr string, source string) RateHistoryResponse {
+	if a.ctx == nil {
+		return RateHistoryResponse{
+			Success: false,
+			Error:   "Приложение не инициализировано",
+		}
+	}
+
+	currency, err := a.parseCurrency(source, currencyStr)
+	if err != nil {
+		return RateHistoryResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Неподдерживаемая валюта: %s", currencyStr),
+		}
+	}
+
+	from, err := parseDate(fromStr)
+	if err != nil {
+		return RateHistoryResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Неверный формат даты: %s. Используйте формат ДД.ММ.ГГГГ", fromStr),
+		}
+	}
+	to, err := parseDate(toStr)
+	if err != nil {
+		return RateHistoryResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Неверный формат даты: %s. Используйте формат ДД.ММ.ГГГГ", toStr),
+		}
+	}
+
+	conv, err := a.converterFor(source, "", nil)
+	if err != nil {
+		return RateHistoryResponse{
+			Success: false,
+			Error:   translateError(err),
+		}
+	}
+
+	history, err := conv.GetRateHistory(a.ctx, currency, from, to)
+	if err != nil {
+		return RateHistoryResponse{
+			Success: false,
+			Error:   translateSourceError(err, source),
+		}
+	}
+
+	points := make([]RateHistoryPoint, 0, len(history.Points))
+	for _, p := range history.Points {
+		points = append(points, RateHistoryPoint{
+			Date:          p.Date.Format("02.01.2006"),
+			Rate:          p.Rate.Float64(),
+			Change:        p.Change.Float64(),
+			ChangePercent: p.ChangePercent.Float64(),
+		})
+	}
+
+	return RateHistoryResponse{
+		Success:  true,
+		Currency: string(history.Currency),
+		From:     history.From.Format("02.01.2006"),
+		To:       history.To.Format("02.01.2006"),
+		Points:   points,
+		Min:      history.Min.Float64(),
+		Max:      history.Max.Float64(),
+		Average:  history.Average.Float64(),
+	}
+}
+
+// GetKeyRate получает ключевую ставку ЦБ РФ, действующую на дату
+// Вызывается из JavaScript с датой в формате "DD.MM.YYYY"
+func (a *App) GetKeyRate(dateStr string) KeyRateResponse {
+	if a.ctx == nil {
+		return KeyRateResponse{
+			Success: false,
+			Error:   "Приложение не инициализировано",
+		}
+	}
+	if a.keyRates == nil {
+		return KeyRateResponse{
+			Success: false,
+			Error:   translateError(converter.ErrNilKeyRateProvider),
+		}
+	}
+
+	date, err := parseDate(dateStr)
+	if err != nil {
+		return KeyRateResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Неверный формат даты: %s. Используйте формат ДД.ММ.ГГГГ", dateStr),
+		}
+	}
+
+	rate, err := a.keyRates.KeyRate(a.ctx, date)
+	if err != nil {
+		return KeyRateResponse{
+			Success: false,
+			Error:   translateError(err),
+		}
+	}
+
+	return KeyRateResponse{
+		Success: true,
+		Date:    rate.Date.Format("02.01.2006"),
+		Rate:    rate.Rate.Float64(),
+	}
+}
+
+// GetKeyRateHistory получает ключевую ставку ЦБ РФ на каждый календарный день периода
+// (для расчета неустойки); ставки кэшируются, как курсы валют
+// Вызывается из JavaScript с датами в формате "DD.MM.YYYY"
+func (a *App) GetKeyRateHistory(fromStr string, toStr string) KeyRateHistoryResponse {
+	if a.ctx == nil {
+		return KeyRateHistoryResponse{
+			Success: false,
+			Error:   "Приложение не инициализировано",
+		}
+	}
+	if a.keyRates == nil {
+		return KeyRateHistoryResponse{
+			Success: false,
+			Error:   translateError(converter.ErrNilKeyRateProvider),
+		}
+	}
+
+	from, err := parseDate(fromStr)
+	if err != nil {
+		return KeyRateHistoryResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Неверный формат даты: %s. Используйте формат ДД.ММ.ГГГГ", fromStr),
+		}
+	}
+	to, err := parseDate(toStr)
+	if err != nil {
+		return KeyRateHistoryResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Неверный формат даты: %s. Используйте формат ДД.ММ.ГГГГ", toStr),
+		}
+	}
+
+	series, err := a.keyRates.KeyRateSeries(a.ctx, from, to)
+	if err != nil {
+		return KeyRateHistoryResponse{
+			Success: false,
+			Error:   translateError(err),
+		}
+	}
+
+	points := make([]KeyRatePoint, 0, len(series.Rates))
+	for _, rate := range series.Rates {
+		points = append(points, KeyRatePoint{
+			Date: rate.Date.Format("02.01.2006"),
+			Rate: rate.Rate.Float64(),
+		})
+	}
+
+	return KeyRateHistoryResponse{
+		Success: true,
+		From:    series.From.Format("02.01.2006"),
+		To:      series.To.Format("02.01.2006"),
+		Points:  points,
+	}
+}
+
+// GetSources возвращает имена источников курсов для поля source запроса (основной - первым)
+func (a *App) GetSources() []string {
+	names := make([]string, 0, len(a.sources)+1)
+	for name := range a.sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append([]string{DefaultSource}, names...)
+}
+
+// parseCurrency парсит код валюты по справочнику валют выбранного источника
+// Неизвестный источник здесь не проверяется: запрос с ним отклонит converterFor
+func (a *App) parseCurrency(source, s string) (models.Currency, error) {
+	if conv, ok := a.sources[source]; ok {
+		return conv.ParseCurrency(s)
+	}
+	return a.converter.ParseCurrency(s)
+}
+
+// converterFor возвращает конвертер выбранного источника с политикой округления из запроса
+// Пустой источник - DefaultSource; пустой режим и отсутствующее количество знаков
+// берутся из политики конвертера
+func (a *App) converterFor(source, mode string, places *int) (*converter.Converter, error) {
+	conv := a.converter
+	if source != "" && source != DefaultSource {
+		var ok bool
+		if conv, ok = a.sources[source]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownSource, source)
+		}
 	}
+
+	if mode == "" && places == nil {
+		return conv, nil
+	}
+
+	rounding := conv.Rounding()
+	if mode != "" {
+		parsed, err := models.ParseRoundingMode(mode)
+		if err != nil {
+			return nil, err
+		}
+		rounding.Mode = parsed
+	}
+	if places != nil {
+		if *places < 0 || *places > models.MaxRoundingPlaces {
+			return nil, fmt.Errorf("%w: количество знаков %d", models.ErrInvalidRounding, *places)
+		}
+		rounding.Places = int32(*places)
+	}
+
+	return conv.WithRounding(rounding), nil
 }
 
 // parseDate парсит дату из формата "DD.MM.YYYY"
@@ -187,12 +671,31 @@ func parseDate(dateStr string) (time.Time, error) {
 	return date, nil
 }
 
+// sourceTitles - названия источников курсов в сообщениях об ошибках
+var sourceTitles = map[string]string{
+	DefaultSource: "ЦБ РФ",
+	"ecb":         "ЕЦБ",
+}
+
 // translateError преобразует ошибку в понятное сообщение на русском языке
 // Использует errors.Is для распознавания базовых ошибок, даже если они обёрнуты
 func translateError(err error) string {
+	return translateSourceError(err, DefaultSource)
+}
+
+// translateSourceError преобразует ошибку запроса к источнику source (пусто - DefaultSource)
+// в понятное сообщение на русском языке: сообщение об отсутствующем курсе называет источник
+func translateSourceError(err error, source string) string {
 	if err == nil {
 		return ""
 	}
+	if source == "" {
+		source = DefaultSource
+	}
+	title, ok := sourceTitles[source]
+	if !ok {
+		title = "Источник курсов " + source
+	}
 
 	// Проверяем базовые ошибки через errors.Is (работает с обёрнутыми ошибками)
 	switch {
@@ -202,8 +705,34 @@ func translateError(err error) string {
 		return "Сумма должна быть положительным числом"
 	case errors.Is(err, converter.ErrDateInFuture):
 		return "Дата не может быть в будущем"
+	case errors.Is(err, converter.ErrInvalidPeriod):
+		return "Начало периода не может быть позже конца"
+	case errors.Is(err, converter.ErrPeriodTooLong):
+		return fmt.Sprintf("Период не может превышать %d дней", converter.MaxHistoryDays)
+	case errors.Is(err, converter.ErrNilSeriesProvider):
+		return "Ошибка конфигурации: источник динамики курсов не настроен"
+	case errors.Is(err, parser.ErrUnknownCBRID):
+		return "Динамика курса недоступна: ЦБ РФ публикует ее только для валют, не для драгоценных металлов"
+	case errors.Is(err, converter.ErrRateNotFound):
+		return title + " не публикует курс этой валюты на выбранную дату"
+	case errors.Is(err, converter.ErrNilKeyRateProvider):
+		return "Ошибка конфигурации: источник ключевой ставки не настроен"
+	case errors.Is(err, converter.ErrKeyRateNotFound):
+		return "Ключевая ставка на выбранную дату не установлена (введена 13.09.2013)"
+	case errors.Is(err, ErrUnknownSource):
+		return "Неизвестный источник курсов"
+	case errors.Is(err, parser.ErrSourceUnavailable):
+		// Проверяется до ErrAllSourcesFailed: ProviderChain оборачивает ошибки всех источников,
+		// и открытый breaker означает, что ждать повторов бессмысленно
+		return "Сервер " + title + " временно недоступен. Повторите попытку через минуту"
+	case errors.Is(err, converter.ErrAllSourcesFailed):
+		return "Не удалось получить курсы: ЦБ РФ и резервные источники недоступны"
+	case errors.Is(err, models.ErrInvalidDirection):
+		return "Неизвестное направление конвертации"
+	case errors.Is(err, models.ErrInvalidRounding):
+		return fmt.Sprintf("Некорректное округление. Режимы: halfUp, halfEven, down, up; знаков после запятой: 0-%d", models.MaxRoundingPlaces)
 	case errors.Is(err, models.ErrUnsupportedCurrency):
-		return "Неподдерживаемая валюта. Поддерживаются только USD, EUR и RUB"
+		return "Неподдерживаемая валюта. Поддерживаются валюты, курсы которых публикует " + title
 	default:
 		// Для неизвестных ошибок возвращаем оригинальное сообщение
 		// или общее сообщение, если оно слишком техническое
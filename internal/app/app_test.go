@@ -4,12 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/bivlked/currate-go/internal/cache"
 	"github.com/bivlked/currate-go/internal/converter"
 	"github.com/bivlked/currate-go/internal/models"
+	"github.com/bivlked/currate-go/internal/parser"
 )
 
 // mockRateProvider - мок для RateProvider
@@ -27,58 +30,43 @@ func (m *mockRateProvider) FetchRates(_ context.Context, date time.Time) (*model
 
 // mockCacheStorage - мок для CacheStorage
 type mockCacheStorage struct {
-	data map[string]struct {
-		rate       float64
-		actualDate time.Time
-	}
+	data map[string]*models.RateData
 }
 
 func newMockCache() *mockCacheStorage {
 	return &mockCacheStorage{
-		data: make(map[string]struct {
-			rate       float64
-			actualDate time.Time
-		}),
+		data: make(map[string]*models.RateData),
 	}
 }
 
-func (m *mockCacheStorage) Get(currency models.Currency, date time.Time) (float64, time.Time, bool) {
-	key := string(currency) + ":" + date.Format("2006-01-02")
-	entry, exists := m.data[key]
-	if !exists {
-		return 0, time.Time{}, false
-	}
-	return entry.rate, entry.actualDate, true
+func (m *mockCacheStorage) Get(date time.Time) (*models.RateData, bool) {
+	rateData, exists := m.data[date.Format("2006-01-02")]
+	return rateData, exists
 }
 
-func (m *mockCacheStorage) Set(currency models.Currency, requestedDate time.Time, rate float64, actualDate time.Time) {
-	key := string(currency) + ":" + requestedDate.Format("2006-01-02")
-	m.data[key] = struct {
-		rate       float64
-		actualDate time.Time
-	}{
-		rate:       rate,
-		actualDate: actualDate,
-	}
+func (m *mockCacheStorage) Set(requestedDate time.Time, rateData *models.RateData) {
+	m.data[requestedDate.Format("2006-01-02")] = rateData
 }
 
 func (m *mockCacheStorage) Clear() {
-	m.data = make(map[string]struct {
-		rate       float64
-		actualDate time.Time
-	})
+	m.data = make(map[string]*models.RateData)
 }
 
 // createTestConverter создает Converter с моками для тестирования
-func createTestConverter(rateData *models.RateData, rateError error, cacheRate float64, cacheFound bool) *converter.Converter {
+func createTestConverter(rateData *models.RateData, rateError error, cacheRate models.Decimal, cacheFound bool) *converter.Converter {
 	mockProvider := &mockRateProvider{
 		rateData: rateData,
 		err:      rateError,
 	}
 	mockCache := newMockCache()
-	if cacheFound && cacheRate > 0 {
+	if cacheFound && cacheRate.Sign() > 0 {
 		date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
-		mockCache.Set(models.USD, date, cacheRate, date)
+		mockCache.Set(date, &models.RateData{
+			Date: date,
+			Rates: map[models.Currency]models.ExchangeRate{
+				models.USD: {Currency: models.USD, Rate: cacheRate, Nominal: 1, Date: date},
+			},
+		})
 	}
 	return converter.NewConverter(mockProvider, mockCache)
 }
@@ -102,10 +90,10 @@ func TestConvert_BeforeStartup(t *testing.T) {
 	rateData := &models.RateData{
 		Date: date,
 		Rates: map[models.Currency]models.ExchangeRate{
-			models.USD: {Currency: models.USD, Rate: 80.0, Nominal: 1, Date: date},
+			models.USD: {Currency: models.USD, Rate: models.MustParseDecimal("80.0"), Nominal: 1, Date: date},
 		},
 	}
-	conv := createTestConverter(rateData, nil, 0, false)
+	conv := createTestConverter(rateData, nil, models.Decimal{}, false)
 	app := NewApp(conv)
 	// Не вызываем Startup — a.ctx == nil
 
@@ -123,14 +111,14 @@ func TestGetRate_BeforeStartup(t *testing.T) {
 	rateData := &models.RateData{
 		Date: date,
 		Rates: map[models.Currency]models.ExchangeRate{
-			models.USD: {Currency: models.USD, Rate: 80.0, Nominal: 1, Date: date},
+			models.USD: {Currency: models.USD, Rate: models.MustParseDecimal("80.0"), Nominal: 1, Date: date},
 		},
 	}
-	conv := createTestConverter(rateData, nil, 0, false)
+	conv := createTestConverter(rateData, nil, models.Decimal{}, false)
 	app := NewApp(conv)
 	// Не вызываем Startup — a.ctx == nil
 
-	result := app.GetRate("USD", "15.01.2024")
+	result := app.GetRate("USD", "15.01.2024", "")
 	if result.Success {
 		t.Fatal("GetRate() before Startup should return Success=false")
 	}
@@ -146,13 +134,13 @@ func TestNewApp(t *testing.T) {
 		Rates: map[models.Currency]models.ExchangeRate{
 			models.USD: {
 				Currency: models.USD,
-				Rate:     80.0,
+				Rate:     models.MustParseDecimal("80.0"),
 				Nominal:  1,
 				Date:     date,
 			},
 		},
 	}
-	conv := createTestConverter(rateData, nil, 0, false)
+	conv := createTestConverter(rateData, nil, models.Decimal{}, false)
 	app := NewApp(conv)
 
 	if app == nil {
@@ -171,13 +159,13 @@ func TestApp_Startup(t *testing.T) {
 		Rates: map[models.Currency]models.ExchangeRate{
 			models.USD: {
 				Currency: models.USD,
-				Rate:     80.0,
+				Rate:     models.MustParseDecimal("80.0"),
 				Nominal:  1,
 				Date:     date,
 			},
 		},
 	}
-	conv := createTestConverter(rateData, nil, 0, false)
+	conv := createTestConverter(rateData, nil, models.Decimal{}, false)
 	app := NewApp(conv)
 
 	// Startup не должен паниковать
@@ -192,13 +180,13 @@ func TestApp_Convert_Success(t *testing.T) {
 		Rates: map[models.Currency]models.ExchangeRate{
 			models.USD: {
 				Currency: models.USD,
-				Rate:     80.0,
+				Rate:     models.MustParseDecimal("80.0"),
 				Nominal:  1,
 				Date:     date,
 			},
 		},
 	}
-	conv := createTestConverter(rateData, nil, 0, false)
+	conv := createTestConverter(rateData, nil, models.Decimal{}, false)
 	app := NewApp(conv)
 	app.Startup(context.Background())
 
@@ -223,19 +211,404 @@ func TestApp_Convert_Success(t *testing.T) {
 	}
 }
 
+func TestApp_Convert_Stale(t *testing.T) {
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	// Запись кэша истекла сразу после сохранения, ЦБ РФ недоступен
+	storage := cache.NewLRUCache(10, time.Nanosecond)
+	storage.Set(date, &models.RateData{
+		Date: date,
+		Rates: map[models.Currency]models.ExchangeRate{
+			models.USD: {Currency: models.USD, Rate: models.MustParseDecimal("80.0"), Nominal: 1, Date: date},
+			models.EUR: {Currency: models.EUR, Rate: models.MustParseDecimal("90.0"), Nominal: 1, Date: date},
+		},
+	})
+	time.Sleep(time.Millisecond)
+	provider := &mockRateProvider{err: errors.New("network error")}
+	app := NewApp(converter.NewConverter(provider, storage).WithStaleWhileRevalidate())
+	app.Startup(context.Background())
+
+	result := app.Convert(ConvertRequest{Amount: 100, Currency: "USD", Date: "15.01.2024"})
+	if !result.Success || !result.Stale {
+		t.Errorf("Convert() Success = %v, Stale = %v; ожидался результат из устаревшего кэша (error: %q)", result.Success, result.Stale, result.Error)
+	}
+
+	cross := app.ConvertCross(CrossConvertRequest{Amount: 100, From: "USD", To: "EUR", Date: "15.01.2024"})
+	if !cross.Success || !cross.Stale {
+		t.Errorf("ConvertCross() Success = %v, Stale = %v; ожидался результат из устаревшего кэша (error: %q)", cross.Success, cross.Stale, cross.Error)
+	}
+}
+
+func TestApp_Convert_CurrencyFromRegistry(t *testing.T) {
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	rateData := &models.RateData{
+		Date: date,
+		Rates: map[models.Currency]models.ExchangeRate{
+			"CNY": {
+				Currency: "CNY",
+				Rate:     models.MustParseDecimal("11.5"),
+				Nominal:  1,
+				Date:     date,
+			},
+		},
+	}
+	conv := createTestConverter(rateData, nil, models.Decimal{}, false)
+	app := NewApp(conv)
+	app.Startup(context.Background())
+
+	result := app.Convert(ConvertRequest{
+		Amount:   100,
+		Currency: "cny",
+		Date:     "15.01.2024",
+	})
+
+	if !result.Success {
+		t.Fatalf("Convert() Success = false, want true. Error: %q", result.Error)
+	}
+
+	if result.Result != "1 150,00 руб. (100,00 CNY по курсу 11,5000)" {
+		t.Errorf("Convert() Result = %q", result.Result)
+	}
+
+	if result.Currency != "CNY" {
+		t.Errorf("Convert() Currency = %q, want CNY", result.Currency)
+	}
+
+	rate := app.GetRate("CNY", "15.01.2024", "")
+	if !rate.Success || rate.Rate != 11.5 {
+		t.Errorf("GetRate() = %+v, want rate 11.5", rate)
+	}
+}
+
+func TestApp_Convert_FromRUB(t *testing.T) {
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	rateData := &models.RateData{
+		Date: date,
+		Rates: map[models.Currency]models.ExchangeRate{
+			models.USD: {
+				Currency: models.USD,
+				Rate:     models.MustParseDecimal("80.0"),
+				Nominal:  1,
+				Date:     date,
+			},
+		},
+	}
+	conv := createTestConverter(rateData, nil, models.Decimal{}, false)
+	app := NewApp(conv)
+	app.Startup(context.Background())
+
+	result := app.Convert(ConvertRequest{
+		Amount:    8000,
+		Currency:  "USD",
+		Date:      "15.01.2024",
+		Direction: "fromRUB",
+	})
+
+	if !result.Success {
+		t.Fatalf("Convert() Success = false, want true. Error: %q", result.Error)
+	}
+	if result.Result != "$100,00 (8 000,00 руб. по курсу 80,0000)" {
+		t.Errorf("Convert() Result = %q", result.Result)
+	}
+	if result.TargetAmount != 100 || result.TargetAmountRUB != 8000 {
+		t.Errorf("Convert() TargetAmount = %v, TargetAmountRUB = %v, want 100 and 8000", result.TargetAmount, result.TargetAmountRUB)
+	}
+	if result.Direction != "fromRUB" || result.Currency != "USD" || result.CurrencySymbol != "$" {
+		t.Errorf("Convert() Direction = %q, Currency = %q, Symbol = %q", result.Direction, result.Currency, result.CurrencySymbol)
+	}
+	if result.AmountInWords != "Сто долларов США 00 центов" {
+		t.Errorf("Convert() AmountInWords = %q", result.AmountInWords)
+	}
+}
+
+func TestApp_Convert_Rounding(t *testing.T) {
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	rateData := &models.RateData{
+		Date: date,
+		Rates: map[models.Currency]models.ExchangeRate{
+			models.USD: {Currency: models.USD, Rate: models.MustParseDecimal("80.0060"), Nominal: 1, Date: date},
+		},
+	}
+	conv := createTestConverter(rateData, nil, models.Decimal{}, false)
+	app := NewApp(conv)
+	app.Startup(context.Background())
+
+	zero, two := 0, 2
+	tests := []struct {
+		name     string
+		rounding string
+		places   *int
+		want     float64
+		result   string
+	}{
+		{name: "По умолчанию", want: 600.05, result: "600,05 руб. ($7,50 по курсу 80,0060)"},
+		{name: "Банковское", rounding: "halfEven", want: 600.04, result: "600,04 руб. ($7,50 по курсу 80,0060)"},
+		{name: "Только знаки", places: &zero, want: 600, result: "600 руб. ($7,50 по курсу 80,0060)"},
+		{name: "Вверх до копеек", rounding: "up", places: &two, want: 600.05, result: "600,05 руб. ($7,50 по курсу 80,0060)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := app.Convert(ConvertRequest{
+				Amount:   7.5,
+				Currency: "USD",
+				Date:     "15.01.2024",
+				Rounding: tt.rounding,
+				Places:   tt.places,
+			})
+			if !result.Success {
+				t.Fatalf("Convert() Success = false. Error: %q", result.Error)
+			}
+			if result.TargetAmount != tt.want || result.Result != tt.result {
+				t.Errorf("Convert() TargetAmount = %v, Result = %q; want %v, %q", result.TargetAmount, result.Result, tt.want, tt.result)
+			}
+		})
+	}
+}
+
+func TestApp_Convert_InvalidRounding(t *testing.T) {
+	conv := createTestConverter(nil, nil, models.Decimal{}, false)
+	app := NewApp(conv)
+	app.Startup(context.Background())
+
+	tooMany := models.MaxRoundingPlaces + 1
+	for _, req := range []ConvertRequest{
+		{Amount: 100, Currency: "USD", Date: "15.01.2024", Rounding: "ceiling"},
+		{Amount: 100, Currency: "USD", Date: "15.01.2024", Places: &tooMany},
+	} {
+		result := app.Convert(req)
+		if result.Success {
+			t.Fatalf("Convert(%+v) Success = true, want false", req)
+		}
+		if !strings.Contains(result.Error, "Некорректное округление") {
+			t.Errorf("Convert() Error = %q, want to contain 'Некорректное округление'", result.Error)
+		}
+	}
+
+	cross := app.ConvertCross(CrossConvertRequest{Amount: 100, From: "USD", To: "EUR", Date: "15.01.2024", Rounding: "ceiling"})
+	if cross.Success || !strings.Contains(cross.Error, "Некорректное округление") {
+		t.Errorf("ConvertCross() = %+v, want rounding error", cross)
+	}
+}
+
+func TestApp_Convert_InvalidDirection(t *testing.T) {
+	conv := createTestConverter(nil, nil, models.Decimal{}, false)
+	app := NewApp(conv)
+	app.Startup(context.Background())
+
+	result := app.Convert(ConvertRequest{
+		Amount:    100,
+		Currency:  "USD",
+		Date:      "15.01.2024",
+		Direction: "sideways",
+	})
+
+	if result.Success {
+		t.Fatal("Convert() Success = true, want false")
+	}
+	if !strings.Contains(result.Error, "направление") {
+		t.Errorf("Convert() Error = %q, want to contain 'направление'", result.Error)
+	}
+}
+
+func TestApp_ConvertCross(t *testing.T) {
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	rateData := &models.RateData{
+		Date: date,
+		Rates: map[models.Currency]models.ExchangeRate{
+			models.USD: {Currency: models.USD, Rate: models.MustParseDecimal("80.0"), Nominal: 1, Date: date},
+			models.EUR: {Currency: models.EUR, Rate: models.MustParseDecimal("100.0"), Nominal: 1, Date: date},
+		},
+	}
+	conv := createTestConverter(rateData, nil, models.Decimal{}, false)
+	app := NewApp(conv)
+	app.Startup(context.Background())
+
+	result := app.ConvertCross(CrossConvertRequest{
+		Amount: 100,
+		From:   "eur",
+		To:     "USD",
+		Date:   "15.01.2024",
+	})
+
+	if !result.Success {
+		t.Fatalf("ConvertCross() Success = false, want true. Error: %q", result.Error)
+	}
+	if result.TargetAmount != 125 || result.CrossRate != 1.25 {
+		t.Errorf("ConvertCross() TargetAmount = %v, CrossRate = %v, want 125 and 1.25", result.TargetAmount, result.CrossRate)
+	}
+	if result.SourceRate != 100 || result.TargetRate != 80 {
+		t.Errorf("ConvertCross() SourceRate = %v, TargetRate = %v", result.SourceRate, result.TargetRate)
+	}
+	if result.SourceCurrency != "EUR" || result.TargetSymbol != "$" {
+		t.Errorf("ConvertCross() SourceCurrency = %q, TargetSymbol = %q", result.SourceCurrency, result.TargetSymbol)
+	}
+	if result.Result != "$125,00 (€100,00 по кросс-курсу 1,2500; EUR 100,0000 руб., USD 80,0000 руб.)" {
+		t.Errorf("ConvertCross() Result = %q", result.Result)
+	}
+}
+
+func TestApp_ConvertCross_Errors(t *testing.T) {
+	conv := createTestConverter(nil, nil, models.Decimal{}, false)
+
+	notStarted := NewApp(conv)
+	if result := notStarted.ConvertCross(CrossConvertRequest{Amount: 1, From: "USD", To: "EUR", Date: "15.01.2024"}); result.Success {
+		t.Error("ConvertCross() до Startup: Success = true, want false")
+	}
+
+	app := NewApp(conv)
+	app.Startup(context.Background())
+
+	tests := []struct {
+		name string
+		req  CrossConvertRequest
+		want string
+	}{
+		{name: "Неизвестная исходная валюта", req: CrossConvertRequest{Amount: 1, From: "XYZ", To: "EUR", Date: "15.01.2024"}, want: "Неподдерживаемая валюта"},
+		{name: "Неизвестная целевая валюта", req: CrossConvertRequest{Amount: 1, From: "USD", To: "XYZ", Date: "15.01.2024"}, want: "Неподдерживаемая валюта"},
+		{name: "Неверная дата", req: CrossConvertRequest{Amount: 1, From: "USD", To: "EUR", Date: "2024-01-15"}, want: "Неверный формат даты"},
+		{name: "Неверная сумма", req: CrossConvertRequest{Amount: -1, From: "USD", To: "EUR", Date: "15.01.2024"}, want: "Сумма должна быть положительным числом"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := app.ConvertCross(tt.req)
+			if result.Success {
+				t.Fatal("ConvertCross() Success = true, want false")
+			}
+			if !strings.Contains(result.Error, tt.want) {
+				t.Errorf("ConvertCross() Error = %q, want to contain %q", result.Error, tt.want)
+			}
+		})
+	}
+}
+
+func TestApp_ConvertCross_Source(t *testing.T) {
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	cbr := createTestConverter(nil, errors.New("ЦБ РФ не должен опрашиваться"), models.Decimal{}, false)
+	ecbData := models.NewRateData(date)
+	ecbData.Base = models.EUR
+	ecbData.AddRate(models.ExchangeRate{Currency: models.USD, Rate: models.MustParseDecimal("0.8"), Nominal: 1, Date: date})
+	ecb := createTestConverter(ecbData, nil, models.Decimal{}, false)
+
+	app := NewApp(cbr, WithSource("ecb", ecb))
+	app.Startup(context.Background())
+
+	if got := app.GetSources(); strings.Join(got, ",") != "cbr,ecb" {
+		t.Errorf("GetSources() = %v, want [cbr ecb]", got)
+	}
+
+	result := app.ConvertCross(CrossConvertRequest{Amount: 100, From: "USD", To: "EUR", Date: "15.01.2024", Source: "ecb"})
+	if !result.Success {
+		t.Fatalf("ConvertCross() Success = false, want true. Error: %q", result.Error)
+	}
+	if result.TargetAmount != 80 {
+		t.Errorf("ConvertCross() TargetAmount = %v, want 80", result.TargetAmount)
+	}
+
+	result = app.ConvertCross(CrossConvertRequest{Amount: 100, From: "USD", To: "EUR", Date: "15.01.2024", Source: "fed"})
+	if result.Success || !strings.Contains(result.Error, "Неизвестный источник курсов") {
+		t.Errorf("ConvertCross() с неизвестным источником: Success = %v, Error = %q", result.Success, result.Error)
+	}
+}
+
+func TestApp_GetRate_Source(t *testing.T) {
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	cbr := createTestConverter(nil, errors.New("ЦБ РФ не должен опрашиваться"), models.Decimal{}, false)
+	ecbData := models.NewRateData(date)
+	ecbData.Base = models.EUR
+	ecbData.AddRate(models.ExchangeRate{Currency: models.USD, Rate: models.MustParseDecimal("0.8"), Nominal: 1, Date: date})
+	ecb := createTestConverter(ecbData, nil, models.Decimal{}, false)
+
+	app := NewApp(cbr, WithSource("ecb", ecb))
+	app.Startup(context.Background())
+
+	// Курс в евро по снимку ЕЦБ
+	if result := app.GetRate("USD", "15.01.2024", "ecb"); !result.Success || result.Rate != 0.8 || result.Base != "EUR" {
+		t.Errorf("GetRate(ecb) = %+v, want rate 0.8 EUR", result)
+	}
+
+	// Курс базовой валюты источника равен 1 в ней самой
+	if result := app.GetRate("EUR", "15.01.2024", "ecb"); !result.Success || result.Rate != 1 || result.Base != "EUR" {
+		t.Errorf("GetRate(ecb, EUR) = %+v, want rate 1 EUR", result)
+	}
+	if result := app.GetRate("RUB", "15.01.2024", "cbr"); !result.Success || result.Rate != 1 || result.Base != "RUB" {
+		t.Errorf("GetRate(cbr, RUB) = %+v, want rate 1 RUB", result)
+	}
+
+	// Сообщение об отсутствующем курсе называет выбранный источник
+	result := app.GetRate("CNY", "15.01.2024", "ecb")
+	if result.Success || result.Error != "ЕЦБ не публикует курс этой валюты на выбранную дату" {
+		t.Errorf("GetRate(ecb, CNY) = %+v, want ошибку ЕЦБ", result)
+	}
+
+	if result := app.GetRate("USD", "15.01.2024", "fed"); result.Success || !strings.Contains(result.Error, "Неизвестный источник курсов") {
+		t.Errorf("GetRate() с неизвестным источником: Success = %v, Error = %q", result.Success, result.Error)
+	}
+	if result := app.GetRateHistory("USD", "15.01.2024", "17.01.2024", "fed"); result.Success || !strings.Contains(result.Error, "Неизвестный источник курсов") {
+		t.Errorf("GetRateHistory() с неизвестным источником: Success = %v, Error = %q", result.Success, result.Error)
+	}
+}
+
+func TestApp_GetRate_ChainSourceUnavailable(t *testing.T) {
+	// Breaker хоста cbr.ru открыт: оба источника ЦБ РФ сразу отклоняют запрос, резервный файл не найден
+	unavailable := converter.FetchRatesFunc(func(ctx context.Context, date time.Time) (*models.RateData, error) {
+		return nil, fmt.Errorf("failed to fetch rates from CBR: %w: circuit breaker open", parser.ErrSourceUnavailable)
+	})
+	noSnapshot := converter.FetchRatesFunc(func(ctx context.Context, date time.Time) (*models.RateData, error) {
+		return nil, errors.New("snapshot not found")
+	})
+	chain := converter.NewProviderChain(
+		converter.RateSource{Name: "cbr-xml", Provider: unavailable},
+		converter.RateSource{Name: "cbr-soap", Provider: unavailable},
+		converter.RateSource{Name: "file", Provider: noSnapshot},
+	)
+	app := NewApp(converter.NewConverter(chain, newMockCache()))
+	app.Startup(context.Background())
+
+	result := app.GetRate("USD", "15.01.2024", "")
+	if want := "Сервер ЦБ РФ временно недоступен. Повторите попытку через минуту"; result.Success || result.Error != want {
+		t.Errorf("GetRate() = %+v, want ошибку %q", result, want)
+	}
+}
+
+func TestTranslateSourceError(t *testing.T) {
+	err := fmt.Errorf("%w: CNY", converter.ErrRateNotFound)
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{"", "ЦБ РФ не публикует курс этой валюты на выбранную дату"},
+		{"cbr", "ЦБ РФ не публикует курс этой валюты на выбранную дату"},
+		{"ecb", "ЕЦБ не публикует курс этой валюты на выбранную дату"},
+		{"fed", "Источник курсов fed не публикует курс этой валюты на выбранную дату"},
+	}
+
+	for _, tt := range tests {
+		if got := translateSourceError(err, tt.source); got != tt.want {
+			t.Errorf("translateSourceError(%q) = %q, want %q", tt.source, got, tt.want)
+		}
+	}
+
+	// Сообщение о неподдерживаемой валюте называет источник
+	if got, want := translateSourceError(models.ErrUnsupportedCurrency, "ecb"), "Неподдерживаемая валюта. Поддерживаются валюты, курсы которых публикует ЕЦБ"; got != want {
+		t.Errorf("translateSourceError(ErrUnsupportedCurrency, ecb) = %q, want %q", got, want)
+	}
+}
+
 func TestApp_Convert_InvalidCurrency(t *testing.T) {
 	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
 	rateData := &models.RateData{
 		Date:  date,
 		Rates: map[models.Currency]models.ExchangeRate{},
 	}
-	conv := createTestConverter(rateData, nil, 0, false)
+	conv := createTestConverter(rateData, nil, models.Decimal{}, false)
 	app := NewApp(conv)
 	app.Startup(context.Background())
 
 	req := ConvertRequest{
 		Amount:   100,
-		Currency: "GBP",
+		Currency: "XYZ",
 		Date:     "15.01.2024",
 	}
 
@@ -260,7 +633,7 @@ func TestApp_Convert_InvalidDate(t *testing.T) {
 		Date:  date,
 		Rates: map[models.Currency]models.ExchangeRate{},
 	}
-	conv := createTestConverter(rateData, nil, 0, false)
+	conv := createTestConverter(rateData, nil, models.Decimal{}, false)
 	app := NewApp(conv)
 	app.Startup(context.Background())
 
@@ -293,13 +666,13 @@ func TestApp_Convert_ConverterError(t *testing.T) {
 		Rates: map[models.Currency]models.ExchangeRate{
 			models.USD: {
 				Currency: models.USD,
-				Rate:     80.0,
+				Rate:     models.MustParseDecimal("80.0"),
 				Nominal:  1,
 				Date:     date,
 			},
 		},
 	}
-	conv := createTestConverter(rateData, nil, 0, false)
+	conv := createTestConverter(rateData, nil, models.Decimal{}, false)
 	app := NewApp(conv)
 	app.Startup(context.Background())
 
@@ -324,6 +697,24 @@ func TestApp_Convert_ConverterError(t *testing.T) {
 	}
 }
 
+// TestApp_Convert_NonFiniteAmount проверяет, что NaN и бесконечность отклоняются
+// до перевода суммы в Decimal
+func TestApp_Convert_NonFiniteAmount(t *testing.T) {
+	conv := createTestConverter(nil, nil, models.Decimal{}, false)
+	app := NewApp(conv)
+	app.Startup(context.Background())
+
+	for _, amount := range []float64{math.NaN(), math.Inf(1)} {
+		result := app.Convert(ConvertRequest{Amount: amount, Currency: "USD", Date: "15.01.2024"})
+		if result.Success {
+			t.Errorf("Convert(%v) Success = true, want false", amount)
+		}
+		if result.Error != "Сумма должна быть положительным числом" {
+			t.Errorf("Convert(%v) Error = %q", amount, result.Error)
+		}
+	}
+}
+
 func TestApp_GetRate_Success(t *testing.T) {
 	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
 	rateData := &models.RateData{
@@ -331,17 +722,17 @@ func TestApp_GetRate_Success(t *testing.T) {
 		Rates: map[models.Currency]models.ExchangeRate{
 			models.USD: {
 				Currency: models.USD,
-				Rate:     80.5,
+				Rate:     models.MustParseDecimal("80.5"),
 				Nominal:  1,
 				Date:     date,
 			},
 		},
 	}
-	conv := createTestConverter(rateData, nil, 0, false)
+	conv := createTestConverter(rateData, nil, models.Decimal{}, false)
 	app := NewApp(conv)
 	app.Startup(context.Background())
 
-	result := app.GetRate("USD", "15.01.2024")
+	result := app.GetRate("USD", "15.01.2024", "")
 
 	if !result.Success {
 		t.Errorf("GetRate() Success = false, want true. Error: %q", result.Error)
@@ -362,11 +753,11 @@ func TestApp_GetRate_RUB(t *testing.T) {
 		Date:  date,
 		Rates: map[models.Currency]models.ExchangeRate{},
 	}
-	conv := createTestConverter(rateData, nil, 0, false)
+	conv := createTestConverter(rateData, nil, models.Decimal{}, false)
 	app := NewApp(conv)
 	app.Startup(context.Background())
 
-	result := app.GetRate("RUB", "15.01.2024")
+	result := app.GetRate("RUB", "15.01.2024", "")
 
 	if !result.Success {
 		t.Errorf("GetRate() Success = false, want true")
@@ -387,11 +778,11 @@ func TestApp_GetRate_InvalidCurrency(t *testing.T) {
 		Date:  date,
 		Rates: map[models.Currency]models.ExchangeRate{},
 	}
-	conv := createTestConverter(rateData, nil, 0, false)
+	conv := createTestConverter(rateData, nil, models.Decimal{}, false)
 	app := NewApp(conv)
 	app.Startup(context.Background())
 
-	result := app.GetRate("GBP", "15.01.2024")
+	result := app.GetRate("XYZ", "15.01.2024", "")
 
 	if result.Success {
 		t.Errorf("GetRate() Success = true, want false")
@@ -412,11 +803,11 @@ func TestApp_GetRate_InvalidDate(t *testing.T) {
 		Date:  date,
 		Rates: map[models.Currency]models.ExchangeRate{},
 	}
-	conv := createTestConverter(rateData, nil, 0, false)
+	conv := createTestConverter(rateData, nil, models.Decimal{}, false)
 	app := NewApp(conv)
 	app.Startup(context.Background())
 
-	result := app.GetRate("USD", "invalid-date")
+	result := app.GetRate("USD", "invalid-date", "")
 
 	if result.Success {
 		t.Errorf("GetRate() Success = true, want false")
@@ -439,19 +830,19 @@ func TestApp_GetRate_ConverterError(t *testing.T) {
 		Rates: map[models.Currency]models.ExchangeRate{
 			models.USD: {
 				Currency: models.USD,
-				Rate:     80.0,
+				Rate:     models.MustParseDecimal("80.0"),
 				Nominal:  1,
 				Date:     date,
 			},
 		},
 	}
-	conv := createTestConverter(rateData, nil, 0, false)
+	conv := createTestConverter(rateData, nil, models.Decimal{}, false)
 	app := NewApp(conv)
 	app.Startup(context.Background())
 
 	// Используем дату в будущем
 	futureDateStr := date.Format("02.01.2006")
-	result := app.GetRate("USD", futureDateStr)
+	result := app.GetRate("USD", futureDateStr, "")
 
 	if result.Success {
 		t.Errorf("GetRate() Success = true, want false")
@@ -466,6 +857,142 @@ func TestApp_GetRate_ConverterError(t *testing.T) {
 	}
 }
 
+func TestApp_GetRateHistory(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.Local) }
+	series := &models.RateSeries{
+		Currency: models.USD,
+		Rates: []models.ExchangeRate{
+			{Currency: models.USD, Rate: models.MustParseDecimal("80.0000"), Nominal: 1, Date: day(15)},
+			{Currency: models.USD, Rate: models.MustParseDecimal("81.0000"), Nominal: 1, Date: day(16)},
+			{Currency: models.USD, Rate: models.MustParseDecimal("79.3800"), Nominal: 1, Date: day(17)},
+		},
+	}
+	seriesProvider := converter.FetchRateSeriesFunc(func(_ context.Context, _ models.Currency, _, _ time.Time) (*models.RateSeries, error) {
+		return series, nil
+	})
+	conv := createTestConverter(nil, nil, models.Decimal{}, false).WithSeriesProvider(seriesProvider)
+	app := NewApp(conv)
+	app.Startup(context.Background())
+
+	result := app.GetRateHistory("USD", "15.01.2024", "17.01.2024", "")
+
+	if !result.Success {
+		t.Fatalf("GetRateHistory() Success = false, Error: %q", result.Error)
+	}
+	if result.Currency != "USD" || result.From != "15.01.2024" || result.To != "17.01.2024" {
+		t.Errorf("GetRateHistory() период = %s %s..%s, want USD 15.01.2024..17.01.2024", result.Currency, result.From, result.To)
+	}
+
+	want := []RateHistoryPoint{
+		{Date: "15.01.2024", Rate: 80, Change: 0, ChangePercent: 0},
+		{Date: "16.01.2024", Rate: 81, Change: 1, ChangePercent: 1.25},
+		{Date: "17.01.2024", Rate: 79.38, Change: -1.62, ChangePercent: -2},
+	}
+	if len(result.Points) != len(want) {
+		t.Fatalf("GetRateHistory() len(Points) = %d, want %d", len(result.Points), len(want))
+	}
+	for i, w := range want {
+		if result.Points[i] != w {
+			t.Errorf("GetRateHistory() Points[%d] = %+v, want %+v", i, result.Points[i], w)
+		}
+	}
+
+	if result.Min != 79.38 || result.Max != 81 || result.Average != 80.1267 {
+		t.Errorf("GetRateHistory() Min/Max/Average = %v/%v/%v, want 79.38/81/80.1267", result.Min, result.Max, result.Average)
+	}
+}
+
+func TestApp_GetRateHistory_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency string
+		from     string
+		to       string
+		wantErr  string
+	}{
+		{"Неподдерживаемая валюта", "XYZ", "15.01.2024", "17.01.2024", "Неподдерживаемая валюта"},
+		{"Неверная дата начала", "USD", "2024-01-15", "17.01.2024", "Неверный формат даты"},
+		{"Неверная дата конца", "USD", "15.01.2024", "17/01/2024", "Неверный формат даты"},
+		{"Начало позже конца", "USD", "17.01.2024", "15.01.2024", "Начало периода не может быть позже конца"},
+		{"Период больше года", "USD", "15.01.2022", "17.01.2024", "Период не может превышать"},
+		{"Источник динамики не задан", "USD", "15.01.2024", "17.01.2024", "источник динамики курсов не настроен"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := NewApp(createTestConverter(nil, nil, models.Decimal{}, false))
+			app.Startup(context.Background())
+
+			result := app.GetRateHistory(tt.currency, tt.from, tt.to, "")
+			if result.Success {
+				t.Fatal("GetRateHistory() Success = true, want false")
+			}
+			if !strings.Contains(result.Error, tt.wantErr) {
+				t.Errorf("GetRateHistory() Error = %q, want to contain %q", result.Error, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApp_GetKeyRate(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.Local) }
+	provider := converter.FetchKeyRatesFunc(func(_ context.Context, _, _ time.Time) ([]models.KeyRate, error) {
+		return []models.KeyRate{
+			{Date: day(12), Rate: models.MustParseDecimal("16.00")},
+			{Date: day(15), Rate: models.MustParseDecimal("16.50")},
+		}, nil
+	})
+	app := NewApp(createTestConverter(nil, nil, models.Decimal{}, false), WithKeyRates(converter.NewKeyRates(provider)))
+	app.Startup(context.Background())
+
+	// Воскресенье - ставка пятницы
+	result := app.GetKeyRate("14.01.2024")
+	if !result.Success {
+		t.Fatalf("GetKeyRate() Success = false, Error: %q", result.Error)
+	}
+	if result.Date != "14.01.2024" || result.Rate != 16 {
+		t.Errorf("GetKeyRate() = %s %v, want 14.01.2024 16", result.Date, result.Rate)
+	}
+
+	history := app.GetKeyRateHistory("13.01.2024", "15.01.2024")
+	if !history.Success {
+		t.Fatalf("GetKeyRateHistory() Success = false, Error: %q", history.Error)
+	}
+	want := []KeyRatePoint{{"13.01.2024", 16}, {"14.01.2024", 16}, {"15.01.2024", 16.5}}
+	if len(history.Points) != len(want) {
+		t.Fatalf("GetKeyRateHistory() len(Points) = %d, want %d", len(history.Points), len(want))
+	}
+	for i, w := range want {
+		if history.Points[i] != w {
+			t.Errorf("GetKeyRateHistory() Points[%d] = %+v, want %+v", i, history.Points[i], w)
+		}
+	}
+}
+
+func TestApp_GetKeyRate_Errors(t *testing.T) {
+	notConfigured := NewApp(createTestConverter(nil, nil, models.Decimal{}, false))
+	notConfigured.Startup(context.Background())
+	if result := notConfigured.GetKeyRate("15.01.2024"); result.Success || !strings.Contains(result.Error, "источник ключевой ставки не настроен") {
+		t.Errorf("GetKeyRate() без WithKeyRates: Success = %v, Error = %q", result.Success, result.Error)
+	}
+
+	empty := converter.FetchKeyRatesFunc(func(_ context.Context, _, _ time.Time) ([]models.KeyRate, error) {
+		return nil, nil
+	})
+	app := NewApp(createTestConverter(nil, nil, models.Decimal{}, false), WithKeyRates(converter.NewKeyRates(empty)))
+	app.Startup(context.Background())
+
+	if result := app.GetKeyRate("15.01.2012"); result.Success || !strings.Contains(result.Error, "Ключевая ставка на выбранную дату не установлена") {
+		t.Errorf("GetKeyRate() до 2013 года: Success = %v, Error = %q", result.Success, result.Error)
+	}
+	if result := app.GetKeyRateHistory("17.01.2024", "15.01.2024"); result.Success || !strings.Contains(result.Error, "Начало периода не может быть позже конца") {
+		t.Errorf("GetKeyRateHistory(): Success = %v, Error = %q", result.Success, result.Error)
+	}
+	if result := app.GetKeyRate("2024-01-15"); result.Success || !strings.Contains(result.Error, "Неверный формат даты") {
+		t.Errorf("GetKeyRate(): Success = %v, Error = %q", result.Success, result.Error)
+	}
+}
+
 func TestParseDate_Success(t *testing.T) {
 	dateStr := "15.01.2024"
 	date, err := parseDate(dateStr)
@@ -542,10 +1069,30 @@ func TestTranslateError(t *testing.T) {
 			err:  converter.ErrDateInFuture,
 			want: "Дата не может быть в будущем",
 		},
+		{
+			name: "ErrInvalidPeriod - прямая ошибка",
+			err:  converter.ErrInvalidPeriod,
+			want: "Начало периода не может быть позже конца",
+		},
 		{
 			name: "ErrUnsupportedCurrency - прямая ошибка",
 			err:  models.ErrUnsupportedCurrency,
-			want: "Неподдерживаемая валюта. Поддерживаются только USD, EUR и RUB",
+			want: "Неподдерживаемая валюта. Поддерживаются валюты, курсы которых публикует ЦБ РФ",
+		},
+		{
+			name: "ErrAllSourcesFailed - обёрнутая ошибка",
+			err:  fmt.Errorf("failed to fetch rates: %w", converter.ErrAllSourcesFailed),
+			want: "Не удалось получить курсы: ЦБ РФ и резервные источники недоступны",
+		},
+		{
+			name: "ErrSourceUnavailable - обёрнутая ошибка",
+			err:  fmt.Errorf("failed to fetch rates: %w", parser.ErrSourceUnavailable),
+			want: "Сервер ЦБ РФ временно недоступен. Повторите попытку через минуту",
+		},
+		{
+			name: "ErrUnknownCBRID - динамика для металла",
+			err:  fmt.Errorf("failed to fetch rate series: %w", fmt.Errorf("%w: XAU", parser.ErrUnknownCBRID)),
+			want: "Динамика курса недоступна: ЦБ РФ публикует ее только для валют, не для драгоценных металлов",
 		},
 		{
 			name: "Неизвестная ошибка - короткое сообщение",
@@ -596,10 +1143,15 @@ func TestTranslateError_WrappedErrors(t *testing.T) {
 			err:  fmt.Errorf("config error: %w", converter.ErrNilRateProvider),
 			want: "Ошибка конфигурации: источник курсов не настроен",
 		},
+		{
+			name: "fmt.Errorf обёртывает ErrRateNotFound",
+			err:  fmt.Errorf("%w: KZT", converter.ErrRateNotFound),
+			want: "ЦБ РФ не публикует курс этой валюты на выбранную дату",
+		},
 		{
 			name: "fmt.Errorf обёртывает ErrUnsupportedCurrency",
 			err:  fmt.Errorf("currency check: %w", models.ErrUnsupportedCurrency),
-			want: "Неподдерживаемая валюта. Поддерживаются только USD, EUR и RUB",
+			want: "Неподдерживаемая валюта. Поддерживаются валюты, курсы которых публикует ЦБ РФ",
 		},
 		{
 			name: "Множественные обёртки",
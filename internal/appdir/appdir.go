@@ -0,0 +1,35 @@
+// Package appdir определяет директорию данных приложения CurRate
+// В ней хранятся идентификатор пользователя (user.json), кэш курсов (rates.json)
+// и сохраненные ответы ЦБ РФ (snapshots)
+package appdir
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Name - имя директории приложения
+const Name = "CurRate"
+
+// Dir возвращает директорию данных приложения %APPDATA%/CurRate
+// (на других системах - ~/.config/CurRate)
+// Создает директорию, если её нет
+func Dir() (string, error) {
+	appData := os.Getenv("APPDATA")
+	if appData == "" {
+		// Fallback для не-Windows систем
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("не удалось определить домашнюю директорию: %w", err)
+		}
+		appData = filepath.Join(homeDir, ".config")
+	}
+
+	// Директория приложения (0700 — доступ только владельцу)
+	dir := filepath.Join(appData, Name)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("не удалось создать директорию: %w", err)
+	}
+	return dir, nil
+}
@@ -0,0 +1,52 @@
+package appdir
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDir(t *testing.T) {
+	t.Run("APPDATA", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		t.Setenv("APPDATA", tmpDir)
+
+		dir, err := Dir()
+		if err != nil {
+			t.Fatalf("Dir() error = %v", err)
+		}
+		if want := filepath.Join(tmpDir, "CurRate"); dir != want {
+			t.Errorf("Dir() = %s, ожидалось %s", dir, want)
+		}
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			t.Errorf("Директория приложения не создана: %v", err)
+		}
+	})
+
+	t.Run("Без APPDATA - ~/.config", func(t *testing.T) {
+		home := t.TempDir()
+		t.Setenv("APPDATA", "")
+		t.Setenv("HOME", home)
+		t.Setenv("USERPROFILE", home)
+
+		dir, err := Dir()
+		if err != nil {
+			t.Fatalf("Dir() error = %v", err)
+		}
+		if want := filepath.Join(home, ".config", "CurRate"); dir != want {
+			t.Errorf("Dir() = %s, ожидалось %s", dir, want)
+		}
+	})
+
+	t.Run("Директорию нельзя создать", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "file")
+		if err := os.WriteFile(file, nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+		t.Setenv("APPDATA", file)
+
+		if _, err := Dir(); err == nil {
+			t.Error("Dir() должна вернуть ошибку, если APPDATA - файл")
+		}
+	})
+}
@@ -0,0 +1,373 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/appdir"
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+// diskCacheVersion - версия формата файла кэша
+// Файл другой версии не читается: кэш начинается пустым и перезаписывается при первом Set
+// 2 - срок жизни каждой записи (expiresAt) по политике TTLPolicy вместо общего TTL
+// 3 - запись - снимок всех курсов на дату вместо курса одной валюты
+// 4 - в снимке ЦБ РФ есть учетные цены драгоценных металлов (снимки v3 без них считались бы полными)
+// 5 - валидаторы ETag/Last-Modified снимка для условного запроса после перезапуска
+const diskCacheVersion = 5
+
+// diskCacheFileName - имя файла кэша в директории приложения
+const diskCacheFileName = "rates.json"
+
+// MaxDiskCacheEntries - максимальное количество записей (дат) в файле кэша
+// При переполнении отбрасываются записи самых ранних дат
+const MaxDiskCacheEntries = 2000
+
+// DiskCacheFlushDelay - задержка записи файла после Set
+// Записи за это время (live preview, конвертация, курсы по фактической дате) сохраняются одной перезаписью
+const DiskCacheFlushDelay = 2 * time.Second
+
+// diskFlushDelay - задержка записи файла (подменяется в тестах)
+var diskFlushDelay = DiskCacheFlushDelay
+
+// diskCacheFile - формат файла кэша
+type diskCacheFile struct {
+	Version int                  `json:"version"`
+	Entries map[string]diskEntry `json:"entries"` // Ключ - запрошенная дата "2025-12-20", как в LRUCache
+}
+
+// diskEntry - снимок курсов на диске
+type diskEntry struct {
+	ActualDate time.Time                    `json:"actualDate"` // Фактическая дата курсов из XML
+	Rates      map[models.Currency]diskRate `json:"rates"`
+	Partial    bool                         `json:"partial,omitempty"`  // Снимок собран из динамики курса
+	Source     string                       `json:"source,omitempty"`   // Источник курсов (RateData.Source)
+	Base       models.Currency              `json:"base,omitempty"`     // Базовая валюта (пусто - рубль)
+	ExpiresAt  time.Time                    `json:"expiresAt,omitzero"` // Срок жизни записи (нулевой - бессрочно)
+
+	// Валидаторы HTTP ответа (RateData.ETag, RateData.LastModified)
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// diskRate - курс валюты на диске (как в XML ЦБ РФ: за Nominal единиц)
+type diskRate struct {
+	Rate    models.Decimal `json:"rate"`
+	Nominal int            `json:"nominal"`
+	Date    time.Time      `json:"date,omitzero"` // Дата курса, если отличается от actualDate
+}
+
+// DiskCache - потокобезопасный кэш снимков курсов в JSON-файле, переживающий перезапуск приложения
+// Записи держатся в памяти; Set и SetMany не пишут файл сами, а планируют запись через
+// DiskCacheFlushDelay: изменения за это время сохраняются одной перезаписью в фоне, и Get/Set
+// не ждут диска. Flush записывает изменения сразу, Close - при завершении приложения
+// Файл перезаписывается атомарно (временный файл в той же директории + rename), поэтому
+// при сбое во время записи на диске остается предыдущая целая версия
+// Хранится не больше MaxDiskCacheEntries записей: при переполнении отбрасываются самые ранние даты
+// Истекшие записи не удаляются до вытеснения, в том числе между перезапусками: Get их не возвращает,
+// а GetStale отдает для режима stale-while-revalidate и условного запроса по валидаторам снимка
+type DiskCache struct {
+	mu         sync.RWMutex
+	path       string
+	policy     TTLPolicy
+	entries    map[string]diskEntry
+	maxEntries int
+	dirty      bool        // Есть изменения, не записанные в файл
+	flushTimer *time.Timer // Запланированная запись (nil - не запланирована)
+
+	writeMu sync.Mutex // Упорядочивает записи файла: более ранний снимок не перезапишет поздний
+}
+
+// NewDiskCache открывает кэш в файле path (файл создается при первом Set)
+// policy - время жизни записей (обычно DefaultDatePolicy: курсы прошедших дат бессрочно),
+// истекшие записи остаются в файле до вытеснения по MaxDiskCacheEntries
+//
+// Поврежденный файл не считается ошибкой: он переименовывается в path + ".corrupt",
+// а кэш начинается пустым (controlled recovery, как для user.json)
+// Ошибка возвращается, только если файл существует, но не читается
+//
+// Пример использования:
+//
+//	path, err := cache.DefaultDiskCachePath()
+//	if err != nil {
+//	    return err
+//	}
+//	disk, err := cache.NewDiskCache(path, cache.DefaultDatePolicy)
+func NewDiskCache(path string, policy TTLPolicy) (*DiskCache, error) {
+	if policy == nil {
+		panic("cache: policy must not be nil")
+	}
+	c := &DiskCache{
+		path:       path,
+		policy:     policy,
+		entries:    make(map[string]diskEntry),
+		maxEntries: MaxDiskCacheEntries,
+	}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// DefaultDiskCachePath возвращает путь к файлу кэша в директории приложения
+// %APPDATA%/CurRate/rates.json (на других системах - ~/.config/CurRate/rates.json),
+// рядом с user.json из telegram.GetOrCreateUserID
+// Создает директорию, если её нет (appdir.Dir)
+func DefaultDiskCachePath() (string, error) {
+	appDir, err := appdir.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appDir, diskCacheFileName), nil
+}
+
+// Get получает снимок курсов из кэша для указанной даты
+// Возвращает (rateData, true) если запись найдена и не истекла
+func (c *DiskCache) Get(date time.Time) (*models.RateData, bool) {
+	rateData, _, found := c.getWithExpiry(date)
+	return rateData, found
+}
+
+// getWithExpiry получает снимок курсов и срок жизни записи (нулевой - бессрочно)
+// Нужен TieredCache: запись, поднятая в память, не должна жить дольше записи на диске
+func (c *DiskCache) getWithExpiry(date time.Time) (*models.RateData, time.Time, bool) {
+	return c.get(date, false)
+}
+
+// GetStale получает снимок курсов для указанной даты без учета срока жизни
+// Возвращает (rateData, true) для найденной записи, в том числе истекшей
+func (c *DiskCache) GetStale(date time.Time) (*models.RateData, bool) {
+	rateData, _, found := c.get(date, true)
+	return rateData, found
+}
+
+// get - общая реализация getWithExpiry и GetStale
+func (c *DiskCache) get(date time.Time, allowStale bool) (*models.RateData, time.Time, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, exists := c.entries[cacheKey(date)]
+	if !exists || (!allowStale && c.expired(entry)) {
+		return nil, time.Time{}, false
+	}
+	return entry.rateData(), entry.ExpiresAt, true
+}
+
+// Set сохраняет снимок курсов в кэш и планирует запись файла
+// Ошибка записи файла не прерывает работу: запись остается в памяти,
+// а файл будет перезаписан при следующем Set
+func (c *DiskCache) Set(requestedDate time.Time, rateData *models.RateData) {
+	c.SetMany(map[time.Time]*models.RateData{requestedDate: rateData})
+}
+
+// SetMany сохраняет несколько снимков курсов (ключ - запрошенная дата) и планирует одну запись файла
+// Используется для динамики курса: снимок на каждый день периода без перезаписи файла на каждый день
+func (c *DiskCache) SetMany(snapshots map[time.Time]*models.RateData) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for requestedDate, rateData := range snapshots {
+		entry := newDiskEntry(rateData)
+		if ttl := c.policy.TTL(requestedDate, rateData.Date); ttl != Forever {
+			entry.ExpiresAt = nowFunc().Add(ttl)
+		}
+		c.entries[cacheKey(requestedDate)] = entry
+	}
+	c.evictLocked()
+	c.dirty = true
+	if c.flushTimer == nil {
+		c.flushTimer = time.AfterFunc(diskFlushDelay, func() {
+			if err := c.Flush(); err != nil {
+				log.Printf("cache: %v", err)
+			}
+		})
+	}
+}
+
+// Flush записывает в файл изменения, еще не записанные по таймеру
+// Файл кодируется под блокировкой, а пишется без неё: Get и Set не ждут диска
+// Ошибка записи не прерывает работу: записи остаются в памяти и будут записаны после следующего Set
+func (c *DiskCache) Flush() error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	c.mu.Lock()
+	if c.flushTimer != nil {
+		c.flushTimer.Stop()
+		c.flushTimer = nil
+	}
+	if !c.dirty {
+		c.mu.Unlock()
+		return nil
+	}
+	data, err := json.Marshal(diskCacheFile{Version: diskCacheVersion, Entries: c.entries})
+	c.dirty = false
+	c.mu.Unlock()
+
+	if err != nil {
+		return fmt.Errorf("failed to encode cache file: %w", err)
+	}
+	return c.writeFile(data)
+}
+
+// Close записывает несохраненные изменения; вызывается при завершении приложения
+// Кэш остается рабочим: последующие Set снова планируют запись
+func (c *DiskCache) Close() error {
+	return c.Flush()
+}
+
+// newDiskEntry преобразует снимок курсов в запись на диске
+// Дата курса сохраняется, только если отличается от фактической даты снимка
+func newDiskEntry(rateData *models.RateData) diskEntry {
+	entry := diskEntry{
+		ActualDate: rateData.Date,
+		Rates:      make(map[models.Currency]diskRate, len(rateData.Rates)),
+		Partial:    rateData.Partial,
+		Source:     rateData.Source,
+		Base:       rateData.Base,
+
+		ETag:         rateData.ETag,
+		LastModified: rateData.LastModified,
+	}
+	for currency, rate := range rateData.Rates {
+		stored := diskRate{Rate: rate.Rate, Nominal: rate.Nominal}
+		if !rate.Date.Equal(rateData.Date) {
+			stored.Date = rate.Date
+		}
+		entry.Rates[currency] = stored
+	}
+	return entry
+}
+
+// rateData восстанавливает снимок курсов из записи на диске
+func (e diskEntry) rateData() *models.RateData {
+	rateData := models.NewRateData(e.ActualDate)
+	rateData.Partial = e.Partial
+	rateData.Source = e.Source
+	rateData.Base = e.Base
+	rateData.ETag = e.ETag
+	rateData.LastModified = e.LastModified
+	for currency, stored := range e.Rates {
+		date := stored.Date
+		if date.IsZero() {
+			date = e.ActualDate
+		}
+		rateData.AddRate(models.ExchangeRate{
+			Currency: currency,
+			Rate:     stored.Rate,
+			Nominal:  stored.Nominal,
+			Date:     date,
+		})
+	}
+	return rateData
+}
+
+// Size возвращает количество записей (дат), включая истекшие, но еще не вытесненные
+func (c *DiskCache) Size() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
+
+// Clear очищает кэш и удаляет файл
+func (c *DiskCache) Clear() {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.flushTimer != nil {
+		c.flushTimer.Stop()
+		c.flushTimer = nil
+	}
+	c.dirty = false
+	c.entries = make(map[string]diskEntry)
+	if err := os.Remove(c.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("cache: failed to remove %s: %v", c.path, err)
+	}
+}
+
+// expired проверяет срок жизни записи
+func (c *DiskCache) expired(entry diskEntry) bool {
+	return !entry.ExpiresAt.IsZero() && nowFunc().After(entry.ExpiresAt)
+}
+
+// load читает файл кэша
+func (c *DiskCache) load() error {
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to read cache file: %w", err)
+	}
+
+	var file diskCacheFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		// Файл поврежден (например, обрезан) - сохраняем его для диагностики и начинаем с пустого кэша
+		if renameErr := os.Rename(c.path, c.path+".corrupt"); renameErr != nil {
+			log.Printf("cache: failed to move corrupt cache file: %v", renameErr)
+		}
+		return nil
+	}
+	if file.Version != diskCacheVersion {
+		return nil
+	}
+
+	// Истекшие записи тоже загружаются: они нужны GetStale
+	for key, entry := range file.Entries {
+		c.entries[key] = entry
+	}
+	c.evictLocked()
+	return nil
+}
+
+// evictLocked отбрасывает записи самых ранних дат сверх maxEntries
+// Вызывается под c.mu
+func (c *DiskCache) evictLocked() {
+	if len(c.entries) <= c.maxEntries {
+		return
+	}
+	keys := make([]string, 0, len(c.entries))
+	for key := range c.entries {
+		keys = append(keys, key)
+	}
+	// Ключ "2025-12-20" сортируется как дата
+	sort.Strings(keys)
+	for _, key := range keys[:len(keys)-c.maxEntries] {
+		delete(c.entries, key)
+	}
+}
+
+// writeFile атомарно записывает закодированный кэш в файл
+// fsync не выполняется: файл, поврежденный при отключении питания, восстанавливается при загрузке
+// Вызывается под c.writeMu
+func (c *DiskCache) writeFile(data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp cache file: %w", err)
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to close cache file: %w", err)
+	}
+	if err := os.Rename(tmpPath, c.path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace cache file: %w", err)
+	}
+	return nil
+}
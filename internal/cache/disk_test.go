@@ -0,0 +1,344 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+func newTestDiskCache(t *testing.T, path string, ttl time.Duration) *DiskCache {
+	t.Helper()
+	c, err := NewDiskCache(path, FixedTTL(ttl))
+	if err != nil {
+		t.Fatalf("NewDiskCache() error = %v", err)
+	}
+	// Запланированная запись не должна выполняться после удаления временной директории
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+// flush записывает изменения кэша в файл (как по таймеру DiskCacheFlushDelay)
+func flush(t *testing.T, c *DiskCache) {
+	t.Helper()
+	if err := c.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+}
+
+// persist сохраняет снимок в файл path - как в прошлом запуске приложения
+func persist(t *testing.T, path string, ttl time.Duration, date time.Time, rateData *models.RateData) {
+	t.Helper()
+	c := newTestDiskCache(t, path, ttl)
+	c.Set(date, rateData)
+	flush(t, c)
+}
+
+func TestDiskCache_SurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	requested := time.Date(2025, 12, 21, 0, 0, 0, 0, time.UTC) // Воскресенье
+	actual := time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC)
+
+	first := newTestDiskCache(t, path, 24*time.Hour)
+	if _, found := first.Get(requested); found {
+		t.Fatal("Новый кэш должен быть пустым")
+	}
+	earlier := actual.AddDate(0, 0, -1)
+	saved := snapshot(models.USD, actual, dec("80.7220"))
+	saved.AddRate(models.ExchangeRate{Currency: "JPY", Rate: dec("51.2345"), Nominal: 100, Date: earlier})
+	saved.Partial = true
+	saved.Source = "cbr-xml"
+	saved.ETag, saved.LastModified = `"v1"`, "Sat, 20 Dec 2025 12:30:00 GMT"
+	first.Set(requested, saved)
+	first.Set(actual, saved)
+	if err := first.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	// "Перезапуск" - новый экземпляр читает тот же файл
+	second := newTestDiskCache(t, path, 24*time.Hour)
+	if second.Size() != 2 {
+		t.Errorf("Размер после перезапуска: ожидалось 2, получено %d", second.Size())
+	}
+
+	rateData, found := second.Get(requested)
+	if !found {
+		t.Fatal("Запись должна пережить перезапуск")
+	}
+	if rate := rateOf(rateData, models.USD); !rate.Equal(dec("80.7220")) || rate.String() != "80.7220" {
+		t.Errorf("Курс: ожидалось 80.7220 без потери точности, получено %s", rate)
+	}
+	if !rateData.Date.Equal(actual) || !rateData.Partial || rateData.Source != "cbr-xml" {
+		t.Errorf("Снимок: дата %v, Partial %v, Source %q; ожидалось %v, true, cbr-xml", rateData.Date, rateData.Partial, rateData.Source, actual)
+	}
+
+	if rateData.ETag != `"v1"` || rateData.LastModified != "Sat, 20 Dec 2025 12:30:00 GMT" {
+		t.Errorf("Валидаторы: ETag %q, Last-Modified %q; ожидались сохраненные", rateData.ETag, rateData.LastModified)
+	}
+
+	jpy := rateData.Rates["JPY"]
+	if jpy.Currency != "JPY" || !jpy.Rate.Equal(dec("51.2345")) || jpy.Nominal != 100 || !jpy.Date.Equal(earlier) {
+		t.Errorf("JPY = %+v; ожидалось 51.2345 за 100 на %v", jpy, earlier)
+	}
+	if usd := rateData.Rates[models.USD]; !usd.Date.Equal(actual) {
+		t.Errorf("Дата курса USD = %v, ожидалось %v (дата снимка)", usd.Date, actual)
+	}
+}
+
+func TestDiskCache_FileFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	date := testPastDateUTC()
+
+	c := newTestDiskCache(t, path, 24*time.Hour)
+	c.Set(date, snapshot(models.EUR, date, dec("88.1234")))
+
+	// Файл пишется не при каждом Set, а по таймеру или Flush
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Файл записан до Flush: %v", err)
+	}
+	flush(t, c)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Файл кэша не создан: %v", err)
+	}
+
+	var file struct {
+		Version int                        `json:"version"`
+		Entries map[string]json.RawMessage `json:"entries"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatalf("Некорректный JSON: %v", err)
+	}
+	if file.Version != diskCacheVersion {
+		t.Errorf("version = %d, ожидалось %d", file.Version, diskCacheVersion)
+	}
+	if _, ok := file.Entries[date.Format("2006-01-02")]; !ok {
+		t.Errorf("Нет записи с ключом %s: %s", date.Format("2006-01-02"), data)
+	}
+
+	// Временные файлы атомарной записи не остаются в директории
+	files, _ := filepath.Glob(filepath.Join(filepath.Dir(path), "*.tmp"))
+	if len(files) != 0 {
+		t.Errorf("Остались временные файлы: %v", files)
+	}
+}
+
+func TestDiskCache_CorruptFileRecovery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	if err := os.WriteFile(path, []byte(`{"version":1,"entries":{"USD:2025-12`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	c := newTestDiskCache(t, path, 24*time.Hour)
+	if c.Size() != 0 {
+		t.Errorf("Кэш из поврежденного файла должен быть пустым, размер %d", c.Size())
+	}
+
+	// Поврежденный файл сохранен для диагностики
+	if _, err := os.Stat(path + ".corrupt"); err != nil {
+		t.Errorf("Поврежденный файл не сохранен: %v", err)
+	}
+
+	// Кэш продолжает работать и записывает новый файл
+	date := testPastDateUTC()
+	c.Set(date, snapshot(models.USD, date, dec("80.5")))
+	flush(t, c)
+	if _, found := newTestDiskCache(t, path, 24*time.Hour).Get(date); !found {
+		t.Error("После восстановления запись должна сохраняться на диск")
+	}
+}
+
+func TestDiskCache_OtherVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	date := testPastDateUTC()
+	key := date.Format("2006-01-02")
+	content := `{"version":2,"entries":{"` + key + `":{"rate":80.5,"actualDate":"2025-12-20T00:00:00Z"}}}`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	c := newTestDiskCache(t, path, 24*time.Hour)
+	if _, found := c.Get(date); found {
+		t.Error("Записи файла другой версии (v2 с курсом одной валюты) не должны читаться")
+	}
+}
+
+func TestDiskCache_TTL(t *testing.T) {
+	clock := withFakeClock(t, time.Date(2025, 12, 20, 12, 0, 0, 0, time.UTC))
+	path := filepath.Join(t.TempDir(), "rates.json")
+	date := time.Date(2025, 12, 19, 0, 0, 0, 0, time.UTC)
+
+	c := newTestDiskCache(t, path, time.Hour)
+	c.Set(date, snapshot(models.USD, date, dec("80.5")))
+
+	clock.Advance(2 * time.Hour)
+	if _, found := c.Get(date); found {
+		t.Error("Истекшая запись не должна возвращаться")
+	}
+
+	if rateData, found := c.GetStale(date); !found || !rateData.Rates[models.USD].Rate.Equal(dec("80.5")) {
+		t.Errorf("GetStale() = %v, %v; ожидалась истекшая запись", rateData, found)
+	}
+
+	// Истекшая запись переживает перезапуск: Get её не возвращает, GetStale - возвращает
+	flush(t, c)
+	restored := newTestDiskCache(t, path, time.Hour)
+	if size := restored.Size(); size != 1 {
+		t.Errorf("Размер после загрузки: ожидалось 1, получено %d", size)
+	}
+	if _, found := restored.Get(date); found {
+		t.Error("Истекшая запись не должна возвращаться после перезапуска")
+	}
+	if _, found := restored.GetStale(date); !found {
+		t.Error("GetStale() после перезапуска не нашел истекшую запись")
+	}
+	if _, found := restored.GetStale(date.AddDate(0, 0, -1)); found {
+		t.Error("GetStale() нашел запись на дату, которой нет в кэше")
+	}
+}
+
+func TestDiskCache_ReadError(t *testing.T) {
+	// Путь - директория: файл "существует", но не читается
+	if _, err := NewDiskCache(t.TempDir(), FixedTTL(time.Hour)); err == nil {
+		t.Error("NewDiskCache() должна вернуть ошибку, если файл не читается")
+	}
+}
+
+func TestDiskCache_Clear(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	date := testPastDateUTC()
+
+	c := newTestDiskCache(t, path, time.Hour)
+	c.Set(date, snapshot(models.USD, date, dec("80.5")))
+	c.Clear()
+
+	if c.Size() != 0 {
+		t.Errorf("Размер после Clear: ожидалось 0, получено %d", c.Size())
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Файл кэша должен быть удален, Stat: %v", err)
+	}
+}
+
+func TestDiskCache_Concurrent(t *testing.T) {
+	c := newTestDiskCache(t, filepath.Join(t.TempDir(), "rates.json"), time.Hour)
+	date := testPastDateUTC()
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func(day int) {
+			defer wg.Done()
+			d := date.AddDate(0, 0, -day)
+			c.Set(d, snapshot(models.USD, d, dec("80.5")))
+			c.Get(d)
+		}(i)
+	}
+	wg.Wait()
+
+	if c.Size() != 20 {
+		t.Errorf("Размер: ожидалось 20, получено %d", c.Size())
+	}
+}
+
+func TestDiskCache_SetMany(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	c := newTestDiskCache(t, path, Forever)
+
+	snapshots := make(map[time.Time]*models.RateData)
+	for i := 0; i < 5; i++ {
+		date := testPastDateUTC().AddDate(0, 0, -i)
+		snapshots[date] = snapshot(models.USD, date, dec("80.5"))
+	}
+	c.SetMany(snapshots)
+	flush(t, c)
+
+	// Все снимки пакета записаны в файл
+	restored := newTestDiskCache(t, path, Forever)
+	if restored.Size() != len(snapshots) {
+		t.Errorf("После перезапуска Size() = %d, ожидалось %d", restored.Size(), len(snapshots))
+	}
+}
+
+func TestDiskCache_DeferredWrite(t *testing.T) {
+	original := diskFlushDelay
+	diskFlushDelay = 10 * time.Millisecond
+	t.Cleanup(func() { diskFlushDelay = original })
+
+	path := filepath.Join(t.TempDir(), "rates.json")
+	c := newTestDiskCache(t, path, Forever)
+	date := testPastDateUTC()
+	for i := 0; i < 3; i++ {
+		d := date.AddDate(0, 0, -i)
+		c.Set(d, snapshot(models.USD, d, dec("80.5")))
+	}
+
+	// Все Set за время задержки записываются одной перезаписью по таймеру
+	deadline := time.Now().Add(5 * time.Second)
+	for newTestDiskCache(t, path, Forever).Size() != 3 {
+		if time.Now().After(deadline) {
+			t.Fatal("Записи не сохранены в файл по таймеру")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// Clear отменяет запланированную запись: файл не появляется снова
+	c.Set(date.AddDate(0, 0, -5), snapshot(models.USD, date, dec("80.5")))
+	c.Clear()
+	time.Sleep(5 * diskFlushDelay)
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Файл после Clear: %v, ожидалось отсутствие", err)
+	}
+}
+
+func TestDiskCache_MaxEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	c := newTestDiskCache(t, path, Forever)
+	c.maxEntries = 3
+
+	newest := testPastDateUTC()
+	snapshots := make(map[time.Time]*models.RateData)
+	for i := 0; i < 5; i++ {
+		date := newest.AddDate(0, 0, -i)
+		snapshots[date] = snapshot(models.USD, date, dec("80.5"))
+	}
+	c.SetMany(snapshots)
+
+	if c.Size() != 3 {
+		t.Fatalf("Size() = %d, ожидалось 3", c.Size())
+	}
+	// Отбрасываются записи самых ранних дат
+	if _, found := c.Get(newest.AddDate(0, 0, -4)); found {
+		t.Error("Запись самой ранней даты должна отбрасываться")
+	}
+	if _, found := c.Get(newest); !found {
+		t.Error("Запись последней даты должна сохраняться")
+	}
+}
+
+func TestDefaultDiskCachePath(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("APPDATA", tmpDir)
+
+	path, err := DefaultDiskCachePath()
+	if err != nil {
+		t.Fatalf("DefaultDiskCachePath() error = %v", err)
+	}
+
+	want := filepath.Join(tmpDir, "CurRate", "rates.json")
+	if path != want {
+		t.Errorf("DefaultDiskCachePath() = %s, ожидалось %s", path, want)
+	}
+	if info, err := os.Stat(filepath.Dir(path)); err != nil || !info.IsDir() {
+		t.Errorf("Директория приложения не создана: %v", err)
+	}
+	if !strings.HasSuffix(path, diskCacheFileName) {
+		t.Errorf("Имя файла: %s", path)
+	}
+}
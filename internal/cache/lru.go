@@ -12,26 +12,30 @@ import (
 var nowFunc = time.Now
 var sinceFunc = time.Since
 
-// LRUCache - потокобезопасный LRU кэш с поддержкой TTL
+// LRUCache - потокобезопасный LRU кэш снимков курсов с поддержкой TTL
+// Запись - все курсы ЦБ РФ на одну дату (models.RateData)
+// Истекшие записи не удаляются до вытеснения: Get их не возвращает,
+// а GetStale отдает для режима stale-while-revalidate (например, когда ЦБ РФ недоступен)
 // Использует комбинацию map и двусвязного списка для O(1) операций
 type LRUCache struct {
 	mu      sync.RWMutex
 	cache   map[string]*list.Element // Хэш-таблица для быстрого доступа
 	lru     *list.List               // Двусвязный список для LRU порядка
 	maxSize int                      // Максимальный размер кэша
-	ttl     time.Duration            // Время жизни записи
+	ttl     time.Duration            // Время жизни записи (для NewLRUCache)
+	policy  TTLPolicy                // Политика времени жизни записи
 }
 
 // Entry - запись в кэше
 type Entry struct {
-	key        string
-	rate       float64
-	timestamp  time.Time
-	actualDate time.Time // Фактическая дата курса из XML (может отличаться от запрошенной)
+	key       string
+	rateData  *models.RateData // Снимок курсов, rateData.Date - фактическая дата из XML (может отличаться от запрошенной)
+	timestamp time.Time
+	ttl       time.Duration // Время жизни записи по политике кэша на момент Set
 }
 
 // NewLRUCache создает новый LRU кэш с заданным размером и TTL
-// maxSize - максимальное количество записей в кэше (должно быть > 0)
+// maxSize - максимальное количество записей (дат) в кэше (должно быть > 0)
 // ttl - время жизни записи (например, 24 часа)
 //
 // Паникует, если maxSize <= 0 (программистская ошибка).
@@ -40,69 +44,127 @@ type Entry struct {
 //
 //	cache := cache.NewLRUCache(100, 24*time.Hour)
 func NewLRUCache(maxSize int, ttl time.Duration) *LRUCache {
+	c := NewLRUCacheWithPolicy(maxSize, FixedTTL(ttl))
+	c.ttl = ttl
+	return c
+}
+
+// NewLRUCacheWithPolicy создает LRU кэш, в котором время жизни записи
+// определяется политикой по датам курса
+//
+// Паникует, если maxSize <= 0 или policy == nil (программистская ошибка).
+//
+// Пример использования:
+//
+//	// Курсы прошедших дат - бессрочно, на сегодня - час
+//	cache := cache.NewLRUCacheWithPolicy(1000, cache.DefaultDatePolicy)
+func NewLRUCacheWithPolicy(maxSize int, policy TTLPolicy) *LRUCache {
 	if maxSize <= 0 {
 		panic("cache: maxSize must be positive")
 	}
+	if policy == nil {
+		panic("cache: policy must not be nil")
+	}
 	return &LRUCache{
 		cache:   make(map[string]*list.Element),
 		lru:     list.New(),
 		maxSize: maxSize,
-		ttl:     ttl,
+		policy:  policy,
 	}
 }
 
-// Get получает курс из кэша для указанной валюты и даты
-// Возвращает (rate, actualDate, true) если запись найдена и не истекла
-// Возвращает (0, time.Time{}, false) если запись не найдена или истекла
+// Get получает снимок курсов из кэша для указанной даты
+// Возвращает (rateData, true) если запись найдена и не истекла
+// Возвращает (nil, false) если запись не найдена или истекла
+// Снимок общий для всех вызывающих и не должен изменяться
 //
 // Метод thread-safe и обновляет LRU порядок при успешном доступе
-func (c *LRUCache) Get(currency models.Currency, date time.Time) (float64, time.Time, bool) {
+func (c *LRUCache) Get(date time.Time) (*models.RateData, bool) {
+	return c.get(date, false)
+}
+
+// GetStale получает снимок курсов для указанной даты без учета TTL
+// Возвращает (rateData, true) для найденной записи, в том числе истекшей
+//
+// Метод thread-safe и обновляет LRU порядок при успешном доступе
+func (c *LRUCache) GetStale(date time.Time) (*models.RateData, bool) {
+	return c.get(date, true)
+}
+
+// get - общая реализация Get и GetStale
+func (c *LRUCache) get(date time.Time, allowStale bool) (*models.RateData, bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	key := c.makeKey(currency, date)
-	elem, exists := c.cache[key]
+	elem, exists := c.cache[c.makeKey(date)]
 	if !exists {
-		return 0, time.Time{}, false
+		return nil, false
 	}
 
 	entry, ok := elem.Value.(*Entry)
 	if !ok {
-		return 0, time.Time{}, false
+		return nil, false
 	}
 
-	// Проверка TTL
-	if sinceFunc(entry.timestamp) > c.ttl {
-		// TTL истек - удаляем запись
-		c.lru.Remove(elem)
-		delete(c.cache, key)
-		return 0, time.Time{}, false
+	// Проверка TTL: истекшая запись остается в кэше для GetStale
+	if !allowStale && sinceFunc(entry.timestamp) > entry.ttl {
+		return nil, false
 	}
 
 	// Переместить в конец списка (most recently used)
 	c.lru.MoveToBack(elem)
-	return entry.rate, entry.actualDate, true
+	return entry.rateData, true
 }
 
-// Set сохраняет курс в кэш для указанной валюты и запрошенной даты
+// Set сохраняет снимок курсов в кэш для запрошенной даты
 // requestedDate - запрошенная дата (используется как ключ кэша)
-// actualDate - фактическая дата курса из XML (сохраняется в Entry, может отличаться от запрошенной)
+// rateData.Date - фактическая дата курсов из XML (может отличаться от запрошенной)
 // Если запись уже существует - обновляет её и обновляет timestamp
 // Если кэш переполнен - вытесняет наименее используемую запись (LRU)
 //
 // Метод thread-safe
-func (c *LRUCache) Set(currency models.Currency, requestedDate time.Time, rate float64, actualDate time.Time) {
+func (c *LRUCache) Set(requestedDate time.Time, rateData *models.RateData) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setLocked(c.makeKey(requestedDate), rateData, c.policy.TTL(requestedDate, rateData.Date))
+}
+
+// SetMany сохраняет несколько снимков курсов (ключ - запрошенная дата) под одной блокировкой
+//
+// Метод thread-safe
+func (c *LRUCache) SetMany(snapshots map[time.Time]*models.RateData) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	for requestedDate, rateData := range snapshots {
+		c.setLocked(c.makeKey(requestedDate), rateData, c.policy.TTL(requestedDate, rateData.Date))
+	}
+}
 
-	key := c.makeKey(currency, requestedDate)
+// setWithExpiry сохраняет снимок со сроком жизни expiresAt (нулевой - бессрочно) вместо политики кэша
+// Нужен TieredCache: запись, поднятая с диска, истекает тогда же, когда и на диске
+// Уже истекшая запись не сохраняется
+func (c *LRUCache) setWithExpiry(date time.Time, rateData *models.RateData, expiresAt time.Time) {
+	ttl := Forever
+	if !expiresAt.IsZero() {
+		if ttl = expiresAt.Sub(nowFunc()); ttl <= 0 {
+			return
+		}
+	}
 
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setLocked(c.makeKey(date), rateData, ttl)
+}
+
+// setLocked сохраняет запись с временем жизни ttl
+// Вызывается под c.mu
+func (c *LRUCache) setLocked(key string, rateData *models.RateData, ttl time.Duration) {
 	// Если уже существует - обновить
 	if elem, exists := c.cache[key]; exists {
 		if entry, ok := elem.Value.(*Entry); ok {
-			entry.rate = rate
+			entry.rateData = rateData
 			entry.timestamp = nowFunc()
-			entry.actualDate = actualDate // Обновляем фактическую дату
+			entry.ttl = ttl
 			c.lru.MoveToBack(elem)
 			return
 		}
@@ -121,22 +183,27 @@ func (c *LRUCache) Set(currency models.Currency, requestedDate time.Time, rate f
 
 	// Добавить новую запись
 	entry := &Entry{
-		key:        key,
-		rate:       rate,
-		timestamp:  nowFunc(),
-		actualDate: actualDate, // Сохраняем фактическую дату (может отличаться от requestedDate)
+		key:       key,
+		rateData:  rateData, // Фактическая дата rateData.Date может отличаться от запрошенной
+		timestamp: nowFunc(),
+		ttl:       ttl,
 	}
 	elem := c.lru.PushBack(entry)
 	c.cache[key] = elem
 }
 
-// makeKey создает уникальный ключ для валюты и даты
-// Формат: "USD:2025-12-20"
-func (c *LRUCache) makeKey(currency models.Currency, date time.Time) string {
-	return string(currency) + ":" + date.Format("2006-01-02")
+// makeKey создает уникальный ключ для даты
+// Формат: "2025-12-20"
+func (c *LRUCache) makeKey(date time.Time) string {
+	return cacheKey(date)
+}
+
+// cacheKey - общий формат ключа для LRUCache и DiskCache: "2025-12-20"
+func cacheKey(date time.Time) string {
+	return date.Format("2006-01-02")
 }
 
-// Size возвращает текущий размер кэша (количество записей)
+// Size возвращает текущий размер кэша (количество записей, включая истекшие)
 // Метод thread-safe
 func (c *LRUCache) Size() int {
 	c.mu.RLock()
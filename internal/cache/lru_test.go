@@ -101,19 +101,19 @@ func TestLRUCache_SetAndGet(t *testing.T) {
 
 	t.Run("Set и Get одной записи", func(t *testing.T) {
 		date := baseDate
-		cache.Set(models.USD, date, 80.5, date) // requestedDate и actualDate одинаковы
+		cache.Set(date, snapshot(models.USD, date, dec("80.5"))) // requestedDate и actualDate одинаковы
 
-		rate, actualDate, found := cache.Get(models.USD, date)
+		rateData, found := cache.Get(date)
 		if !found {
 			t.Fatal("Запись должна быть найдена")
 		}
 
-		if rate != 80.5 {
+		if rate := rateOf(rateData, models.USD); !rate.Equal(dec("80.5")) {
 			t.Errorf("Курс: ожидалось 80.5, получено %v", rate)
 		}
 
-		if !actualDate.Equal(date) {
-			t.Errorf("Фактическая дата: ожидалось %v, получено %v", date, actualDate)
+		if !rateData.Date.Equal(date) {
+			t.Errorf("Фактическая дата: ожидалось %v, получено %v", date, rateData.Date)
 		}
 
 		if cache.Size() != 1 {
@@ -123,18 +123,14 @@ func TestLRUCache_SetAndGet(t *testing.T) {
 
 	t.Run("Get несуществующей записи", func(t *testing.T) {
 		date := baseDate.AddDate(0, 0, 5)
-		rate, actualDate, found := cache.Get(models.EUR, date)
+		rateData, found := cache.Get(date)
 
 		if found {
 			t.Error("Запись не должна быть найдена")
 		}
 
-		if rate != 0 {
-			t.Errorf("Rate должен быть 0, получено %v", rate)
-		}
-
-		if !actualDate.IsZero() {
-			t.Errorf("ActualDate должен быть нулевым, получено %v", actualDate)
+		if rateData != nil {
+			t.Errorf("RateData должен быть nil, получено %v", rateData)
 		}
 	})
 
@@ -144,27 +140,32 @@ func TestLRUCache_SetAndGet(t *testing.T) {
 		date1 := baseDate
 		date2 := baseDate.AddDate(0, 0, 1)
 
-		cache.Set(models.USD, date1, 80.5, date1) // requestedDate и actualDate одинаковы
-		cache.Set(models.EUR, date1, 94.2, date1)
-		cache.Set(models.USD, date2, 81.0, date2)
+		// Снимок на дату содержит курсы всех валют
+		snapshot1 := snapshot(models.USD, date1, dec("80.5"))
+		snapshot1.AddRate(models.ExchangeRate{Currency: models.EUR, Rate: dec("94.2"), Nominal: 1, Date: date1})
 
-		if cache.Size() != 3 {
-			t.Errorf("Размер: ожидалось 3, получено %d", cache.Size())
+		cache.Set(date1, snapshot1) // requestedDate и actualDate одинаковы
+		cache.Set(date2, snapshot(models.USD, date2, dec("81.0")))
+
+		if cache.Size() != 2 {
+			t.Errorf("Размер: ожидалось 2 (по записи на дату), получено %d", cache.Size())
 		}
 
 		// Проверяем все записи
-		rate1, _, _ := cache.Get(models.USD, date1)
-		if rate1 != 80.5 {
+		rateData, _ := cache.Get(date1)
+		rate1 := rateOf(rateData, models.USD)
+		if !rate1.Equal(dec("80.5")) {
 			t.Errorf("USD date1: ожидалось 80.5, получено %v", rate1)
 		}
 
-		rate2, _, _ := cache.Get(models.EUR, date1)
-		if rate2 != 94.2 {
+		rate2 := rateOf(rateData, models.EUR)
+		if !rate2.Equal(dec("94.2")) {
 			t.Errorf("EUR date1: ожидалось 94.2, получено %v", rate2)
 		}
 
-		rate3, _, _ := cache.Get(models.USD, date2)
-		if rate3 != 81.0 {
+		rateData, _ = cache.Get(date2)
+		rate3 := rateOf(rateData, models.USD)
+		if !rate3.Equal(dec("81.0")) {
 			t.Errorf("USD date2: ожидалось 81.0, получено %v", rate3)
 		}
 	})
@@ -176,17 +177,18 @@ func TestLRUCache_Update(t *testing.T) {
 	date := testPastDateUTC()
 
 	// Первоначальное значение
-	cache.Set(models.USD, date, 80.5, date) // requestedDate и actualDate одинаковы
+	cache.Set(date, snapshot(models.USD, date, dec("80.5"))) // requestedDate и actualDate одинаковы
 
 	// Обновление
-	cache.Set(models.USD, date, 85.0, date)
+	cache.Set(date, snapshot(models.USD, date, dec("85.0")))
 
-	rate, _, found := cache.Get(models.USD, date)
+	rateData, found := cache.Get(date)
+	rate := rateOf(rateData, models.USD)
 	if !found {
 		t.Fatal("Запись должна быть найдена")
 	}
 
-	if rate != 85.0 {
+	if !rate.Equal(dec("85.0")) {
 		t.Errorf("Курс: ожидалось 85.0 (обновленное значение), получено %v", rate)
 	}
 
@@ -208,39 +210,39 @@ func TestLRUCache_Eviction(t *testing.T) {
 		date1 := date.AddDate(0, 0, 1)
 		date2 := date.AddDate(0, 0, 2)
 		date3 := date.AddDate(0, 0, 3)
-		cache.Set(models.USD, date0, 80.0, date0) // requestedDate и actualDate одинаковы
-		cache.Set(models.USD, date1, 81.0, date1)
-		cache.Set(models.USD, date2, 82.0, date2)
+		cache.Set(date0, snapshot(models.USD, date0, dec("80.0"))) // requestedDate и actualDate одинаковы
+		cache.Set(date1, snapshot(models.USD, date1, dec("81.0")))
+		cache.Set(date2, snapshot(models.USD, date2, dec("82.0")))
 
 		if cache.Size() != 3 {
 			t.Errorf("Размер: ожидалось 3, получено %d", cache.Size())
 		}
 
 		// Добавляем 4-ю запись - должна вытеснить первую
-		cache.Set(models.USD, date3, 83.0, date3)
+		cache.Set(date3, snapshot(models.USD, date3, dec("83.0")))
 
 		if cache.Size() != 3 {
 			t.Errorf("Размер: ожидалось 3 (после вытеснения), получено %d", cache.Size())
 		}
 
 		// Первая запись должна быть вытеснена
-		_, _, found := cache.Get(models.USD, date0)
+		_, found := cache.Get(date0)
 		if found {
 			t.Error("Первая запись должна быть вытеснена")
 		}
 
 		// Остальные записи должны остаться
-		_, _, found = cache.Get(models.USD, date1)
+		_, found = cache.Get(date1)
 		if !found {
 			t.Error("Вторая запись должна остаться")
 		}
 
-		_, _, found = cache.Get(models.USD, date.AddDate(0, 0, 2))
+		_, found = cache.Get(date.AddDate(0, 0, 2))
 		if !found {
 			t.Error("Третья запись должна остаться")
 		}
 
-		_, _, found = cache.Get(models.USD, date.AddDate(0, 0, 3))
+		_, found = cache.Get(date.AddDate(0, 0, 3))
 		if !found {
 			t.Error("Четвертая запись должна быть добавлена")
 		}
@@ -255,35 +257,35 @@ func TestLRUCache_Eviction(t *testing.T) {
 		date1 := date.AddDate(0, 0, 1)
 		date2 := date.AddDate(0, 0, 2)
 		date3 := date.AddDate(0, 0, 3)
-		cache.Set(models.USD, date0, 80.0, date0) // oldest, requestedDate и actualDate одинаковы
-		cache.Set(models.USD, date1, 81.0, date1)
-		cache.Set(models.USD, date2, 82.0, date2) // newest
+		cache.Set(date0, snapshot(models.USD, date0, dec("80.0"))) // oldest, requestedDate и actualDate одинаковы
+		cache.Set(date1, snapshot(models.USD, date1, dec("81.0")))
+		cache.Set(date2, snapshot(models.USD, date2, dec("82.0"))) // newest
 
 		// Обращаемся к oldest записи - она становится newest
-		cache.Get(models.USD, date0)
+		cache.Get(date0)
 
 		// Добавляем новую запись - должна вытеснить вторую (теперь она oldest)
-		cache.Set(models.USD, date3, 83.0, date3)
+		cache.Set(date3, snapshot(models.USD, date3, dec("83.0")))
 
 		// Первая запись должна остаться (была перемещена в конец)
-		_, _, found := cache.Get(models.USD, date0)
+		_, found := cache.Get(date0)
 		if !found {
 			t.Error("Первая запись должна остаться (была обновлена через Get)")
 		}
 
 		// Вторая запись должна быть вытеснена (стала oldest)
-		_, _, found = cache.Get(models.USD, date1)
+		_, found = cache.Get(date1)
 		if found {
 			t.Error("Вторая запись должна быть вытеснена")
 		}
 
 		// Третья и четвертая должны остаться
-		_, _, found = cache.Get(models.USD, date2)
+		_, found = cache.Get(date2)
 		if !found {
 			t.Error("Третья запись должна остаться")
 		}
 
-		_, _, found = cache.Get(models.USD, date3)
+		_, found = cache.Get(date3)
 		if !found {
 			t.Error("Четвертая запись должна быть добавлена")
 		}
@@ -293,36 +295,55 @@ func TestLRUCache_Eviction(t *testing.T) {
 // Тесты TTL
 
 func TestLRUCache_TTL(t *testing.T) {
-	t.Run("Запись с истекшим TTL удаляется", func(t *testing.T) {
+	t.Run("Запись с истекшим TTL не возвращается Get", func(t *testing.T) {
 		// Короткий TTL для теста
 		start := time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC)
 		clock := withFakeClock(t, start)
 		cache := NewLRUCache(100, 100*time.Millisecond)
 		date := testPastDateUTC()
 
-		cache.Set(models.USD, date, 80.5, date) // requestedDate и actualDate одинаковы
+		cache.Set(date, snapshot(models.USD, date, dec("80.5"))) // requestedDate и actualDate одинаковы
 
 		// Проверяем что запись есть
-		rate, _, found := cache.Get(models.USD, date)
+		rateData, found := cache.Get(date)
+		rate := rateOf(rateData, models.USD)
 		if !found {
 			t.Fatal("Запись должна быть найдена")
 		}
-		if rate != 80.5 {
+		if !rate.Equal(dec("80.5")) {
 			t.Errorf("Курс: ожидалось 80.5, получено %v", rate)
 		}
 
 		// Двигаем время за пределы TTL
 		clock.Advance(150 * time.Millisecond)
 
-		// Запись должна быть удалена
-		_, _, found = cache.Get(models.USD, date)
+		// Get не возвращает истекшую запись
+		_, found = cache.Get(date)
 		if found {
-			t.Error("Запись с истекшим TTL должна быть удалена")
+			t.Error("Запись с истекшим TTL не должна возвращаться Get")
+		}
+
+		// Истекшая запись остается для GetStale до вытеснения
+		rateData, found = cache.GetStale(date)
+		if !found || !rateOf(rateData, models.USD).Equal(dec("80.5")) {
+			t.Errorf("GetStale() = %+v, %v; ожидалась истекшая запись 80.5", rateData, found)
 		}
+		if cache.Size() != 1 {
+			t.Errorf("Размер: ожидалось 1 (истекшая запись хранится), получено %d", cache.Size())
+		}
+	})
+
+	t.Run("Истекшие записи вытесняются как обычные", func(t *testing.T) {
+		clock := withFakeClock(t, time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC))
+		cache := NewLRUCache(1, time.Minute)
+		date := testPastDateUTC()
+
+		cache.Set(date, snapshot(models.USD, date, dec("80.5")))
+		clock.Advance(time.Hour)
+		cache.Set(date.AddDate(0, 0, 1), snapshot(models.USD, date.AddDate(0, 0, 1), dec("81.0")))
 
-		// Размер должен уменьшиться
-		if cache.Size() != 0 {
-			t.Errorf("Размер: ожидалось 0 (после истечения TTL), получено %d", cache.Size())
+		if _, found := cache.GetStale(date); found {
+			t.Error("Истекшая запись должна вытесняться при переполнении")
 		}
 	})
 
@@ -332,17 +353,18 @@ func TestLRUCache_TTL(t *testing.T) {
 		cache := NewLRUCache(100, 1*time.Second)
 		date := testPastDateUTC()
 
-		cache.Set(models.USD, date, 80.5, date) // requestedDate и actualDate одинаковы
+		cache.Set(date, snapshot(models.USD, date, dec("80.5"))) // requestedDate и actualDate одинаковы
 
 		// Небольшой шаг времени (меньше TTL)
 		clock.Advance(100 * time.Millisecond)
 
 		// Запись должна быть доступна
-		rate, _, found := cache.Get(models.USD, date)
+		rateData, found := cache.Get(date)
+		rate := rateOf(rateData, models.USD)
 		if !found {
 			t.Error("Запись с актуальным TTL должна быть найдена")
 		}
-		if rate != 80.5 {
+		if !rate.Equal(dec("80.5")) {
 			t.Errorf("Курс: ожидалось 80.5, получено %v", rate)
 		}
 	})
@@ -353,23 +375,24 @@ func TestLRUCache_TTL(t *testing.T) {
 		cache := NewLRUCache(100, 200*time.Millisecond)
 		date := testPastDateUTC()
 
-		cache.Set(models.USD, date, 80.5, date) // requestedDate и actualDate одинаковы
+		cache.Set(date, snapshot(models.USD, date, dec("80.5"))) // requestedDate и actualDate одинаковы
 
 		// Двигаем время вперед
 		clock.Advance(120 * time.Millisecond)
 
 		// Обновляем запись
-		cache.Set(models.USD, date, 81.0, date)
+		cache.Set(date, snapshot(models.USD, date, dec("81.0")))
 
 		// Двигаем время вперед
 		clock.Advance(120 * time.Millisecond)
 
 		// Запись должна быть доступна (timestamp был обновлен)
-		rate, _, found := cache.Get(models.USD, date)
+		rateData, found := cache.Get(date)
+		rate := rateOf(rateData, models.USD)
 		if !found {
 			t.Error("Обновленная запись должна быть найдена (timestamp обновлен)")
 		}
-		if rate != 81.0 {
+		if !rate.Equal(dec("81.0")) {
 			t.Errorf("Курс: ожидалось 81.0, получено %v", rate)
 		}
 	})
@@ -398,10 +421,10 @@ func TestLRUCache_ThreadSafety(t *testing.T) {
 
 				// Set
 				reqDate := date.AddDate(0, 0, id%10)
-				cache.Set(currency, reqDate, float64(80+id), reqDate) // requestedDate и actualDate одинаковы
+				cache.Set(reqDate, snapshot(currency, reqDate, models.NewDecimalFromInt(int64(80+id)))) // requestedDate и actualDate одинаковы
 
 				// Get
-				cache.Get(currency, date.AddDate(0, 0, id%10)) // Игнорируем возвращаемые значения
+				cache.Get(date.AddDate(0, 0, id%10)) // Игнорируем возвращаемые значения
 
 				// Size
 				cache.Size()
@@ -417,7 +440,7 @@ func TestLRUCache_ThreadSafety(t *testing.T) {
 		}
 
 		// Проверяем что можем получить записи
-		_, _, found := cache.Get(models.USD, date)
+		_, found := cache.Get(date)
 		_ = found // Результат не критичен, главное что нет паники
 	})
 
@@ -433,20 +456,21 @@ func TestLRUCache_ThreadSafety(t *testing.T) {
 			wg.Add(1)
 			go func(id int) {
 				defer wg.Done()
-				cache.Set(models.USD, date, float64(80+id), date) // requestedDate и actualDate одинаковы
+				cache.Set(date, snapshot(models.USD, date, models.NewDecimalFromInt(int64(80+id)))) // requestedDate и actualDate одинаковы
 			}(i)
 		}
 
 		wg.Wait()
 
 		// Проверяем что запись существует
-		rate, _, found := cache.Get(models.USD, date)
+		rateData, found := cache.Get(date)
+		rate := rateOf(rateData, models.USD)
 		if !found {
 			t.Error("Запись должна быть найдена после конкурентных Set")
 		}
 
 		// Курс должен быть одним из установленных значений (80-129)
-		if rate < 80 || rate >= 130 {
+		if rate.Cmp(dec("80")) < 0 || rate.Cmp(dec("130")) >= 0 {
 			t.Errorf("Курс вне ожидаемого диапазона: %v", rate)
 		}
 
@@ -468,7 +492,7 @@ func TestLRUCache_ThreadSafety(t *testing.T) {
 			go func(id int) {
 				defer wg.Done()
 				reqDate := date.AddDate(0, 0, id)
-				cache.Set(models.USD, reqDate, float64(80+id), reqDate) // requestedDate и actualDate одинаковы
+				cache.Set(reqDate, snapshot(models.USD, reqDate, models.NewDecimalFromInt(int64(80+id)))) // requestedDate и actualDate одинаковы
 			}(i)
 		}
 
@@ -477,7 +501,7 @@ func TestLRUCache_ThreadSafety(t *testing.T) {
 			wg.Add(1)
 			go func(id int) {
 				defer wg.Done()
-				cache.Get(models.USD, date.AddDate(0, 0, id)) // Игнорируем возвращаемые значения
+				cache.Get(date.AddDate(0, 0, id)) // Игнорируем возвращаемые значения
 			}(i)
 		}
 
@@ -498,12 +522,11 @@ func TestLRUCache_Clear(t *testing.T) {
 	date := testPastDateUTC()
 
 	// Добавляем записи
-	cache.Set(models.USD, date, 80.5, date) // requestedDate и actualDate одинаковы
-	cache.Set(models.EUR, date, 94.2, date)
-	cache.Set(models.USD, date.AddDate(0, 0, 1), 81.0, date.AddDate(0, 0, 1))
+	cache.Set(date, snapshot(models.USD, date, dec("80.5"))) // requestedDate и actualDate одинаковы
+	cache.Set(date.AddDate(0, 0, 1), snapshot(models.USD, date.AddDate(0, 0, 1), dec("81.0")))
 
-	if cache.Size() != 3 {
-		t.Errorf("Размер до Clear: ожидалось 3, получено %d", cache.Size())
+	if cache.Size() != 2 {
+		t.Errorf("Размер до Clear: ожидалось 2, получено %d", cache.Size())
 	}
 
 	// Очищаем
@@ -514,18 +537,18 @@ func TestLRUCache_Clear(t *testing.T) {
 	}
 
 	// Проверяем что все записи удалены
-	_, _, found := cache.Get(models.USD, date)
+	_, found := cache.Get(date)
 	if found {
 		t.Error("Запись должна быть удалена после Clear")
 	}
 
-	_, _, found = cache.Get(models.EUR, date)
+	_, found = cache.Get(date.AddDate(0, 0, 1))
 	if found {
 		t.Error("Запись должна быть удалена после Clear")
 	}
 
 	// Проверяем что можем добавить новые записи
-	cache.Set(models.USD, date, 85.0, date) // requestedDate и actualDate одинаковы
+	cache.Set(date, snapshot(models.USD, date, dec("85.0"))) // requestedDate и actualDate одинаковы
 	if cache.Size() != 1 {
 		t.Errorf("Размер после добавления: ожидалось 1, получено %d", cache.Size())
 	}
@@ -539,33 +562,29 @@ func TestLRUCache_MakeKey(t *testing.T) {
 
 	tests := []struct {
 		name     string
-		currency models.Currency
 		date     time.Time
 		expected string
 	}{
 		{
-			name:     "USD базовая дата",
-			currency: models.USD,
+			name:     "Базовая дата",
 			date:     baseDate,
-			expected: "USD:" + baseDate.Format("2006-01-02"),
+			expected: baseDate.Format("2006-01-02"),
 		},
 		{
-			name:     "EUR базовая дата минус 30 дней",
-			currency: models.EUR,
+			name:     "Базовая дата минус 30 дней",
 			date:     baseDate.AddDate(0, 0, -30),
-			expected: "EUR:" + baseDate.AddDate(0, 0, -30).Format("2006-01-02"),
+			expected: baseDate.AddDate(0, 0, -30).Format("2006-01-02"),
 		},
 		{
-			name:     "USD с временем (должно игнорироваться)",
-			currency: models.USD,
+			name:     "Дата с временем (время должно игнорироваться)",
 			date:     time.Date(baseDate.Year(), baseDate.Month(), baseDate.Day(), 15, 30, 45, 0, time.UTC),
-			expected: "USD:" + baseDate.Format("2006-01-02"),
+			expected: baseDate.Format("2006-01-02"),
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := cache.makeKey(tt.currency, tt.date)
+			got := cache.makeKey(tt.date)
 			if got != tt.expected {
 				t.Errorf("makeKey: ожидалось %s, получено %s", tt.expected, got)
 			}
@@ -582,7 +601,7 @@ func BenchmarkLRUCache_Set(b *testing.B) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		reqDate := date.AddDate(0, 0, i%100)
-		cache.Set(models.USD, reqDate, float64(80+i), reqDate) // requestedDate и actualDate одинаковы
+		cache.Set(reqDate, snapshot(models.USD, reqDate, models.NewDecimalFromInt(int64(80+i)))) // requestedDate и actualDate одинаковы
 	}
 }
 
@@ -593,12 +612,12 @@ func BenchmarkLRUCache_Get(b *testing.B) {
 	// Предварительно заполняем кэш
 	for i := 0; i < 100; i++ {
 		reqDate := date.AddDate(0, 0, i)
-		cache.Set(models.USD, reqDate, float64(80+i), reqDate) // requestedDate и actualDate одинаковы
+		cache.Set(reqDate, snapshot(models.USD, reqDate, models.NewDecimalFromInt(int64(80+i)))) // requestedDate и actualDate одинаковы
 	}
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		cache.Get(models.USD, date.AddDate(0, 0, i%100))
+		cache.Get(date.AddDate(0, 0, i%100))
 	}
 }
 
@@ -611,9 +630,9 @@ func BenchmarkLRUCache_Concurrent(b *testing.B) {
 		for pb.Next() {
 			if i%2 == 0 {
 				reqDate := date.AddDate(0, 0, i%100)
-				cache.Set(models.USD, reqDate, float64(80+i), reqDate) // requestedDate и actualDate одинаковы
+				cache.Set(reqDate, snapshot(models.USD, reqDate, models.NewDecimalFromInt(int64(80+i)))) // requestedDate и actualDate одинаковы
 			} else {
-				cache.Get(models.USD, date.AddDate(0, 0, i%100))
+				cache.Get(date.AddDate(0, 0, i%100))
 			}
 			i++
 		}
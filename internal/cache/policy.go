@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"math"
+	"time"
+)
+
+// Forever - время жизни записи, которая никогда не истекает
+const Forever time.Duration = math.MaxInt64
+
+// TTLPolicy определяет время жизни записи кэша в зависимости от дат курса
+// Позволяет хранить курсы прошлых дат бессрочно, а курс на сегодня - недолго
+type TTLPolicy interface {
+	// TTL возвращает время жизни курса, запрошенного на requestedDate
+	// actualDate - фактическая дата курса из XML (может быть раньше запрошенной)
+	// Forever - запись не истекает
+	TTL(requestedDate, actualDate time.Time) time.Duration
+}
+
+// FixedTTL - одинаковое время жизни для всех записей
+type FixedTTL time.Duration
+
+// TTL реализует интерфейс TTLPolicy
+func (t FixedTTL) TTL(_, _ time.Time) time.Duration {
+	return time.Duration(t)
+}
+
+// DatePolicy - время жизни записи по дате курса
+// Курсы ЦБ РФ на прошедшие даты не меняются и хранятся бессрочно,
+// курс на сегодня (и более поздние даты) может быть скорректирован
+type DatePolicy struct {
+	// Current - время жизни курса на сегодняшнюю или более позднюю дату
+	Current time.Duration
+
+	// NotPublished - время жизни записи на сегодняшнюю или более позднюю дату,
+	// для которой ЦБ РФ еще не установил курс и вернул курс более ранней даты
+	// (негативный кэш: короткий срок, чтобы подхватить курс сразу после публикации)
+	NotPublished time.Duration
+}
+
+// DefaultDatePolicy - политика по умолчанию: курс на сегодня - час, неопубликованный - 15 минут
+var DefaultDatePolicy = DatePolicy{
+	Current:      time.Hour,
+	NotPublished: 15 * time.Minute,
+}
+
+// TTL реализует интерфейс TTLPolicy
+// Даты сравниваются как календарные: дата курса - в своей временной зоне (как ключ кэша),
+// сегодняшняя - в локальной
+func (p DatePolicy) TTL(requestedDate, actualDate time.Time) time.Duration {
+	requested := calendarDay(requestedDate)
+	if requested < calendarDay(nowFunc()) {
+		return Forever
+	}
+	if calendarDay(actualDate) < requested {
+		return p.NotPublished
+	}
+	return p.Current
+}
+
+// calendarDay возвращает календарную дату в формате YYYY-MM-DD (сравнима как строка)
+func calendarDay(t time.Time) string {
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,111 @@
+package cache
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+func TestDatePolicy_TTL(t *testing.T) {
+	withFakeClock(t, time.Date(2025, 12, 22, 10, 0, 0, 0, time.Local)) // Понедельник
+	day := func(d int) time.Time { return time.Date(2025, 12, d, 0, 0, 0, 0, time.Local) }
+	policy := DatePolicy{Current: time.Hour, NotPublished: 15 * time.Minute}
+
+	tests := []struct {
+		name      string
+		requested time.Time
+		actual    time.Time
+		want      time.Duration
+	}{
+		{"Прошедшая дата - бессрочно", day(19), day(19), Forever},
+		{"Прошедший выходной - бессрочно", day(21), day(20), Forever},
+		{"Сегодня - курс установлен", day(22), day(22), time.Hour},
+		{"Сегодня - курс еще не установлен", day(22), day(20), 15 * time.Minute},
+		{"Завтра - курс установлен", day(23), day(23), time.Hour},
+		{"Завтра - курс еще не установлен", day(23), day(22), 15 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.TTL(tt.requested, tt.actual); got != tt.want {
+				t.Errorf("TTL() = %v, ожидалось %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFixedTTL(t *testing.T) {
+	past := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got := FixedTTL(time.Hour).TTL(past, past); got != time.Hour {
+		t.Errorf("FixedTTL.TTL() = %v, ожидалось 1h", got)
+	}
+}
+
+func TestLRUCache_DatePolicy(t *testing.T) {
+	clock := withFakeClock(t, time.Date(2025, 12, 22, 10, 0, 0, 0, time.Local))
+	past := time.Date(2025, 12, 19, 0, 0, 0, 0, time.Local)
+	today := time.Date(2025, 12, 22, 0, 0, 0, 0, time.Local)
+	tomorrow := today.AddDate(0, 0, 1)
+
+	cache := NewLRUCacheWithPolicy(10, DatePolicy{Current: time.Hour, NotPublished: 15 * time.Minute})
+	cache.Set(past, snapshot(models.USD, past, dec("80.1")))
+	cache.Set(today, snapshot(models.USD, today, dec("80.5")))
+	cache.Set(tomorrow, snapshot(models.USD, today, dec("80.5"))) // Курс на завтра еще не установлен
+
+	clock.Advance(30 * time.Minute)
+	if _, found := cache.Get(tomorrow); found {
+		t.Error("Неопубликованный курс должен истечь через NotPublished")
+	}
+	if _, found := cache.Get(today); !found {
+		t.Error("Курс на сегодня не должен истечь раньше Current")
+	}
+
+	clock.Advance(365 * 24 * time.Hour)
+	if _, found := cache.Get(today); found {
+		t.Error("Курс на сегодня должен истечь через Current")
+	}
+	if _, found := cache.Get(past); !found {
+		t.Error("Курс прошедшей даты не должен истекать")
+	}
+}
+
+func TestDiskCache_DatePolicy(t *testing.T) {
+	clock := withFakeClock(t, time.Date(2025, 12, 22, 10, 0, 0, 0, time.Local))
+	path := filepath.Join(t.TempDir(), "rates.json")
+	past := time.Date(2025, 12, 19, 0, 0, 0, 0, time.Local)
+	today := time.Date(2025, 12, 22, 0, 0, 0, 0, time.Local)
+
+	c, err := NewDiskCache(path, DefaultDatePolicy)
+	if err != nil {
+		t.Fatalf("NewDiskCache() error = %v", err)
+	}
+	c.Set(past, snapshot(models.USD, past, dec("80.1")))
+	c.Set(today, snapshot(models.USD, today, dec("80.5")))
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	// Через год после перезапуска свежим остается только курс прошедшей даты
+	clock.Advance(365 * 24 * time.Hour)
+	reopened, err := NewDiskCache(path, DefaultDatePolicy)
+	if err != nil {
+		t.Fatalf("NewDiskCache() error = %v", err)
+	}
+	if _, found := reopened.Get(today); found {
+		t.Error("Курс на сегодня должен истечь через Current")
+	}
+	if _, found := reopened.Get(past); !found {
+		t.Error("Курс прошедшей даты не должен истекать")
+	}
+}
+
+func TestNewLRUCacheWithPolicy_PanicsOnNilPolicy(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("NewLRUCacheWithPolicy должна паниковать при nil политике")
+		}
+	}()
+	NewLRUCacheWithPolicy(10, nil)
+}
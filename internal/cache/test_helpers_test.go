@@ -1,7 +1,31 @@
 package cache
 
-import "time"
+import (
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
 
 func testPastDateUTC() time.Time {
 	return time.Now().UTC().AddDate(0, 0, -30)
 }
+
+// dec создает Decimal из строкового литерала для тестовых данных
+func dec(s string) models.Decimal {
+	return models.MustParseDecimal(s)
+}
+
+// snapshot создает снимок курсов на дату с курсом одной валюты (номинал 1)
+func snapshot(currency models.Currency, date time.Time, rate models.Decimal) *models.RateData {
+	rateData := models.NewRateData(date)
+	rateData.AddRate(models.ExchangeRate{Currency: currency, Rate: rate, Nominal: 1, Date: date})
+	return rateData
+}
+
+// rateOf возвращает курс валюты из снимка (нулевой, если снимка или курса нет)
+func rateOf(rateData *models.RateData, currency models.Currency) models.Decimal {
+	if rateData == nil {
+		return models.Decimal{}
+	}
+	return rateData.Rates[currency].Rate
+}
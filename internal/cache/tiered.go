@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+// Storage - хранилище курсов, из которых собирается TieredCache
+// Совпадает с converter.CacheStorage (пакет cache не зависит от converter)
+type Storage interface {
+	Get(date time.Time) (*models.RateData, bool)
+	Set(requestedDate time.Time, rateData *models.RateData)
+	Clear()
+}
+
+// StaleStorage - хранилище, которое может вернуть истекшую запись
+// Совпадает с converter.StaleCacheStorage
+type StaleStorage interface {
+	Storage
+	GetStale(date time.Time) (*models.RateData, bool)
+}
+
+// BatchStorage - хранилище, сохраняющее несколько снимков за одну операцию
+// Совпадает с converter.BatchCacheStorage
+type BatchStorage interface {
+	Storage
+	SetMany(snapshots map[time.Time]*models.RateData)
+}
+
+// expiryGetter - уровень, который сообщает срок жизни записи (DiskCache)
+type expiryGetter interface {
+	getWithExpiry(date time.Time) (*models.RateData, time.Time, bool)
+}
+
+// expirySetter - уровень, который принимает запись с заданным сроком жизни (LRUCache)
+type expirySetter interface {
+	setWithExpiry(date time.Time, rateData *models.RateData, expiresAt time.Time)
+}
+
+// TieredCache - двухуровневый кэш: быстрый primary (обычно LRUCache в памяти)
+// поверх secondary (обычно DiskCache)
+// Get сначала проверяет primary, при промахе - secondary и поднимает найденную запись в primary
+// с оставшимся сроком жизни: курс на сегодня не продлевается при каждом перезапуске
+// Set, SetMany и Clear применяются к обоим уровням
+type TieredCache struct {
+	primary   Storage
+	secondary Storage
+}
+
+// NewTieredCache создает двухуровневый кэш
+//
+// Пример использования:
+//
+//	disk, err := cache.NewDiskCache(path, cache.DefaultDatePolicy)
+//	if err != nil {
+//	    return err
+//	}
+//	storage := cache.NewTieredCache(cache.NewLRUCacheWithPolicy(1000, cache.DefaultDatePolicy), disk)
+func NewTieredCache(primary, secondary Storage) *TieredCache {
+	if primary == nil || secondary == nil {
+		panic("cache: tiers must not be nil")
+	}
+	return &TieredCache{
+		primary:   primary,
+		secondary: secondary,
+	}
+}
+
+// Get получает снимок курсов сначала из primary, затем из secondary
+func (c *TieredCache) Get(date time.Time) (*models.RateData, bool) {
+	if rateData, found := c.primary.Get(date); found {
+		return rateData, true
+	}
+
+	// Поднимаем запись в primary, чтобы следующие Get не обращались ко второму уровню
+	getter, ok := c.secondary.(expiryGetter)
+	if !ok {
+		// Срок жизни записи второго уровня неизвестен: в primary она получила бы полный срок заново
+		return c.secondary.Get(date)
+	}
+	rateData, expiresAt, found := getter.getWithExpiry(date)
+	if !found {
+		return nil, false
+	}
+	if setter, ok := c.primary.(expirySetter); ok {
+		setter.setWithExpiry(date, rateData, expiresAt)
+	} else if expiresAt.IsZero() {
+		c.primary.Set(date, rateData)
+	}
+	return rateData, true
+}
+
+// GetStale получает снимок курсов без учета срока жизни из уровней, реализующих StaleStorage
+// Свежая запись второго уровня предпочтительнее истекшей записи первого
+// и поднимается в primary, как в Get
+func (c *TieredCache) GetStale(date time.Time) (*models.RateData, bool) {
+	if rateData, found := c.Get(date); found {
+		return rateData, true
+	}
+	for _, tier := range []Storage{c.primary, c.secondary} {
+		if staleTier, ok := tier.(StaleStorage); ok {
+			if rateData, found := staleTier.GetStale(date); found {
+				return rateData, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Set сохраняет снимок курсов в оба уровня
+func (c *TieredCache) Set(requestedDate time.Time, rateData *models.RateData) {
+	c.primary.Set(requestedDate, rateData)
+	c.secondary.Set(requestedDate, rateData)
+}
+
+// SetMany сохраняет несколько снимков курсов в оба уровня
+// Уровень, реализующий BatchStorage, получает их одним вызовом (DiskCache перезаписывает файл один раз)
+func (c *TieredCache) SetMany(snapshots map[time.Time]*models.RateData) {
+	for _, tier := range []Storage{c.primary, c.secondary} {
+		if batch, ok := tier.(BatchStorage); ok {
+			batch.SetMany(snapshots)
+			continue
+		}
+		for requestedDate, rateData := range snapshots {
+			tier.Set(requestedDate, rateData)
+		}
+	}
+}
+
+// Clear очищает оба уровня
+func (c *TieredCache) Clear() {
+	c.primary.Clear()
+	c.secondary.Clear()
+}
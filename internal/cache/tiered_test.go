@@ -0,0 +1,143 @@
+package cache
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+func TestTieredCache_PromotesFromSecondary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	date := testPastDateUTC()
+
+	// Курс сохранен на диск в прошлом запуске
+	persist(t, path, 24*time.Hour, date, snapshot(models.USD, date, dec("80.5")))
+
+	memory := NewLRUCache(10, time.Hour)
+	tiered := NewTieredCache(memory, newTestDiskCache(t, path, 24*time.Hour))
+
+	rateData, found := tiered.Get(date)
+	if !found || !rateOf(rateData, models.USD).Equal(dec("80.5")) || !rateData.Date.Equal(date) {
+		t.Fatalf("Get() = %+v, %v; ожидалось 80.5 из второго уровня", rateData, found)
+	}
+	if _, found := memory.Get(date); !found {
+		t.Error("Запись из второго уровня должна подниматься в первый")
+	}
+}
+
+func TestTieredCache_PromotionKeepsExpiry(t *testing.T) {
+	clock := withFakeClock(t, time.Date(2025, 12, 20, 12, 0, 0, 0, time.UTC))
+	path := filepath.Join(t.TempDir(), "rates.json")
+	date := testPastDateUTC()
+
+	// Запись сохранена на диск за 50 минут до перезапуска и живет час
+	persist(t, path, time.Hour, date, snapshot(models.USD, date, dec("80.5")))
+	clock.Advance(50 * time.Minute)
+
+	memory := NewLRUCache(10, time.Hour)
+	tiered := NewTieredCache(memory, newTestDiskCache(t, path, time.Hour))
+	if _, found := tiered.Get(date); !found {
+		t.Fatal("Запись второго уровня должна находиться")
+	}
+
+	// Поднятая запись истекает вместе с записью на диске, а не через час после подъема
+	clock.Advance(20 * time.Minute)
+	if _, found := memory.Get(date); found {
+		t.Error("Поднятая запись не должна продлеваться в первом уровне")
+	}
+	if _, found := tiered.Get(date); found {
+		t.Error("Истекшая запись не должна возвращаться Get")
+	}
+}
+
+func TestTieredCache_PromotesForeverEntry(t *testing.T) {
+	clock := withFakeClock(t, time.Date(2025, 12, 20, 12, 0, 0, 0, time.UTC))
+	path := filepath.Join(t.TempDir(), "rates.json")
+	date := testPastDateUTC()
+
+	persist(t, path, Forever, date, snapshot(models.USD, date, dec("80.5")))
+
+	memory := NewLRUCache(10, time.Hour)
+	tiered := NewTieredCache(memory, newTestDiskCache(t, path, Forever))
+	if _, found := tiered.Get(date); !found {
+		t.Fatal("Запись второго уровня должна находиться")
+	}
+
+	// Бессрочная запись остается бессрочной и в первом уровне
+	clock.Advance(48 * time.Hour)
+	if _, found := memory.Get(date); !found {
+		t.Error("Бессрочная запись должна подниматься без срока жизни")
+	}
+}
+
+func TestTieredCache_SetMany(t *testing.T) {
+	memory := NewLRUCache(10, time.Hour)
+	disk := newTestDiskCache(t, filepath.Join(t.TempDir(), "rates.json"), time.Hour)
+	tiered := NewTieredCache(memory, disk)
+
+	first := testPastDateUTC()
+	second := first.AddDate(0, 0, 1)
+	tiered.SetMany(map[time.Time]*models.RateData{
+		first:  snapshot(models.USD, first, dec("80.5")),
+		second: snapshot(models.USD, second, dec("81.0")),
+	})
+	if memory.Size() != 2 || disk.Size() != 2 {
+		t.Errorf("SetMany: размеры уровней %d и %d, ожидалось 2 и 2", memory.Size(), disk.Size())
+	}
+}
+
+func TestTieredCache_SetAndClearBothTiers(t *testing.T) {
+	date := testPastDateUTC()
+	memory := NewLRUCache(10, time.Hour)
+	disk := newTestDiskCache(t, filepath.Join(t.TempDir(), "rates.json"), time.Hour)
+	tiered := NewTieredCache(memory, disk)
+
+	tiered.Set(date, snapshot(models.EUR, date, dec("88.1")))
+	if memory.Size() != 1 || disk.Size() != 1 {
+		t.Errorf("Set: размеры уровней %d и %d, ожидалось 1 и 1", memory.Size(), disk.Size())
+	}
+
+	tiered.Clear()
+	if memory.Size() != 0 || disk.Size() != 0 {
+		t.Errorf("Clear: размеры уровней %d и %d, ожидалось 0 и 0", memory.Size(), disk.Size())
+	}
+	if _, found := tiered.Get(date); found {
+		t.Error("После Clear запись не должна находиться")
+	}
+}
+
+func TestTieredCache_GetStale(t *testing.T) {
+	clock := withFakeClock(t, time.Date(2025, 12, 20, 12, 0, 0, 0, time.UTC))
+	date := testPastDateUTC()
+	memory := NewLRUCache(10, time.Hour)
+	disk := newTestDiskCache(t, filepath.Join(t.TempDir(), "rates.json"), time.Hour)
+	tiered := NewTieredCache(memory, disk)
+
+	tiered.Set(date, snapshot(models.USD, date, dec("80.5")))
+	clock.Advance(2 * time.Hour)
+
+	if _, found := tiered.Get(date); found {
+		t.Fatal("Истекшая запись не должна возвращаться Get")
+	}
+	rateData, found := tiered.GetStale(date)
+	if !found || !rateOf(rateData, models.USD).Equal(dec("80.5")) {
+		t.Errorf("GetStale() = %+v, %v; ожидалась истекшая запись из первого уровня", rateData, found)
+	}
+
+	// Свежая запись второго уровня предпочтительнее истекшей
+	disk.Set(date, snapshot(models.USD, date, dec("81.0")))
+	if rateData, _ := tiered.GetStale(date); !rateOf(rateData, models.USD).Equal(dec("81.0")) {
+		t.Errorf("GetStale() = %+v; ожидалась свежая запись второго уровня 81.0", rateData)
+	}
+}
+
+func TestNewTieredCache_PanicsOnNilTier(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("NewTieredCache должна паниковать при nil уровне")
+		}
+	}()
+	NewTieredCache(NewLRUCache(10, time.Hour), nil)
+}
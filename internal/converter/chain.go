@@ -0,0 +1,106 @@
+package converter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+// Ошибки цепочки источников
+var (
+	ErrAllSourcesFailed = errors.New("все источники курсов недоступны")
+)
+
+// RateSource - источник курсов в цепочке ProviderChain
+type RateSource struct {
+	Name     string        // Имя источника для ConversionResult.Source ("cbr-xml", "file" и т.д.)
+	Provider RateProvider  // Источник курсов
+	Timeout  time.Duration // Время на ответ источника (0 - без отдельного ограничения)
+}
+
+// ProviderChain - составной RateProvider с резервными источниками
+// Источники опрашиваются по порядку до первого успешного ответа; если источник не ответил
+// за свой Timeout или вернул ошибку, запрос передается следующему
+// В RateData.Source записывается имя источника, который отдал курсы
+//
+// Пример использования:
+//
+//	chain := converter.NewProviderChain(
+//		converter.RateSource{Name: "cbr-xml", Provider: converter.FetchRatesFunc(parser.FetchRates), Timeout: 15 * time.Second},
+//		converter.RateSource{Name: "file", Provider: converter.FetchRatesFunc(parser.SnapshotFetcher(dir))},
+//	)
+//	conv := converter.NewConverter(chain, cacheStorage)
+type ProviderChain struct {
+	sources []RateSource
+}
+
+// NewProviderChain создает цепочку источников курсов в порядке приоритета
+// Паникует, если источники не заданы или у источника нет имени или provider (ошибка конфигурации)
+func NewProviderChain(sources ...RateSource) *ProviderChain {
+	if len(sources) == 0 {
+		panic("converter: NewProviderChain без источников")
+	}
+	for _, source := range sources {
+		if source.Name == "" || source.Provider == nil {
+			panic(fmt.Sprintf("converter: некорректный источник курсов %+v", source))
+		}
+	}
+	return &ProviderChain{sources: append([]RateSource(nil), sources...)}
+}
+
+// FetchRates реализует интерфейс RateProvider
+// Возвращает курсы первого ответившего источника или ErrAllSourcesFailed с ошибками всех источников
+// Отмена ctx прерывает цепочку: следующие источники не опрашиваются
+func (p *ProviderChain) FetchRates(ctx context.Context, date time.Time) (*models.RateData, error) {
+	return p.FetchRatesConditional(ctx, date, nil)
+}
+
+// FetchRatesConditional реализует интерфейс ConditionalRateProvider
+// Снимок cached передается источникам, реализующим ConditionalRateProvider; models.ErrNotModified
+// источника возвращается сразу: снимок в кэше актуален, резервные источники не нужны
+func (p *ProviderChain) FetchRatesConditional(ctx context.Context, date time.Time, cached *models.RateData) (*models.RateData, error) {
+	var errs []error
+	for _, source := range p.sources {
+		rateData, err := fetchFromSource(ctx, source, date, cached)
+		if err == nil {
+			return rateData, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		// Курсы не изменились: снимок в кэше конвертера актуален, резервные источники не нужны
+		if errors.Is(err, models.ErrNotModified) {
+			return nil, err
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", source.Name, err))
+	}
+	return nil, fmt.Errorf("%w: %w", ErrAllSourcesFailed, errors.Join(errs...))
+}
+
+// fetchFromSource получает курсы из одного источника с его таймаутом (условно, если задан cached)
+func fetchFromSource(ctx context.Context, source RateSource, date time.Time, cached *models.RateData) (*models.RateData, error) {
+	if source.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, source.Timeout)
+		defer cancel()
+	}
+
+	rateData, err := fetchConditional(ctx, source.Provider, date, cached)
+	if err != nil {
+		return nil, err
+	}
+	if rateData == nil {
+		return nil, errors.New("rate provider returned nil data")
+	}
+
+	// Вложенная цепочка уже указала источник - сохраняем его
+	if rateData.Source == "" {
+		withSource := *rateData
+		withSource.Source = source.Name
+		rateData = &withSource
+	}
+	return rateData, nil
+}
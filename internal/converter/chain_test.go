@@ -0,0 +1,259 @@
+package converter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+func TestProviderChain_FetchRates(t *testing.T) {
+	date := testPastDateUTC()
+	xmlErr := errors.New("cbr.ru unreachable")
+	soapErr := errors.New("soap fault")
+
+	tests := []struct {
+		name       string
+		sources    []*MockRateProvider
+		wantSource string
+		wantErrs   []error
+		wantCalls  []int
+	}{
+		{
+			name: "Первый источник ответил",
+			sources: []*MockRateProvider{
+				{rateData: rateSnapshot(date, models.USD, "80.0000")},
+				{rateData: rateSnapshot(date, models.USD, "81.0000")},
+			},
+			wantSource: "cbr-xml",
+			wantCalls:  []int{1, 0},
+		},
+		{
+			name: "Переход к резервному источнику",
+			sources: []*MockRateProvider{
+				{err: xmlErr},
+				{rateData: rateSnapshot(date, models.USD, "81.0000")},
+			},
+			wantSource: "file",
+			wantCalls:  []int{1, 1},
+		},
+		{
+			name: "Источник вернул nil без ошибки",
+			sources: []*MockRateProvider{
+				{},
+				{rateData: rateSnapshot(date, models.USD, "81.0000")},
+			},
+			wantSource: "file",
+			wantCalls:  []int{1, 1},
+		},
+		{
+			name: "Все источники недоступны",
+			sources: []*MockRateProvider{
+				{err: xmlErr},
+				{err: soapErr},
+			},
+			wantErrs:  []error{ErrAllSourcesFailed, xmlErr, soapErr},
+			wantCalls: []int{1, 1},
+		},
+	}
+
+	names := []string{"cbr-xml", "file"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sources := make([]RateSource, len(tt.sources))
+			for i, provider := range tt.sources {
+				sources[i] = RateSource{Name: names[i], Provider: provider}
+			}
+
+			rateData, err := NewProviderChain(sources...).FetchRates(context.Background(), date)
+			for _, want := range tt.wantErrs {
+				if !errors.Is(err, want) {
+					t.Errorf("FetchRates() error = %v, ожидалась ошибка %v", err, want)
+				}
+			}
+			if tt.wantErrs == nil {
+				if err != nil {
+					t.Fatalf("FetchRates() error = %v", err)
+				}
+				if rateData.Source != tt.wantSource {
+					t.Errorf("Source = %q, ожидалось %q", rateData.Source, tt.wantSource)
+				}
+			}
+			for i, provider := range tt.sources {
+				if provider.callCount != tt.wantCalls[i] {
+					t.Errorf("Источник %s вызван %d раз, ожидалось %d", names[i], provider.callCount, tt.wantCalls[i])
+				}
+			}
+		})
+	}
+}
+
+func TestProviderChain_SourceNotOverwritten(t *testing.T) {
+	date := testPastDateUTC()
+	snapshot := rateSnapshot(date, models.USD, "80.0000")
+	snapshot.Source = "mirror"
+
+	inner := NewProviderChain(RateSource{Name: "mirror", Provider: &MockRateProvider{rateData: snapshot}})
+	rateData, err := NewProviderChain(RateSource{Name: "fallback", Provider: inner}).FetchRates(context.Background(), date)
+	if err != nil {
+		t.Fatalf("FetchRates() error = %v", err)
+	}
+	if rateData.Source != "mirror" {
+		t.Errorf("Source = %q, ожидался источник вложенной цепочки mirror", rateData.Source)
+	}
+
+	// Снимок источника не изменяется
+	plain := rateSnapshot(date, models.USD, "80.0000")
+	if _, err := NewProviderChain(RateSource{Name: "cbr-xml", Provider: &MockRateProvider{rateData: plain}}).FetchRates(context.Background(), date); err != nil {
+		t.Fatalf("FetchRates() error = %v", err)
+	}
+	if plain.Source != "" {
+		t.Errorf("Снимок источника изменен: Source = %q", plain.Source)
+	}
+}
+
+func TestProviderChain_Timeout(t *testing.T) {
+	date := testPastDateUTC()
+	// Источник не отвечает, пока запрос не отменят
+	slow := FetchRatesFunc(func(ctx context.Context, _ time.Time) (*models.RateData, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	fallback := &MockRateProvider{rateData: rateSnapshot(date, models.USD, "81.0000")}
+
+	chain := NewProviderChain(
+		RateSource{Name: "cbr-xml", Provider: slow, Timeout: 10 * time.Millisecond},
+		RateSource{Name: "file", Provider: fallback},
+	)
+
+	rateData, err := chain.FetchRates(context.Background(), date)
+	if err != nil {
+		t.Fatalf("FetchRates() error = %v", err)
+	}
+	if rateData.Source != "file" {
+		t.Errorf("Source = %q, ожидался резервный источник file после таймаута", rateData.Source)
+	}
+}
+
+func TestProviderChain_ContextCanceled(t *testing.T) {
+	date := testPastDateUTC()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	fallback := &MockRateProvider{rateData: rateSnapshot(date, models.USD, "81.0000")}
+	chain := NewProviderChain(
+		RateSource{Name: "cbr-xml", Provider: FetchRatesFunc(func(ctx context.Context, _ time.Time) (*models.RateData, error) {
+			return nil, ctx.Err()
+		})},
+		RateSource{Name: "file", Provider: fallback},
+	)
+
+	if _, err := chain.FetchRates(ctx, date); !errors.Is(err, context.Canceled) {
+		t.Errorf("FetchRates() error = %v, ожидалась context.Canceled", err)
+	}
+	if fallback.callCount != 0 {
+		t.Errorf("После отмены запроса резервный источник вызван %d раз", fallback.callCount)
+	}
+}
+
+func TestNewProviderChain_InvalidSources(t *testing.T) {
+	tests := []struct {
+		name    string
+		sources []RateSource
+	}{
+		{name: "Без источников", sources: nil},
+		{name: "Без имени", sources: []RateSource{{Provider: &MockRateProvider{}}}},
+		{name: "Без provider", sources: []RateSource{{Name: "cbr-xml"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("NewProviderChain() должна паниковать")
+				}
+			}()
+			NewProviderChain(tt.sources...)
+		})
+	}
+}
+
+func TestConverter_Convert_Source(t *testing.T) {
+	date := testPastDateUTC()
+	chain := NewProviderChain(
+		RateSource{Name: "cbr-xml", Provider: &MockRateProvider{err: errors.New("cbr.ru unreachable")}},
+		RateSource{Name: "file", Provider: &MockRateProvider{rateData: rateSnapshot(date, models.USD, "80.0000")}},
+	)
+	conv := NewConverter(chain, NewMockCache())
+
+	// Источник сохраняется вместе со снимком: ответ из кэша указывает тот же источник
+	for i := range 2 {
+		result, err := conv.Convert(context.Background(), dec("100"), models.USD, date)
+		if err != nil {
+			t.Fatalf("Convert() #%d error = %v", i+1, err)
+		}
+		if result.Source != "file" {
+			t.Errorf("Convert() #%d Source = %q, ожидалось file", i+1, result.Source)
+		}
+	}
+
+	cross, err := conv.ConvertCross(context.Background(), dec("100"), models.USD, models.RUB, date)
+	if err != nil {
+		t.Fatalf("ConvertCross() error = %v", err)
+	}
+	if cross.Source != "file" {
+		t.Errorf("ConvertCross() Source = %q, ожидалось file", cross.Source)
+	}
+}
+
+// mockConditionalProvider - источник с условными запросами: для снимка cached возвращает ErrNotModified
+type mockConditionalProvider struct {
+	MockRateProvider
+	cached []*models.RateData
+}
+
+func (m *mockConditionalProvider) FetchRatesConditional(ctx context.Context, date time.Time, cached *models.RateData) (*models.RateData, error) {
+	m.cached = append(m.cached, cached)
+	return nil, models.ErrNotModified
+}
+
+func TestConverter_ConditionalThroughChainAndMerge(t *testing.T) {
+	date := testPastDateUTC()
+	snapshot := rateSnapshot(date, models.USD, "80.0000")
+	snapshot.ETag = `"v1"`
+
+	conditional := &mockConditionalProvider{}
+	fallback := &MockRateProvider{rateData: rateSnapshot(date, models.USD, "81.0000")}
+	metals := &MockRateProvider{err: errors.New("металлы не должны запрашиваться")}
+	provider := NewMergeProvider(
+		NewProviderChain(RateSource{Name: "cbr-xml", Provider: conditional}, RateSource{Name: "file", Provider: fallback}),
+		metals,
+	)
+
+	cache := newMockStaleCache()
+	cache.expire(date, snapshot)
+	conv := NewConverter(provider, cache)
+
+	result, err := conv.Convert(context.Background(), dec("100"), models.USD, date)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if !result.TargetAmount.Equal(dec("8000.00")) {
+		t.Errorf("TargetAmount = %v, ожидалось 8000.00 по снимку из кэша", result.TargetAmount)
+	}
+
+	// Снимок из кэша дошел до источника через MergeProvider и ProviderChain,
+	// ErrNotModified не передал запрос резервному источнику и продлил снимок
+	if len(conditional.cached) != 1 || conditional.cached[0] != snapshot {
+		t.Errorf("Условный запрос получил снимки %v, ожидался снимок из кэша", conditional.cached)
+	}
+	if conditional.callCount != 0 || fallback.callCount != 0 || metals.callCount != 0 {
+		t.Errorf("Безусловных запросов: источник %d, резерв %d, металлы %d; ожидалось 0",
+			conditional.callCount, fallback.callCount, metals.callCount)
+	}
+	if rateData, found := cache.Get(date); !found || rateData != snapshot {
+		t.Error("Снимок после ErrNotModified должен быть продлен в кэше")
+	}
+}
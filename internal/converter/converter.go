@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/bivlked/currate-go/internal/models"
@@ -27,31 +28,100 @@ func (f FetchRatesFunc) FetchRates(ctx context.Context, date time.Time) (*models
 	return f(ctx, date)
 }
 
+// ConditionalRateProvider - источник курсов с поддержкой условных запросов
+// По истечении записи кэша Converter передает источнику снимок из кэша: источник отправляет
+// его валидаторы (parser.CBRClient - If-None-Match / If-Modified-Since) и не загружает курсы повторно,
+// если они не изменились. ProviderChain и MergeProvider передают снимок своим источникам
+type ConditionalRateProvider interface {
+	RateProvider
+
+	// FetchRatesConditional получает курсы на дату, если они изменились с загрузки снимка cached
+	// Возвращает models.ErrNotModified, если снимок cached актуален
+	FetchRatesConditional(ctx context.Context, date time.Time, cached *models.RateData) (*models.RateData, error)
+}
+
+// fetchConditional запрашивает курсы условно, если provider это поддерживает и снимок задан
+func fetchConditional(ctx context.Context, provider RateProvider, date time.Time, cached *models.RateData) (*models.RateData, error) {
+	if conditional, ok := provider.(ConditionalRateProvider); ok && cached != nil {
+		return conditional.FetchRatesConditional(ctx, date, cached)
+	}
+	return provider.FetchRates(ctx, date)
+}
+
 // CacheStorage - интерфейс для кэширования курсов
+// Хранит снимки всех курсов ЦБ РФ на дату: один запрос к ЦБ РФ обслуживает любую валюту на эту дату
 // Позволяет использовать моки для тестирования
 type CacheStorage interface {
-	// Get получает курс из кэша
-	// Возвращает (rate, actualDate, found), где actualDate - фактическая дата курса из XML
-	Get(currency models.Currency, date time.Time) (float64, time.Time, bool)
+	// Get получает снимок курсов из кэша
+	// Возвращает (rateData, found), где rateData.Date - фактическая дата курсов из XML
+	// Снимок общий для всех вызывающих и не должен изменяться
+	Get(date time.Time) (*models.RateData, bool)
 
-	// Set сохраняет курс в кэш
+	// Set сохраняет снимок курсов в кэш
 	// requestedDate - запрошенная дата (используется как ключ кэша)
-	// actualDate - фактическая дата курса из XML (сохраняется в Entry)
-	Set(currency models.Currency, requestedDate time.Time, rate float64, actualDate time.Time)
+	// rateData.Date - фактическая дата курсов из XML (может отличаться от запрошенной)
+	Set(requestedDate time.Time, rateData *models.RateData)
 
 	// Clear очищает весь кэш
 	Clear()
 }
 
+// BatchCacheStorage - кэш, который сохраняет несколько снимков за одну операцию
+// Динамика курса сохраняет снимок на каждый день периода;
+// cache.DiskCache перезаписывает файл один раз на пакет, а не на каждый день
+type BatchCacheStorage interface {
+	CacheStorage
+
+	// SetMany сохраняет снимки курсов; ключ - запрошенная дата (как в Set)
+	SetMany(snapshots map[time.Time]*models.RateData)
+}
+
+// setMany сохраняет снимки одной операцией, если кэш это поддерживает, иначе по одному
+func setMany(cache CacheStorage, snapshots map[time.Time]*models.RateData) {
+	if len(snapshots) == 0 {
+		return
+	}
+	if batch, ok := cache.(BatchCacheStorage); ok {
+		batch.SetMany(snapshots)
+		return
+	}
+	for requestedDate, rateData := range snapshots {
+		cache.Set(requestedDate, rateData)
+	}
+}
+
 // Ошибки конвертера
 var (
 	ErrNilRateProvider = errors.New("источник курсов не задан")
+	ErrRateNotFound    = errors.New("курс валюты не опубликован на указанную дату")
+)
+
+// Точность расчетов
+const (
+	// AmountPlaces - количество знаков после запятой в результате конвертации (копейки/центы)
+	AmountPlaces = 2
+
+	// CrossRatePlaces - количество знаков после запятой в кросс-курсе
+	CrossRatePlaces = 6
+
+	// unitRateExtraPlaces - дополнительные знаки при пересчете курса на единицу валюты
+	// для номиналов, не являющихся степенью 10 (для 10, 100, 1000 деление точное)
+	unitRateExtraPlaces = 10
 )
 
+// DefaultRounding - политика округления по умолчанию: до копеек, половина - от нуля
+var DefaultRounding = models.Rounding{Mode: models.RoundHalfUp, Places: AmountPlaces}
+
 // Converter - конвертер валют с кэшированием
 type Converter struct {
-	provider RateProvider
-	cache    CacheStorage
+	provider       RateProvider
+	seriesProvider RateSeriesProvider // Источник динамики курсов (может быть nil)
+	cache          CacheStorage
+	rounding       models.Rounding  // Политика округления TargetAmount и отформатированной строки
+	flight         *flightGroup     // Объединение одновременных загрузок на одну дату (общее для копий)
+	currencies     *models.Registry // Справочник валют источника (nil - справочник ЦБ РФ)
+
+	staleWhileRevalidate bool // Отдавать истекшие записи кэша с обновлением в фоне
 }
 
 // NewConverter создает новый конвертер валют
@@ -68,16 +138,72 @@ func NewConverter(provider RateProvider, cache CacheStorage) *Converter {
 		cache = noopCache{}
 	}
 
+	// Источник курсов может сам уметь отдавать динамику за период
+	seriesProvider, _ := provider.(RateSeriesProvider)
+
 	return &Converter{
-		provider: provider,
-		cache:    cache,
+		provider:       provider,
+		seriesProvider: seriesProvider,
+		cache:          cache,
+		rounding:       DefaultRounding,
+		flight:         &flightGroup{},
 	}
 }
 
+// WithRounding возвращает конвертер с другой политикой округления
+// Копия разделяет provider, кэш и текущие загрузки с исходным конвертером, поэтому её дёшево
+// создавать на каждый запрос. Политика проверяется при конвертации
+//
+// Пример использования:
+//
+//	wholeRubles := converter.WithRounding(models.Rounding{Mode: models.RoundDown, Places: 0})
+//	result, err := wholeRubles.Convert(ctx, amount, models.USD, date)
+//	// Вывод: "80 722 руб. ($1 000,00 по курсу 80,7220)"
+func (c *Converter) WithRounding(rounding models.Rounding) *Converter {
+	clone := *c
+	clone.rounding = rounding
+	return &clone
+}
+
+// Rounding возвращает текущую политику округления
+func (c *Converter) Rounding() models.Rounding {
+	return c.rounding
+}
+
+// WithCurrencies возвращает конвертер, который проверяет валюты по справочнику registry
+// вместо справочника ЦБ РФ: источник с другим набором валют (parser.ECBCurrencies для ЕЦБ)
+// отклоняет неизвестную ему валюту с models.ErrUnsupportedCurrency до запроса курсов
+//
+// Пример использования:
+//
+//	ecb := converter.NewConverter(parser.NewECBProvider(models.EUR), cacheStorage).
+//	    WithCurrencies(parser.ECBCurrencies())
+func (c *Converter) WithCurrencies(registry *models.Registry) *Converter {
+	clone := *c
+	clone.currencies = registry
+	return &clone
+}
+
+// ParseCurrency парсит код валюты и проверяет его по справочнику валют конвертера
+func (c *Converter) ParseCurrency(s string) (models.Currency, error) {
+	if c.currencies == nil {
+		return models.ParseCurrency(s)
+	}
+	return c.currencies.Parse(s)
+}
+
+// validateCurrency проверяет валюту по справочнику валют конвертера
+func (c *Converter) validateCurrency(currency models.Currency) error {
+	if c.currencies == nil {
+		return currency.Validate()
+	}
+	return c.currencies.Validate(currency)
+}
+
 // Convert конвертирует сумму в указанной валюте в рубли на заданную дату
 // ctx - контекст для отмены сетевых запросов
 // amount - сумма для конвертации
-// currency - валюта (USD, EUR)
+// currency - валюта из справочника ЦБ РФ (USD, EUR, CNY, KZT и т.д.)
 // date - дата курса
 //
 // # Возвращает ConversionResult с отформатированным результатом или ошибку
@@ -97,15 +223,52 @@ func NewConverter(provider RateProvider, cache CacheStorage) *Converter {
 //	}
 //	fmt.Println(result.FormattedStr)
 //	// Вывод: "80 722,00 руб. ($1 000,00 по курсу 80,7220)"
-func (c *Converter) Convert(ctx context.Context, amount float64, currency models.Currency, date time.Time) (*models.ConversionResult, error) {
+func (c *Converter) Convert(ctx context.Context, amount models.Decimal, currency models.Currency, date time.Time) (*models.ConversionResult, error) {
+	return c.convert(ctx, amount, currency, date, models.ToRUB)
+}
+
+// ConvertFromRUB конвертирует сумму в рублях в указанную валюту на заданную дату
+// ctx - контекст для отмены сетевых запросов
+// amount - сумма в рублях
+// currency - целевая валюта из справочника ЦБ РФ
+// date - дата курса
+//
+// Пример использования:
+//
+//	result, err := converter.ConvertFromRUB(ctx, 80722, models.USD, date)
+//	if err != nil {
+//	    return err
+//	}
+//	fmt.Println(result.FormattedStr)
+//	// Вывод: "$1 000,00 (80 722,00 руб. по курсу 80,7220)"
+func (c *Converter) ConvertFromRUB(ctx context.Context, amount models.Decimal, currency models.Currency, date time.Time) (*models.ConversionResult, error) {
+	return c.convert(ctx, amount, currency, date, models.FromRUB)
+}
+
+// ConvertDirection конвертирует сумму в заданном направлении
+// Удобно, когда направление приходит извне (например, из запроса GUI)
+func (c *Converter) ConvertDirection(ctx context.Context, amount models.Decimal, currency models.Currency, direction models.Direction, date time.Time) (*models.ConversionResult, error) {
+	return c.convert(ctx, amount, currency, date, direction)
+}
+
+// convert - общая реализация Convert и ConvertFromRUB
+func (c *Converter) convert(ctx context.Context, amount models.Decimal, currency models.Currency, date time.Time, direction models.Direction) (*models.ConversionResult, error) {
 	normalizedDate := normalizeDate(date)
 
+	if direction != models.ToRUB && direction != models.FromRUB {
+		return nil, fmt.Errorf("%w: %s", models.ErrInvalidDirection, direction)
+	}
+
 	// Валидация входных данных
 	if err := ValidateAmount(amount); err != nil {
 		return nil, err
 	}
 
-	if err := currency.Validate(); err != nil {
+	if err := c.rounding.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := c.validateCurrency(currency); err != nil {
 		return nil, err
 	}
 
@@ -116,115 +279,365 @@ func (c *Converter) Convert(ctx context.Context, amount float64, currency models
 	// Получаем курс через внутренний метод (использует кэш и provider)
 	// Для RUB он возвращает rate=1 и actualDate=normalizedDate, поэтому
 	// отдельная ветка не нужна - общий путь даёт идентичный результат
-	rate, actualDate, err := c.getRateInternal(ctx, currency, normalizedDate)
+	rate, q, err := c.getRateInternal(ctx, currency, normalizedDate)
 	if err != nil {
 		return nil, err
 	}
 
-	// Конвертация
-	resultRUB := amount * rate
+	if direction == models.FromRUB {
+		// Рубли → валюта: делим на курс с округлением по политике конвертера
+		resultForeign := amount.DivRound(rate, c.rounding.Places, c.rounding.Mode)
+
+		return &models.ConversionResult{
+			SourceCurrency: models.RUB,
+			TargetCurrency: currency,
+			Direction:      models.FromRUB,
+			SourceAmount:   amount,
+			TargetAmount:   resultForeign,
+			Rate:           rate,
+			SourceRate:     models.NewDecimalFromInt(1),
+			TargetRate:     rate,
+			Date:           q.date,
+			FormattedStr:   formatReverseResult(amount, rate, currency, resultForeign, c.rounding.Places),
+			AmountInWords:  AmountInWordsRounded(resultForeign, currency, c.rounding),
+			Stale:          q.stale,
+			Source:         q.source,
+		}, nil
+	}
+
+	// Конвертация: точное произведение, затем явное округление по политике конвертера
+	resultRUB := c.rounding.Apply(amount.Mul(rate))
 
-	// Форматирование
-	formatted := FormatResult(amount, rate, currency, resultRUB)
+	// Форматирование (результат выводится с тем же количеством знаков, что и округлён)
+	formatted := formatResult(amount, rate, currency, resultRUB, c.rounding.Places)
 
 	return &models.ConversionResult{
 		SourceCurrency: currency,
 		TargetCurrency: models.RUB,
+		Direction:      models.ToRUB,
 		SourceAmount:   amount,
 		TargetAmount:   resultRUB,
 		Rate:           rate,
-		Date:           actualDate, // Используем фактическую дату из XML
+		SourceRate:     rate,
+		TargetRate:     models.NewDecimalFromInt(1),
+		Date:           q.date, // Используем фактическую дату из XML
 		FormattedStr:   formatted,
+		AmountInWords:  AmountInWordsRounded(resultRUB, models.RUB, c.rounding),
+		Stale:          q.stale,
+		Source:         q.source,
 	}, nil
 }
 
-// getRateInternal получает курс валюты на указанную дату без форматирования
-// Возвращает (rate, actualDate, error), где actualDate - фактическая дата из XML
+// ConvertCross конвертирует сумму из одной валюты в другую по кросс-курсу ЦБ РФ
+// Кросс-курс выводится из двух рублёвых курсов, полученных из одного ответа ЦБ РФ
+// ctx - контекст для отмены сетевых запросов
+// amount - сумма в исходной валюте
+// from - исходная валюта
+// to - целевая валюта
+// date - дата курса
+//
+// Пример использования:
+//
+//	result, err := converter.ConvertCross(ctx, 1000, models.USD, models.EUR, date)
+//	if err != nil {
+//	    return err
+//	}
+//	fmt.Println(result.FormattedStr)
+//	// Вывод: "€920,12 ($1 000,00 по кросс-курсу 0,9201; USD 80,7220 руб., EUR 87,7300 руб.)"
+func (c *Converter) ConvertCross(ctx context.Context, amount models.Decimal, from, to models.Currency, date time.Time) (*models.ConversionResult, error) {
+	normalizedDate := normalizeDate(date)
+
+	// Валидация входных данных
+	if err := ValidateAmount(amount); err != nil {
+		return nil, err
+	}
+
+	if err := c.rounding.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := c.validateCurrency(from); err != nil {
+		return nil, err
+	}
+
+	if err := c.validateCurrency(to); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateDate(normalizedDate); err != nil {
+		return nil, err
+	}
+
+	// Оба курса берутся из одного RateData: кэш или один запрос к provider
+	q, err := c.getRatesInternal(ctx, normalizedDate, from, to)
+	if err != nil {
+		return nil, err
+	}
+	fromRate, toRate := q.rates[0], q.rates[1]
+
+	// Умножаем до деления и округляем один раз, чтобы не накапливать погрешность кросс-курса
+	result := amount.Mul(fromRate).DivRound(toRate, c.rounding.Places, c.rounding.Mode)
+	crossRate := fromRate.Div(toRate, CrossRatePlaces)
+
+	return &models.ConversionResult{
+		SourceCurrency: from,
+		TargetCurrency: to,
+		Direction:      models.Cross,
+		SourceAmount:   amount,
+		TargetAmount:   result,
+		Rate:           crossRate,
+		SourceRate:     fromRate,
+		TargetRate:     toRate,
+		Date:           q.date,
+		FormattedStr:   formatCrossResult(amount, from, to, result, crossRate, fromRate, toRate, c.rounding.Places, q.base),
+		AmountInWords:  AmountInWordsRounded(result, to, c.rounding),
+		Stale:          q.stale,
+		Source:         q.source,
+	}, nil
+}
+
+// quote - курсы валют из одного снимка с его метаданными
+type quote struct {
+	rates  []models.Decimal // Единиц базовой валюты за единицу валюты, в порядке запрошенных валют
+	base   models.Currency  // Базовая валюта снимка (у ЦБ РФ - рубль)
+	date   time.Time        // Фактическая дата курсов из XML
+	stale  bool             // Курсы из истекшей записи кэша
+	source string           // Источник курсов (RateData.Source)
+}
+
+// getRateInternal получает курс валюты в рублях на указанную дату без форматирования
+// Возвращает курс и снимок, из которого он взят (фактическая дата из XML, признак истекшей записи, источник)
+// Если курсы снимка выражены не в рублях (например, ЕЦБ), рублевый курс выводится через курс рубля
+// в снимке, а без него возвращается ErrRateNotFound
 // Это внутренний метод, который используется как в Convert, так и в GetRate
 // для избежания дублирования кода
-func (c *Converter) getRateInternal(ctx context.Context, currency models.Currency, normalizedDate time.Time) (float64, time.Time, error) {
-	// Для RUB всегда возвращаем 1.0 (provider не нужен)
-	if currency == models.RUB {
-		return 1.0, normalizedDate, nil
+func (c *Converter) getRateInternal(ctx context.Context, currency models.Currency, normalizedDate time.Time) (models.Decimal, quote, error) {
+	q, err := c.getRatesInternal(ctx, normalizedDate, currency, models.RUB)
+	if err != nil {
+		return models.Decimal{}, quote{}, err
+	}
+	rate := q.rates[0]
+	if q.base != models.RUB {
+		rate = rate.Div(q.rates[1], rate.Scale()+unitRateExtraPlaces)
 	}
+	return rate, q, nil
+}
 
-	if c.provider == nil {
-		return 0, time.Time{}, ErrNilRateProvider
+// getRatesInternal получает курсы нескольких валют на одну дату
+// Все курсы берутся из одного снимка: из кэша или из одного ответа provider,
+// сколько бы валют ни было запрошено, выполняется не более одного запроса к ЦБ РФ
+// Возвращает курсы (рублей за единицу валюты) в порядке currencies, фактическую дату,
+// признак курсов из истекшей записи кэша (режим stale-while-revalidate) и источник
+func (c *Converter) getRatesInternal(ctx context.Context, normalizedDate time.Time, currencies ...models.Currency) (quote, error) {
+	// Для RUB курс всегда 1: если запрошены только рубли, provider не нужен
+	rubOnly := &models.RateData{Date: normalizedDate}
+	if hasRates(rubOnly, currencies) {
+		rates, err := ratesFromData(rubOnly, currencies)
+		return quote{rates: rates, base: models.RUB, date: normalizedDate}, err
 	}
 
-	// Получение курса (сначала проверяем кэш по запрошенной дате)
-	// Ключ кэша - запрошенная дата, но в Entry хранится фактическая дата
-	rate, actualDate, found := c.cache.Get(currency, normalizedDate)
-	if !found {
-		// Курса нет в кэше - получаем через provider
-		rateData, err := c.provider.FetchRates(ctx, normalizedDate)
-		if err != nil {
-			return 0, time.Time{}, fmt.Errorf("failed to fetch rates: %w", err)
-		}
-		if rateData == nil {
-			return 0, time.Time{}, errors.New("rate provider returned nil data")
+	// Получение снимка (сначала проверяем кэш по запрошенной дате)
+	// Снимок, собранный из динамики курса, подходит, только если в нем есть все запрошенные валюты;
+	// в полном снимке отсутствие валюты означает, что ЦБ РФ не публиковал её курс
+	stale := false
+	rateData, found := c.cache.Get(normalizedDate)
+	if !found || (rateData.Partial && !hasRates(rateData, currencies)) {
+		if staleData, ok := c.getStale(normalizedDate, currencies); ok {
+			// Запись истекла - отдаем её сразу, а свежие курсы загружаем в фоне
+			rateData, stale = staleData, true
+			c.revalidate(ctx, normalizedDate)
+		} else {
+			if c.provider == nil {
+				return quote{}, ErrNilRateProvider
+			}
+
+			// Курсов нет в кэше - получаем через provider
+			var err error
+			rateData, err = c.fetchRates(ctx, normalizedDate)
+			if err != nil {
+				return quote{}, err
+			}
 		}
+	}
+
+	rates, err := ratesFromData(rateData, currencies)
+	if err != nil {
+		return quote{}, err
+	}
 
-		// Используем фактическую дату из XML
-		actualDate = normalizeDate(rateData.Date)
+	// Используем фактическую дату из XML
+	return quote{
+		rates:  rates,
+		base:   rateData.BaseCurrency(),
+		date:   normalizeDate(rateData.Date),
+		stale:  stale,
+		source: rateData.Source,
+	}, nil
+}
 
-		// Извлекаем курс для нужной валюты
-		exchangeRate, exists := rateData.Rates[currency]
-		if !exists {
-			return 0, time.Time{}, fmt.Errorf("currency %s not found in rates", currency)
+// hasRates проверяет, что в снимке есть курсы всех валют (базовая валюта снимка не требует курса)
+func hasRates(rateData *models.RateData, currencies []models.Currency) bool {
+	for _, currency := range currencies {
+		if _, exists := rateData.Rates[currency]; !exists && currency != rateData.BaseCurrency() {
+			return false
 		}
+	}
+	return true
+}
 
-		rate = exchangeRate.Rate
-		if exchangeRate.Nominal > 1 {
-			rate = rate / float64(exchangeRate.Nominal)
+// ratesFromData извлекает из снимка курсы (единиц базовой валюты за единицу валюты) в порядке currencies
+func ratesFromData(rateData *models.RateData, currencies []models.Currency) ([]models.Decimal, error) {
+	rates := make([]models.Decimal, len(currencies))
+	for i, currency := range currencies {
+		if currency == rateData.BaseCurrency() {
+			rates[i] = models.NewDecimalFromInt(1)
+			continue
 		}
 
-		// Сохраняем в кэш дважды для максимальной эффективности:
-		// 1) По запрошенной дате - чтобы последующие запросы на ту же дату попадали в кэш
-		c.cache.Set(currency, normalizedDate, rate, actualDate)
-
-		// 2) По фактической дате - чтобы избежать повторных сетевых запросов
-		//    Например: запрос на воскресенье вернет пятницу, затем запрос на пятницу
-		//    найдет данные в кэше без нового обращения к API ЦБ РФ
-		if !actualDate.Equal(normalizedDate) {
-			c.cache.Set(currency, actualDate, rate, actualDate)
+		exchangeRate, exists := rateData.Rates[currency]
+		if !exists {
+			return nil, fmt.Errorf("%w: %s", ErrRateNotFound, currency)
 		}
-
-		return rate, actualDate, nil
+		rates[i] = UnitRate(exchangeRate)
 	}
-
-	// Курс найден в кэше - возвращаем с фактической датой из кэша
-	return rate, actualDate, nil
+	return rates, nil
 }
 
 // GetRate получает курс валюты на указанную дату без форматирования
 // Используется для live preview в GUI, где не нужна полная конвертация
 // Возвращает только числовой курс без создания ConversionResult
-func (c *Converter) GetRate(ctx context.Context, currency models.Currency, date time.Time) (float64, error) {
+func (c *Converter) GetRate(ctx context.Context, currency models.Currency, date time.Time) (models.Decimal, error) {
 	normalizedDate := normalizeDate(date)
 
 	// Валидация входных данных
-	if err := currency.Validate(); err != nil {
-		return 0, err
+	if err := c.validateCurrency(currency); err != nil {
+		return models.Decimal{}, err
 	}
 
 	if err := ValidateDate(normalizedDate); err != nil {
-		return 0, err
+		return models.Decimal{}, err
 	}
 
 	// Используем внутренний метод для получения курса
-	// Для live preview фактическая дата не нужна, поэтому игнорируем её
+	// Для live preview фактическая дата, признак истекшей записи и источник не нужны, поэтому игнорируем их
 	rate, _, err := c.getRateInternal(ctx, currency, normalizedDate)
 	return rate, err
 }
 
-type noopCache struct{}
+// RateQuote - курс валюты в базовой валюте источника со сведениями о снимке, из которого он взят
+type RateQuote struct {
+	Currency models.Currency
+	Rate     models.Decimal  // Единиц базовой валюты за единицу валюты
+	Base     models.Currency // Базовая валюта снимка (RUB у ЦБ РФ, EUR у ЕЦБ)
+	Date     time.Time       // Фактическая дата курса из XML (в выходные - последнего рабочего дня)
+	Source   string          // Источник курсов (RateData.Source)
+}
+
+// GetRateInBase получает курс валюты в базовой валюте источника (RateData.Base) без пересчета в рубли
+// Для ЦБ РФ совпадает с GetRate; для ЕЦБ возвращает курс в евро - рублевого курса ЕЦБ не публикует
+// Возвращает курс за единицу валюты с базовой валютой и фактической датой снимка
+func (c *Converter) GetRateInBase(ctx context.Context, currency models.Currency, date time.Time) (RateQuote, error) {
+	normalizedDate := normalizeDate(date)
+
+	if err := c.validateCurrency(currency); err != nil {
+		return RateQuote{}, err
+	}
+
+	if err := ValidateDate(normalizedDate); err != nil {
+		return RateQuote{}, err
+	}
+
+	q, err := c.getRatesInternal(ctx, normalizedDate, currency)
+	if err != nil {
+		return RateQuote{}, err
+	}
+	return RateQuote{Currency: currency, Rate: q.rates[0], Base: q.base, Date: q.date, Source: q.source}, nil
+}
+
+// GetRates получает все курсы ЦБ РФ на указанную дату
+// Курсы возвращаются как в XML ЦБ РФ (за Nominal единиц) с фактической датой
+// Снимок берется из кэша или сохраняется в него: последующие Convert и GetRate
+// на эту дату обслуживаются без обращения к ЦБ РФ
+// Возвращаемый снимок общий с кэшем и не должен изменяться
+func (c *Converter) GetRates(ctx context.Context, date time.Time) (*models.RateData, error) {
+	normalizedDate := normalizeDate(date)
+
+	if err := ValidateDate(normalizedDate); err != nil {
+		return nil, err
+	}
+
+	// Снимок из динамики курса содержит не все валюты - нужен полный из XML на дату
+	if rateData, found := c.cache.Get(normalizedDate); found && !rateData.Partial {
+		return rateData, nil
+	}
+
+	if c.provider == nil {
+		return nil, ErrNilRateProvider
+	}
+
+	return c.fetchRates(ctx, normalizedDate)
+}
+
+// fetchRates получает курсы через provider и сохраняет снимок в кэш
+// Одновременные запросы на одну дату объединяются в одну загрузку:
+// live preview и конвертация в GUI или несколько HTTP клиентов не скачивают один XML повторно
+func (c *Converter) fetchRates(ctx context.Context, normalizedDate time.Time) (*models.RateData, error) {
+	return c.flight.do(ctx, normalizedDate.Format("2006-01-02"), func(ctx context.Context) (*models.RateData, error) {
+		// Истекший снимок с валидаторами ETag/Last-Modified делает запрос условным:
+		// если курсы не изменились, источник возвращает ErrNotModified и снимок продлевается в кэше
+		cached := c.conditionalSnapshot(normalizedDate)
+		rateData, err := fetchConditional(ctx, c.provider, normalizedDate, cached)
+		if cached != nil && errors.Is(err, models.ErrNotModified) {
+			rateData, err = cached, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch rates: %w", err)
+		}
+		if rateData == nil {
+			return nil, errors.New("rate provider returned nil data")
+		}
+
+		// Сохраняем в кэш дважды для максимальной эффективности:
+		// 1) По запрошенной дате - чтобы последующие запросы на ту же дату попадали в кэш
+		c.cache.Set(normalizedDate, rateData)
+
+		// 2) По фактической дате - чтобы избежать повторных сетевых запросов
+		//    Например: запрос на воскресенье вернет пятницу, затем запрос на пятницу
+		//    найдет данные в кэше без нового обращения к API ЦБ РФ
+		if actualDate := normalizeDate(rateData.Date); !actualDate.Equal(normalizedDate) {
+			c.cache.Set(actualDate, rateData)
+		}
+		return rateData, nil
+	})
+}
 
-func (noopCache) Get(currency models.Currency, date time.Time) (float64, time.Time, bool) {
-	return 0, time.Time{}, false
+// UnitRate пересчитывает курс ЦБ РФ (за Nominal единиц) в курс за одну единицу валюты
+// Для номиналов 10, 100, 1000 и т.д. деление точное: масштаб увеличивается на число нулей
+func UnitRate(rate models.ExchangeRate) models.Decimal {
+	if rate.Nominal <= 1 {
+		return rate.Rate
+	}
+	places := rate.Rate.Scale() + int32(len(strconv.Itoa(rate.Nominal))-1)
+	if !isPowerOf10(rate.Nominal) {
+		places = rate.Rate.Scale() + unitRateExtraPlaces
+	}
+	return rate.Rate.Div(models.NewDecimalFromInt(int64(rate.Nominal)), places)
 }
 
-func (noopCache) Set(currency models.Currency, requestedDate time.Time, rate float64, actualDate time.Time) {
+// isPowerOf10 проверяет, что n = 10^k
+func isPowerOf10(n int) bool {
+	for n >= 10 && n%10 == 0 {
+		n /= 10
+	}
+	return n == 1
 }
 
+type noopCache struct{}
+
+func (noopCache) Get(date time.Time) (*models.RateData, bool) {
+	return nil, false
+}
+
+func (noopCache) Set(requestedDate time.Time, rateData *models.RateData) {}
+
 func (noopCache) Clear() {}
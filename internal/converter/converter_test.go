@@ -3,7 +3,6 @@ package converter
 import (
 	"context"
 	"errors"
-	"math"
 	"strings"
 	"testing"
 	"time"
@@ -30,46 +29,26 @@ func (m *MockRateProvider) FetchRates(_ context.Context, date time.Time) (*model
 
 // MockCacheStorage - мок для CacheStorage
 type MockCacheStorage struct {
-	data map[string]struct {
-		rate       float64
-		actualDate time.Time
-	}
+	data map[string]*models.RateData
 }
 
 func NewMockCache() *MockCacheStorage {
 	return &MockCacheStorage{
-		data: make(map[string]struct {
-			rate       float64
-			actualDate time.Time
-		}),
+		data: make(map[string]*models.RateData),
 	}
 }
 
-func (m *MockCacheStorage) Get(currency models.Currency, date time.Time) (float64, time.Time, bool) {
-	key := string(currency) + ":" + date.Format("2006-01-02")
-	entry, exists := m.data[key]
-	if !exists {
-		return 0, time.Time{}, false
-	}
-	return entry.rate, entry.actualDate, true
+func (m *MockCacheStorage) Get(date time.Time) (*models.RateData, bool) {
+	rateData, exists := m.data[date.Format("2006-01-02")]
+	return rateData, exists
 }
 
-func (m *MockCacheStorage) Set(currency models.Currency, requestedDate time.Time, rate float64, actualDate time.Time) {
-	key := string(currency) + ":" + requestedDate.Format("2006-01-02")
-	m.data[key] = struct {
-		rate       float64
-		actualDate time.Time
-	}{
-		rate:       rate,
-		actualDate: actualDate,
-	}
+func (m *MockCacheStorage) Set(requestedDate time.Time, rateData *models.RateData) {
+	m.data[requestedDate.Format("2006-01-02")] = rateData
 }
 
 func (m *MockCacheStorage) Clear() {
-	m.data = make(map[string]struct {
-		rate       float64
-		actualDate time.Time
-	})
+	m.data = make(map[string]*models.RateData)
 }
 
 // Тесты для проблемы 4: Rate date - использование фактической даты из XML
@@ -88,7 +67,7 @@ func TestConverter_Convert_UsesActualDateFromXML(t *testing.T) {
 			Rates: map[models.Currency]models.ExchangeRate{
 				models.USD: {
 					Currency: models.USD,
-					Rate:     80.0,
+					Rate:     dec("80.0"),
 					Nominal:  1,
 					Date:     actualDateFromXML,
 				},
@@ -99,7 +78,7 @@ func TestConverter_Convert_UsesActualDateFromXML(t *testing.T) {
 	cache := NewMockCache()
 	converter := NewConverter(mockProvider, cache)
 
-	result, err := converter.Convert(context.Background(), 100, models.USD, requestedDate)
+	result, err := converter.Convert(context.Background(), dec("100"), models.USD, requestedDate)
 	if err != nil {
 		t.Fatalf("Convert() error = %v, want nil", err)
 	}
@@ -129,7 +108,7 @@ func TestConverter_Convert_UsesActualDateFromCache(t *testing.T) {
 			Rates: map[models.Currency]models.ExchangeRate{
 				models.USD: {
 					Currency: models.USD,
-					Rate:     80.0,
+					Rate:     dec("80.0"),
 					Nominal:  1,
 					Date:     actualDateFromXML,
 				},
@@ -141,7 +120,7 @@ func TestConverter_Convert_UsesActualDateFromCache(t *testing.T) {
 	converter := NewConverter(mockProvider, cache)
 
 	// Первый вызов - получаем из provider и кэшируем
-	result1, err := converter.Convert(context.Background(), 100, models.USD, requestedDate)
+	result1, err := converter.Convert(context.Background(), dec("100"), models.USD, requestedDate)
 	if err != nil {
 		t.Fatalf("First Convert() error = %v, want nil", err)
 	}
@@ -151,7 +130,7 @@ func TestConverter_Convert_UsesActualDateFromCache(t *testing.T) {
 	}
 
 	// Второй вызов - должен использовать кэш с фактической датой
-	result2, err := converter.Convert(context.Background(), 200, models.USD, requestedDate)
+	result2, err := converter.Convert(context.Background(), dec("200"), models.USD, requestedDate)
 	if err != nil {
 		t.Fatalf("Second Convert() error = %v, want nil", err)
 	}
@@ -178,7 +157,7 @@ func TestConverter_GetRateInternal_UsesActualDate(t *testing.T) {
 			Rates: map[models.Currency]models.ExchangeRate{
 				models.USD: {
 					Currency: models.USD,
-					Rate:     80.0,
+					Rate:     dec("80.0"),
 					Nominal:  1,
 					Date:     actualDateFromXML,
 				},
@@ -193,7 +172,7 @@ func TestConverter_GetRateInternal_UsesActualDate(t *testing.T) {
 	// Но getRateInternal - приватный метод, поэтому тестируем через Convert
 	// и проверяем, что actualDate используется корректно
 
-	result, err := converter.Convert(context.Background(), 100, models.USD, requestedDate)
+	result, err := converter.Convert(context.Background(), dec("100"), models.USD, requestedDate)
 	if err != nil {
 		t.Fatalf("Convert() error = %v, want nil", err)
 	}
@@ -209,59 +188,44 @@ func TestConverter_GetRateInternal_UsesActualDate(t *testing.T) {
 func TestValidateAmount(t *testing.T) {
 	tests := []struct {
 		name    string
-		amount  float64
+		amount  string
 		wantErr bool
 	}{
 		{
 			name:    "Положительная сумма",
-			amount:  1000.0,
+			amount:  "1000.0",
 			wantErr: false,
 		},
 		{
 			name:    "Маленькая положительная сумма",
-			amount:  0.01,
+			amount:  "0.01",
 			wantErr: false,
 		},
 		{
 			name:    "Большая сумма",
-			amount:  1000000.0,
+			amount:  "1000000.0",
 			wantErr: false,
 		},
 		{
 			name:    "Ноль - ошибка",
-			amount:  0.0,
+			amount:  "0.0",
 			wantErr: true,
 		},
 		{
 			name:    "Отрицательная сумма - ошибка",
-			amount:  -100.0,
+			amount:  "-100.0",
 			wantErr: true,
 		},
 		{
 			name:    "Очень маленькая отрицательная - ошибка",
-			amount:  -0.01,
-			wantErr: true,
-		},
-		{
-			name:    "NaN - ошибка",
-			amount:  math.NaN(),
-			wantErr: true,
-		},
-		{
-			name:    "Положительная бесконечность - ошибка",
-			amount:  math.Inf(1),
-			wantErr: true,
-		},
-		{
-			name:    "Отрицательная бесконечность - ошибка",
-			amount:  math.Inf(-1),
+			amount:  "-0.01",
 			wantErr: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := ValidateAmount(tt.amount)
+			err := ValidateAmount(dec(tt.amount))
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ValidateAmount() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -390,49 +354,49 @@ func TestAddThousandsSeparator(t *testing.T) {
 func TestFormatNumber(t *testing.T) {
 	tests := []struct {
 		name     string
-		number   float64
+		number   string
 		expected string
 	}{
 		{
 			name:     "Целое число",
-			number:   1000.0,
+			number:   "1000.0",
 			expected: "1 000,00",
 		},
 		{
 			name:     "Число с копейками",
-			number:   1000.50,
+			number:   "1000.50",
 			expected: "1 000,50",
 		},
 		{
 			name:     "Большое число",
-			number:   80722.0,
+			number:   "80722.0",
 			expected: "80 722,00",
 		},
 		{
 			name:     "Миллион",
-			number:   1000000.0,
+			number:   "1000000.0",
 			expected: "1 000 000,00",
 		},
 		{
 			name:     "Число с округлением",
-			number:   999.999,
+			number:   "999.999",
 			expected: "1 000,00", // Округляется до 1000.00
 		},
 		{
 			name:     "Маленькое число",
-			number:   100.5,
+			number:   "100.5",
 			expected: "100,50",
 		},
 		{
 			name:     "Ноль",
-			number:   0.0,
+			number:   "0.0",
 			expected: "0,00",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := formatNumber(tt.number)
+			got := formatNumber(dec(tt.number))
 			if got != tt.expected {
 				t.Errorf("formatNumber() = %s, expected %s", got, tt.expected)
 			}
@@ -443,49 +407,57 @@ func TestFormatNumber(t *testing.T) {
 func TestFormatResult(t *testing.T) {
 	tests := []struct {
 		name      string
-		amount    float64
-		rate      float64
+		amount    string
+		rate      string
 		currency  models.Currency
-		resultRUB float64
+		resultRUB string
 		expected  string
 	}{
 		{
 			name:      "USD конвертация",
-			amount:    1000.0,
-			rate:      80.7220,
+			amount:    "1000.0",
+			rate:      "80.7220",
 			currency:  models.USD,
-			resultRUB: 80722.0,
+			resultRUB: "80722.0",
 			expected:  "80 722,00 руб. ($1 000,00 по курсу 80,7220)",
 		},
 		{
 			name:      "EUR конвертация",
-			amount:    500.0,
-			rate:      94.5120,
+			amount:    "500.0",
+			rate:      "94.5120",
 			currency:  models.EUR,
-			resultRUB: 47256.0,
+			resultRUB: "47256.0",
 			expected:  "47 256,00 руб. (€500,00 по курсу 94,5120)",
 		},
 		{
 			name:      "Маленькая сумма USD",
-			amount:    100.0,
-			rate:      80.0,
+			amount:    "100.0",
+			rate:      "80.0",
 			currency:  models.USD,
-			resultRUB: 8000.0,
+			resultRUB: "8000.0",
 			expected:  "8 000,00 руб. ($100,00 по курсу 80,0000)",
 		},
 		{
 			name:      "Дробная сумма",
-			amount:    100.50,
-			rate:      80.0,
+			amount:    "100.50",
+			rate:      "80.0",
 			currency:  models.USD,
-			resultRUB: 8040.0,
+			resultRUB: "8040.0",
 			expected:  "8 040,00 руб. ($100,50 по курсу 80,0000)",
 		},
+		{
+			name:      "Валюта без символа",
+			amount:    "1000.0",
+			rate:      "11.2345",
+			currency:  models.Currency("CNY"),
+			resultRUB: "11234.5",
+			expected:  "11 234,50 руб. (1 000,00 CNY по курсу 11,2345)",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := FormatResult(tt.amount, tt.rate, tt.currency, tt.resultRUB)
+			got := FormatResult(dec(tt.amount), dec(tt.rate), tt.currency, dec(tt.resultRUB))
 			if got != tt.expected {
 				t.Errorf("FormatResult() = %s, expected %s", got, tt.expected)
 			}
@@ -493,6 +465,43 @@ func TestFormatResult(t *testing.T) {
 	}
 }
 
+func TestFormatReverseResult(t *testing.T) {
+	tests := []struct {
+		name      string
+		amountRUB string
+		rate      string
+		currency  models.Currency
+		result    string
+		expected  string
+	}{
+		{
+			name:      "Рубли в USD",
+			amountRUB: "80722.0",
+			rate:      "80.7220",
+			currency:  models.USD,
+			result:    "1000.0",
+			expected:  "$1 000,00 (80 722,00 руб. по курсу 80,7220)",
+		},
+		{
+			name:      "Рубли в CNY",
+			amountRUB: "1150.0",
+			rate:      "11.5",
+			currency:  models.Currency("CNY"),
+			result:    "100.0",
+			expected:  "100,00 CNY (1 150,00 руб. по курсу 11,5000)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatReverseResult(dec(tt.amountRUB), dec(tt.rate), tt.currency, dec(tt.result))
+			if got != tt.expected {
+				t.Errorf("FormatReverseResult() = %s, expected %s", got, tt.expected)
+			}
+		})
+	}
+}
+
 // Тесты для converter.go
 
 func TestNewConverter(t *testing.T) {
@@ -531,22 +540,19 @@ func TestNewConverter_NilCacheUsesNoop(t *testing.T) {
 	date := testPastDateUTC()
 
 	// Get должен возвращать false
-	rate, actualDate, found := converter.cache.Get(models.USD, date)
+	rateData, found := converter.cache.Get(date)
 	if found {
 		t.Error("noopCache.Get() должен возвращать false")
 	}
-	if rate != 0 {
-		t.Errorf("noopCache.Get() должен возвращать rate=0, получено %v", rate)
-	}
-	if !actualDate.IsZero() {
-		t.Errorf("noopCache.Get() должен возвращать нулевую дату, получено %v", actualDate)
+	if rateData != nil {
+		t.Errorf("noopCache.Get() должен возвращать nil, получено %v", rateData)
 	}
 
 	// Set не должен вызывать ошибок
-	converter.cache.Set(models.USD, date, 80.0, date)
+	converter.cache.Set(date, rateSnapshot(date, models.USD, "80.0"))
 
 	// После Set Get все равно должен возвращать false (noop cache)
-	_, _, found = converter.cache.Get(models.USD, date)
+	_, found = converter.cache.Get(date)
 	if found {
 		t.Error("noopCache.Get() после Set должен все равно возвращать false")
 	}
@@ -559,7 +565,7 @@ func TestConverter_Convert_NilProvider(t *testing.T) {
 	cache := NewMockCache()
 	converter := NewConverter(nil, cache)
 
-	_, err := converter.Convert(context.Background(), 100, models.USD, time.Now())
+	_, err := converter.Convert(context.Background(), dec("100"), models.USD, time.Now())
 	if err == nil {
 		t.Fatal("Ожидалась ошибка при отсутствии источника курсов")
 	}
@@ -579,13 +585,13 @@ func TestConverter_Convert_Success(t *testing.T) {
 			Rates: map[models.Currency]models.ExchangeRate{
 				models.USD: {
 					Currency: models.USD,
-					Rate:     80.7220,
+					Rate:     dec("80.7220"),
 					Nominal:  1,
 					Date:     date,
 				},
 				models.EUR: {
 					Currency: models.EUR,
-					Rate:     94.5120,
+					Rate:     dec("94.5120"),
 					Nominal:  1,
 					Date:     date,
 				},
@@ -597,7 +603,7 @@ func TestConverter_Convert_Success(t *testing.T) {
 	converter := NewConverter(mockProvider, cache)
 
 	// Тест USD
-	result, err := converter.Convert(context.Background(), 1000, models.USD, date)
+	result, err := converter.Convert(context.Background(), dec("1000"), models.USD, date)
 	if err != nil {
 		t.Fatalf("Неожиданная ошибка: %v", err)
 	}
@@ -606,7 +612,7 @@ func TestConverter_Convert_Success(t *testing.T) {
 		t.Fatal("Result не должен быть nil")
 	}
 
-	if result.SourceAmount != 1000 {
+	if !result.SourceAmount.Equal(dec("1000")) {
 		t.Errorf("SourceAmount: ожидалось 1000, получено %v", result.SourceAmount)
 	}
 
@@ -618,11 +624,11 @@ func TestConverter_Convert_Success(t *testing.T) {
 		t.Errorf("TargetCurrency: ожидалось RUB, получено %v", result.TargetCurrency)
 	}
 
-	if result.Rate != 80.7220 {
+	if !result.Rate.Equal(dec("80.7220")) {
 		t.Errorf("Rate: ожидалось 80.7220, получено %v", result.Rate)
 	}
 
-	if result.TargetAmount != 80722.0 {
+	if !result.TargetAmount.Equal(dec("80722.0")) {
 		t.Errorf("TargetAmount: ожидалось 80722.0, получено %v", result.TargetAmount)
 	}
 
@@ -632,12 +638,20 @@ func TestConverter_Convert_Success(t *testing.T) {
 	}
 
 	// Проверяем что курс сохранен в кэше
-	cachedRate, _, found := cache.Get(models.USD, date)
+	cached, _, found := cachedRate(cache, models.USD, date)
 	if !found {
 		t.Error("Курс должен быть сохранен в кэше")
 	}
-	if cachedRate != 80.7220 {
-		t.Errorf("Cached rate: ожидалось 80.7220, получено %v", cachedRate)
+	if !cached.Equal(dec("80.7220")) {
+		t.Errorf("Cached rate: ожидалось 80.7220, получено %v", cached)
+	}
+
+	// Снимок в кэше содержит все валюты из ответа: EUR на ту же дату - без обращения к ЦБ РФ
+	if _, err := converter.Convert(context.Background(), dec("1000"), models.EUR, date); err != nil {
+		t.Fatalf("Неожиданная ошибка: %v", err)
+	}
+	if mockProvider.callCount != 1 {
+		t.Errorf("FetchRates вызван %d раз, ожидался 1 (EUR из снимка в кэше)", mockProvider.callCount)
 	}
 }
 
@@ -651,21 +665,21 @@ func TestConverter_Convert_CacheHit(t *testing.T) {
 
 	cache := NewMockCache()
 	// Предварительно заполняем кэш
-	cache.Set(models.USD, date, 85.0, date) // requestedDate и actualDate одинаковы
+	cache.Set(date, rateSnapshot(date, models.USD, "85.0")) // requestedDate и actualDate одинаковы
 
 	converter := NewConverter(mockProvider, cache)
 
-	result, err := converter.Convert(context.Background(), 1000, models.USD, date)
+	result, err := converter.Convert(context.Background(), dec("1000"), models.USD, date)
 	if err != nil {
 		t.Fatalf("Неожиданная ошибка: %v", err)
 	}
 
 	// Проверяем что использован курс из кэша
-	if result.Rate != 85.0 {
+	if !result.Rate.Equal(dec("85.0")) {
 		t.Errorf("Rate: ожидалось 85.0 (из кэша), получено %v", result.Rate)
 	}
 
-	if result.TargetAmount != 85000.0 {
+	if !result.TargetAmount.Equal(dec("85000.0")) {
 		t.Errorf("TargetAmount: ожидалось 85000.0, получено %v", result.TargetAmount)
 	}
 }
@@ -680,16 +694,16 @@ func TestConverter_Convert_RUB(t *testing.T) {
 	cache := NewMockCache()
 	converter := NewConverter(mockProvider, cache)
 
-	result, err := converter.Convert(context.Background(), 1000, models.RUB, date)
+	result, err := converter.Convert(context.Background(), dec("1000"), models.RUB, date)
 	if err != nil {
 		t.Fatalf("Неожиданная ошибка: %v", err)
 	}
 
-	if result.Rate != 1 {
+	if !result.Rate.Equal(dec("1")) {
 		t.Errorf("Rate: ожидалось 1, получено %v", result.Rate)
 	}
 
-	if result.TargetAmount != 1000 {
+	if !result.TargetAmount.Equal(dec("1000")) {
 		t.Errorf("TargetAmount: ожидалось 1000, получено %v", result.TargetAmount)
 	}
 
@@ -709,35 +723,35 @@ func TestConverter_Convert_ValidationErrors(t *testing.T) {
 
 	tests := []struct {
 		name     string
-		amount   float64
+		amount   string
 		currency models.Currency
 		date     time.Time
 		wantErr  error
 	}{
 		{
 			name:     "Отрицательная сумма",
-			amount:   -100,
+			amount:   "-100",
 			currency: models.USD,
 			date:     date,
 			wantErr:  ErrInvalidAmount,
 		},
 		{
 			name:     "Нулевая сумма",
-			amount:   0,
+			amount:   "0",
 			currency: models.USD,
 			date:     date,
 			wantErr:  ErrInvalidAmount,
 		},
 		{
 			name:     "Неподдерживаемая валюта",
-			amount:   1000,
-			currency: models.Currency("GBP"),
+			amount:   "1000",
+			currency: models.Currency("XYZ"),
 			date:     date,
 			wantErr:  models.ErrUnsupportedCurrency,
 		},
 		{
 			name:     "Дата в будущем",
-			amount:   1000,
+			amount:   "1000",
 			currency: models.USD,
 			date:     futureDate,
 			wantErr:  ErrDateInFuture,
@@ -746,7 +760,7 @@ func TestConverter_Convert_ValidationErrors(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := converter.Convert(context.Background(), tt.amount, tt.currency, tt.date)
+			_, err := converter.Convert(context.Background(), dec(tt.amount), tt.currency, tt.date)
 			if err == nil {
 				t.Fatal("Ожидалась ошибка, но её нет")
 			}
@@ -769,7 +783,7 @@ func TestConverter_Convert_ProviderError(t *testing.T) {
 	cache := NewMockCache()
 	converter := NewConverter(mockProvider, cache)
 
-	_, err := converter.Convert(context.Background(), 1000, models.USD, date)
+	_, err := converter.Convert(context.Background(), dec("1000"), models.USD, date)
 	if err == nil {
 		t.Fatal("Ожидалась ошибка от provider")
 	}
@@ -790,7 +804,7 @@ func TestConverter_Convert_CurrencyNotFound(t *testing.T) {
 			Rates: map[models.Currency]models.ExchangeRate{
 				models.EUR: {
 					Currency: models.EUR,
-					Rate:     94.5120,
+					Rate:     dec("94.5120"),
 					Nominal:  1,
 					Date:     date,
 				},
@@ -801,10 +815,142 @@ func TestConverter_Convert_CurrencyNotFound(t *testing.T) {
 	cache := NewMockCache()
 	converter := NewConverter(mockProvider, cache)
 
-	_, err := converter.Convert(context.Background(), 1000, models.USD, date)
+	_, err := converter.Convert(context.Background(), dec("1000"), models.USD, date)
 	if err == nil {
 		t.Fatal("Ожидалась ошибка 'currency not found'")
 	}
+	if !errors.Is(err, ErrRateNotFound) {
+		t.Errorf("Ожидалась ошибка %v, получена %v", ErrRateNotFound, err)
+	}
+}
+
+func TestConverter_Convert_CurrencyNotFoundFromCache(t *testing.T) {
+	date := testPastDateUTC()
+	mockProvider := &MockRateProvider{rateData: rateSnapshot(date, models.USD, "80.7220")}
+	converter := NewConverter(mockProvider, NewMockCache())
+
+	for range 2 {
+		_, err := converter.Convert(context.Background(), dec("100"), "KZT", date)
+		if !errors.Is(err, ErrRateNotFound) {
+			t.Fatalf("Ожидалась ошибка ErrRateNotFound, получено: %v", err)
+		}
+	}
+
+	// Полный снимок на дату уже в кэше: валюты в нем нет - ЦБ РФ её не публиковал
+	if mockProvider.callCount != 1 {
+		t.Errorf("FetchRates вызван %d раз, ожидался 1", mockProvider.callCount)
+	}
+}
+
+func TestConverter_ConvertFromRUB(t *testing.T) {
+	date := testPastDateUTC()
+
+	mockProvider := &MockRateProvider{
+		rateData: &models.RateData{
+			Date: date,
+			Rates: map[models.Currency]models.ExchangeRate{
+				models.USD: {Currency: models.USD, Rate: dec("80.7220"), Nominal: 1, Date: date},
+			},
+		},
+	}
+
+	converter := NewConverter(mockProvider, NewMockCache())
+
+	result, err := converter.ConvertFromRUB(context.Background(), dec("80722"), models.USD, date)
+	if err != nil {
+		t.Fatalf("ConvertFromRUB() error = %v", err)
+	}
+
+	if result.SourceCurrency != models.RUB || result.TargetCurrency != models.USD {
+		t.Errorf("Валюты = %s → %s, ожидается RUB → USD", result.SourceCurrency, result.TargetCurrency)
+	}
+	if result.Direction != models.FromRUB {
+		t.Errorf("Direction = %s, ожидается %s", result.Direction, models.FromRUB)
+	}
+	if !result.TargetAmount.Equal(dec("1000")) {
+		t.Errorf("TargetAmount = %v, ожидается 1000", result.TargetAmount)
+	}
+	if !result.Rate.Equal(dec("80.7220")) {
+		t.Errorf("Rate = %v, ожидается 80.7220", result.Rate)
+	}
+	if result.FormattedStr != "$1 000,00 (80 722,00 руб. по курсу 80,7220)" {
+		t.Errorf("FormattedStr = %q", result.FormattedStr)
+	}
+}
+
+func TestConverter_ConvertDirection(t *testing.T) {
+	date := testPastDateUTC()
+
+	mockProvider := &MockRateProvider{
+		rateData: &models.RateData{
+			Date: date,
+			Rates: map[models.Currency]models.ExchangeRate{
+				models.EUR: {Currency: models.EUR, Rate: dec("100"), Nominal: 1, Date: date},
+			},
+		},
+	}
+
+	converter := NewConverter(mockProvider, NewMockCache())
+
+	toRUB, err := converter.ConvertDirection(context.Background(), dec("10"), models.EUR, models.ToRUB, date)
+	if err != nil {
+		t.Fatalf("ConvertDirection(ToRUB) error = %v", err)
+	}
+	if !toRUB.TargetAmount.Equal(dec("1000")) || toRUB.TargetCurrency != models.RUB {
+		t.Errorf("ConvertDirection(ToRUB) = %v %s, ожидается 1000 RUB", toRUB.TargetAmount, toRUB.TargetCurrency)
+	}
+
+	fromRUB, err := converter.ConvertDirection(context.Background(), dec("1000"), models.EUR, models.FromRUB, date)
+	if err != nil {
+		t.Fatalf("ConvertDirection(FromRUB) error = %v", err)
+	}
+	if !fromRUB.TargetAmount.Equal(dec("10")) || fromRUB.TargetCurrency != models.EUR {
+		t.Errorf("ConvertDirection(FromRUB) = %v %s, ожидается 10 EUR", fromRUB.TargetAmount, fromRUB.TargetCurrency)
+	}
+
+	_, err = converter.ConvertDirection(context.Background(), dec("1000"), models.EUR, models.Direction("sideways"), date)
+	if !errors.Is(err, models.ErrInvalidDirection) {
+		t.Errorf("ConvertDirection(sideways) error = %v, ожидается ErrInvalidDirection", err)
+	}
+}
+
+func TestConverter_Convert_AnyPublishedCurrency(t *testing.T) {
+	date := testPastDateUTC()
+
+	mockProvider := &MockRateProvider{
+		rateData: &models.RateData{
+			Date: date,
+			Rates: map[models.Currency]models.ExchangeRate{
+				"CNY": {Currency: "CNY", Rate: dec("11.2345"), Nominal: 1, Date: date},
+				"KZT": {Currency: "KZT", Rate: dec("15.4321"), Nominal: 100, Date: date},
+			},
+		},
+	}
+
+	converter := NewConverter(mockProvider, NewMockCache())
+
+	tests := []struct {
+		currency models.Currency
+		want     string
+	}{
+		{currency: "CNY", want: "11234.50"},
+		{currency: "KZT", want: "154.32"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.currency), func(t *testing.T) {
+			result, err := converter.Convert(context.Background(), dec("1000"), tt.currency, date)
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			if !result.TargetAmount.Equal(dec(tt.want)) {
+				t.Errorf("TargetAmount = %v, ожидается %v", result.TargetAmount, tt.want)
+			}
+			if result.SourceCurrency != tt.currency {
+				t.Errorf("SourceCurrency = %s, ожидается %s", result.SourceCurrency, tt.currency)
+			}
+		})
+	}
 }
 
 func TestConverter_Convert_MultipleConversions(t *testing.T) {
@@ -814,8 +960,8 @@ func TestConverter_Convert_MultipleConversions(t *testing.T) {
 		rateData: &models.RateData{
 			Date: date,
 			Rates: map[models.Currency]models.ExchangeRate{
-				models.USD: {Currency: models.USD, Rate: 80.0, Nominal: 1, Date: date},
-				models.EUR: {Currency: models.EUR, Rate: 90.0, Nominal: 1, Date: date},
+				models.USD: {Currency: models.USD, Rate: dec("80.0"), Nominal: 1, Date: date},
+				models.EUR: {Currency: models.EUR, Rate: dec("90.0"), Nominal: 1, Date: date},
 			},
 		},
 	}
@@ -824,29 +970,29 @@ func TestConverter_Convert_MultipleConversions(t *testing.T) {
 	converter := NewConverter(mockProvider, cache)
 
 	// Первая конвертация USD
-	result1, err := converter.Convert(context.Background(), 1000, models.USD, date)
+	result1, err := converter.Convert(context.Background(), dec("1000"), models.USD, date)
 	if err != nil {
 		t.Fatalf("Ошибка первой конвертации: %v", err)
 	}
-	if result1.TargetAmount != 80000.0 {
+	if !result1.TargetAmount.Equal(dec("80000.0")) {
 		t.Errorf("USD результат: ожидалось 80000, получено %v", result1.TargetAmount)
 	}
 
 	// Вторая конвертация EUR
-	result2, err := converter.Convert(context.Background(), 500, models.EUR, date)
+	result2, err := converter.Convert(context.Background(), dec("500"), models.EUR, date)
 	if err != nil {
 		t.Fatalf("Ошибка второй конвертации: %v", err)
 	}
-	if result2.TargetAmount != 45000.0 {
+	if !result2.TargetAmount.Equal(dec("45000.0")) {
 		t.Errorf("EUR результат: ожидалось 45000, получено %v", result2.TargetAmount)
 	}
 
 	// Третья конвертация USD (должна использовать кэш)
-	result3, err := converter.Convert(context.Background(), 2000, models.USD, date)
+	result3, err := converter.Convert(context.Background(), dec("2000"), models.USD, date)
 	if err != nil {
 		t.Fatalf("Ошибка третьей конвертации: %v", err)
 	}
-	if result3.TargetAmount != 160000.0 {
+	if !result3.TargetAmount.Equal(dec("160000.0")) {
 		t.Errorf("USD результат: ожидалось 160000, получено %v", result3.TargetAmount)
 	}
 }
@@ -860,7 +1006,7 @@ func TestConverter_Convert_NormalizesNominalRate(t *testing.T) {
 			Rates: map[models.Currency]models.ExchangeRate{
 				models.USD: {
 					Currency: models.USD,
-					Rate:     100.0,
+					Rate:     dec("100.0"),
 					Nominal:  10,
 					Date:     date,
 				},
@@ -871,25 +1017,25 @@ func TestConverter_Convert_NormalizesNominalRate(t *testing.T) {
 	cache := NewMockCache()
 	converter := NewConverter(mockProvider, cache)
 
-	result, err := converter.Convert(context.Background(), 10, models.USD, date)
+	result, err := converter.Convert(context.Background(), dec("10"), models.USD, date)
 	if err != nil {
 		t.Fatalf("Неожиданная ошибка: %v", err)
 	}
 
-	if result.Rate != 10.0 {
+	if !result.Rate.Equal(dec("10.0")) {
 		t.Errorf("Rate: ожидалось 10.0 (100/10), получено %v", result.Rate)
 	}
 
-	if result.TargetAmount != 100.0 {
+	if !result.TargetAmount.Equal(dec("100.0")) {
 		t.Errorf("TargetAmount: ожидалось 100.0, получено %v", result.TargetAmount)
 	}
 
-	cachedRate, _, found := cache.Get(models.USD, date)
+	cached, _, found := cachedRate(cache, models.USD, date)
 	if !found {
 		t.Fatal("Ожидался сохраненный курс в кэше")
 	}
-	if cachedRate != 10.0 {
-		t.Errorf("Cached rate: ожидалось 10.0, получено %v", cachedRate)
+	if !cached.Equal(dec("10.0")) {
+		t.Errorf("Cached rate: ожидалось 10.0, получено %v", cached)
 	}
 }
 
@@ -904,7 +1050,7 @@ func TestConverter_Convert_NormalizesDateForCache(t *testing.T) {
 			Rates: map[models.Currency]models.ExchangeRate{
 				models.USD: {
 					Currency: models.USD,
-					Rate:     80.0,
+					Rate:     dec("80.0"),
 					Nominal:  1,
 					Date:     date,
 				},
@@ -915,12 +1061,12 @@ func TestConverter_Convert_NormalizesDateForCache(t *testing.T) {
 	cache := NewMockCache()
 	converter := NewConverter(mockProvider, cache)
 
-	_, err := converter.Convert(context.Background(), 100, models.USD, date)
+	_, err := converter.Convert(context.Background(), dec("100"), models.USD, date)
 	if err != nil {
 		t.Fatalf("Неожиданная ошибка первой конвертации: %v", err)
 	}
 
-	_, err = converter.Convert(context.Background(), 200, models.USD, sameDayLater)
+	_, err = converter.Convert(context.Background(), dec("200"), models.USD, sameDayLater)
 	if err != nil {
 		t.Fatalf("Неожиданная ошибка второй конвертации: %v", err)
 	}
@@ -949,14 +1095,14 @@ func TestConvert_NilProvider_RUB(t *testing.T) {
 	converter := NewConverter(nil, cache)
 	date := testPastDateUTC()
 
-	result, err := converter.Convert(context.Background(), 1000, models.RUB, date)
+	result, err := converter.Convert(context.Background(), dec("1000"), models.RUB, date)
 	if err != nil {
 		t.Fatalf("Convert(RUB) with nil provider should succeed, got error: %v", err)
 	}
-	if result.Rate != 1 {
+	if !result.Rate.Equal(dec("1")) {
 		t.Errorf("Rate = %v, want 1", result.Rate)
 	}
-	if result.TargetAmount != 1000 {
+	if !result.TargetAmount.Equal(dec("1000")) {
 		t.Errorf("TargetAmount = %v, want 1000", result.TargetAmount)
 	}
 }
@@ -970,7 +1116,7 @@ func TestGetRate_NilProvider_RUB(t *testing.T) {
 	if err != nil {
 		t.Fatalf("GetRate(RUB) with nil provider should succeed, got error: %v", err)
 	}
-	if rate != 1.0 {
+	if !rate.Equal(dec("1.0")) {
 		t.Errorf("Rate = %v, want 1.0", rate)
 	}
 }
@@ -980,7 +1126,7 @@ func TestConvert_NilProvider_USD(t *testing.T) {
 	converter := NewConverter(nil, cache)
 	date := testPastDateUTC()
 
-	_, err := converter.Convert(context.Background(), 1000, models.USD, date)
+	_, err := converter.Convert(context.Background(), dec("1000"), models.USD, date)
 	if err == nil {
 		t.Fatal("Convert(USD) with nil provider should return error")
 	}
@@ -999,7 +1145,7 @@ func TestConvert_ProviderReturnsNilData(t *testing.T) {
 	converter := NewConverter(mockProvider, cache)
 	date := testPastDateUTC()
 
-	_, err := converter.Convert(context.Background(), 1000, models.USD, date)
+	_, err := converter.Convert(context.Background(), dec("1000"), models.USD, date)
 	if err == nil {
 		t.Fatal("expected error when provider returns nil data")
 	}
@@ -1021,3 +1167,315 @@ func TestGetRate_NilProvider_USD(t *testing.T) {
 		t.Errorf("expected ErrNilRateProvider, got: %v", err)
 	}
 }
+
+func TestConverter_ConvertCross(t *testing.T) {
+	date := testPastDateUTC()
+
+	mockProvider := &MockRateProvider{
+		rateData: &models.RateData{
+			Date: date,
+			Rates: map[models.Currency]models.ExchangeRate{
+				models.USD: {Currency: models.USD, Rate: dec("80.7220"), Nominal: 1, Date: date},
+				models.EUR: {Currency: models.EUR, Rate: dec("87.7300"), Nominal: 1, Date: date},
+				"KZT":      {Currency: "KZT", Rate: dec("15.0"), Nominal: 100, Date: date},
+			},
+		},
+	}
+
+	converter := NewConverter(mockProvider, NewMockCache())
+
+	result, err := converter.ConvertCross(context.Background(), dec("1000"), models.USD, models.EUR, date)
+	if err != nil {
+		t.Fatalf("ConvertCross() error = %v", err)
+	}
+
+	// Оба курса получены одним запросом к provider
+	if mockProvider.callCount != 1 {
+		t.Errorf("callCount = %d, ожидается 1", mockProvider.callCount)
+	}
+
+	if result.Direction != models.Cross {
+		t.Errorf("Direction = %s, ожидается %s", result.Direction, models.Cross)
+	}
+	if !result.SourceRate.Equal(dec("80.7220")) || !result.TargetRate.Equal(dec("87.7300")) {
+		t.Errorf("SourceRate = %v, TargetRate = %v", result.SourceRate, result.TargetRate)
+	}
+	if !result.Rate.Equal(dec("0.920119")) {
+		t.Errorf("Rate = %v, ожидается 0.920119", result.Rate)
+	}
+	if !result.TargetAmount.Equal(dec("920.12")) {
+		t.Errorf("TargetAmount = %v, ожидается 920.12", result.TargetAmount)
+	}
+	if result.FormattedStr != "€920,12 ($1 000,00 по кросс-курсу 0,9201; USD 80,7220 руб., EUR 87,7300 руб.)" {
+		t.Errorf("FormattedStr = %q", result.FormattedStr)
+	}
+
+	// Повторный запрос с другой парой на ту же дату обслуживается из кэша
+	result, err = converter.ConvertCross(context.Background(), dec("100"), models.EUR, "KZT", date)
+	if err != nil {
+		t.Fatalf("ConvertCross(EUR, KZT) error = %v", err)
+	}
+	if mockProvider.callCount != 1 {
+		// KZT есть в снимке, сохраненном первым вызовом
+		t.Errorf("callCount = %d, ожидается 1", mockProvider.callCount)
+	}
+	if !result.TargetAmount.Equal(dec("58486.67")) {
+		t.Errorf("TargetAmount = %v, ожидается 58486.67 (номинал KZT учтён)", result.TargetAmount)
+	}
+
+	if _, err := converter.ConvertCross(context.Background(), dec("100"), models.EUR, "KZT", date); err != nil {
+		t.Fatalf("ConvertCross() из кэша error = %v", err)
+	}
+	if mockProvider.callCount != 1 {
+		t.Errorf("callCount = %d, ожидается 1 (оба курса в кэше)", mockProvider.callCount)
+	}
+}
+
+func TestConverter_ConvertCross_WithRUB(t *testing.T) {
+	date := testPastDateUTC()
+
+	mockProvider := &MockRateProvider{
+		rateData: &models.RateData{
+			Date: date,
+			Rates: map[models.Currency]models.ExchangeRate{
+				models.USD: {Currency: models.USD, Rate: dec("80"), Nominal: 1, Date: date},
+			},
+		},
+	}
+
+	converter := NewConverter(mockProvider, NewMockCache())
+
+	result, err := converter.ConvertCross(context.Background(), dec("8000"), models.RUB, models.USD, date)
+	if err != nil {
+		t.Fatalf("ConvertCross() error = %v", err)
+	}
+	if !result.TargetAmount.Equal(dec("100")) {
+		t.Errorf("TargetAmount = %v, ожидается 100", result.TargetAmount)
+	}
+}
+
+func TestConverter_ConvertCross_NonRUBBase(t *testing.T) {
+	date := testPastDateUTC()
+
+	// Снимок ЕЦБ: курсы в евро, рубля нет
+	rateData := models.NewRateData(date)
+	rateData.Base = models.EUR
+	rateData.AddRate(models.ExchangeRate{Currency: models.USD, Rate: dec("0.8531"), Nominal: 1, Date: date})
+	rateData.AddRate(models.ExchangeRate{Currency: "JPY", Rate: dec("0.5490"), Nominal: 100, Date: date})
+	converter := NewConverter(&MockRateProvider{rateData: rateData}, NewMockCache())
+
+	result, err := converter.ConvertCross(context.Background(), dec("100"), models.USD, models.EUR, date)
+	if err != nil {
+		t.Fatalf("ConvertCross() error = %v", err)
+	}
+	if !result.TargetAmount.Equal(dec("85.31")) {
+		t.Errorf("TargetAmount = %v, ожидается 85.31", result.TargetAmount)
+	}
+	if result.FormattedStr != "€85,31 ($100,00 по кросс-курсу 0,8531; USD 0,8531 EUR, EUR 1,0000 EUR)" {
+		t.Errorf("FormattedStr = %q", result.FormattedStr)
+	}
+
+	result, err = converter.ConvertCross(context.Background(), dec("1000"), "JPY", models.USD, date)
+	if err != nil {
+		t.Fatalf("ConvertCross(JPY, USD) error = %v", err)
+	}
+	if !result.TargetAmount.Equal(dec("6.44")) {
+		t.Errorf("TargetAmount = %v, ожидается 6.44 (номинал JPY учтён)", result.TargetAmount)
+	}
+
+	// Рублевого курса в снимке нет
+	if _, err := converter.Convert(context.Background(), dec("100"), models.USD, date); !errors.Is(err, ErrRateNotFound) {
+		t.Errorf("Convert() error = %v, ожидается ErrRateNotFound", err)
+	}
+}
+
+func TestConverter_ConvertCross_ValidationErrors(t *testing.T) {
+	date := testPastDateUTC()
+	converter := NewConverter(&MockRateProvider{}, NewMockCache())
+
+	tests := []struct {
+		name    string
+		amount  string
+		from    models.Currency
+		to      models.Currency
+		wantErr error
+	}{
+		{name: "Нулевая сумма", amount: "0", from: models.USD, to: models.EUR, wantErr: ErrInvalidAmount},
+		{name: "Неизвестная исходная валюта", amount: "1", from: "XYZ", to: models.EUR, wantErr: models.ErrUnsupportedCurrency},
+		{name: "Неизвестная целевая валюта", amount: "1", from: models.USD, to: "XYZ", wantErr: models.ErrUnsupportedCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := converter.ConvertCross(context.Background(), dec(tt.amount), tt.from, tt.to, date)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ConvertCross() error = %v, ожидается %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConverter_ConvertCross_CurrencyNotFound(t *testing.T) {
+	date := testPastDateUTC()
+
+	mockProvider := &MockRateProvider{
+		rateData: &models.RateData{
+			Date: date,
+			Rates: map[models.Currency]models.ExchangeRate{
+				models.USD: {Currency: models.USD, Rate: dec("80"), Nominal: 1, Date: date},
+			},
+		},
+	}
+
+	converter := NewConverter(mockProvider, NewMockCache())
+
+	_, err := converter.ConvertCross(context.Background(), dec("100"), models.USD, "CNY", date)
+	if !errors.Is(err, ErrRateNotFound) {
+		t.Errorf("ConvertCross() error = %v, ожидается ErrRateNotFound", err)
+	}
+}
+
+// TestConverter_Convert_ExactDecimalRounding проверяет, что копейки считаются точно:
+// 7,50 × 80,0060 = 600,045 округляется до 600,05, тогда как во float64 получается 600,04
+func TestConverter_Convert_ExactDecimalRounding(t *testing.T) {
+	date := testPastDateUTC()
+
+	mockProvider := &MockRateProvider{
+		rateData: &models.RateData{
+			Date: date,
+			Rates: map[models.Currency]models.ExchangeRate{
+				models.USD: {Currency: models.USD, Rate: dec("80.0060"), Nominal: 1, Date: date},
+			},
+		},
+	}
+
+	converter := NewConverter(mockProvider, NewMockCache())
+
+	result, err := converter.Convert(context.Background(), dec("7.50"), models.USD, date)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if result.TargetAmount.String() != "600.05" {
+		t.Errorf("TargetAmount = %s, ожидается 600.05", result.TargetAmount)
+	}
+}
+
+func TestConverter_WithRounding(t *testing.T) {
+	date := testPastDateUTC()
+
+	mockProvider := &MockRateProvider{
+		rateData: &models.RateData{
+			Date: date,
+			Rates: map[models.Currency]models.ExchangeRate{
+				models.USD: {Currency: models.USD, Rate: dec("80.0060"), Nominal: 1, Date: date},
+			},
+		},
+	}
+
+	base := NewConverter(mockProvider, NewMockCache())
+
+	// 7,50 × 80,0060 = 600,045 - ровно половина на третьем знаке
+	tests := []struct {
+		name      string
+		rounding  models.Rounding
+		want      string
+		formatted string
+	}{
+		{
+			name:      "По умолчанию - половина от нуля до копеек",
+			rounding:  DefaultRounding,
+			want:      "600.05",
+			formatted: "600,05 руб. ($7,50 по курсу 80,0060)",
+		},
+		{
+			name:      "Банковское округление",
+			rounding:  models.Rounding{Mode: models.RoundHalfEven, Places: 2},
+			want:      "600.04",
+			formatted: "600,04 руб. ($7,50 по курсу 80,0060)",
+		},
+		{
+			name:      "Отбрасывание до целых рублей",
+			rounding:  models.Rounding{Mode: models.RoundDown, Places: 0},
+			want:      "600",
+			formatted: "600 руб. ($7,50 по курсу 80,0060)",
+		},
+		{
+			name:      "Вверх до целых рублей",
+			rounding:  models.Rounding{Mode: models.RoundUp, Places: 0},
+			want:      "601",
+			formatted: "601 руб. ($7,50 по курсу 80,0060)",
+		},
+		{
+			name:      "Четыре знака",
+			rounding:  models.Rounding{Mode: models.RoundHalfUp, Places: 4},
+			want:      "600.045",
+			formatted: "600,0450 руб. ($7,50 по курсу 80,0060)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := base.WithRounding(tt.rounding).Convert(context.Background(), dec("7.50"), models.USD, date)
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			if !result.TargetAmount.Equal(dec(tt.want)) {
+				t.Errorf("TargetAmount = %s, ожидается %s", result.TargetAmount, tt.want)
+			}
+			if result.FormattedStr != tt.formatted {
+				t.Errorf("FormattedStr = %q, ожидается %q", result.FormattedStr, tt.formatted)
+			}
+		})
+	}
+
+	// Исходный конвертер не меняется
+	if base.Rounding() != DefaultRounding {
+		t.Errorf("Rounding() исходного конвертера = %+v, ожидается %+v", base.Rounding(), DefaultRounding)
+	}
+}
+
+func TestConverter_WithRounding_FromRUBAndCross(t *testing.T) {
+	date := testPastDateUTC()
+
+	mockProvider := &MockRateProvider{
+		rateData: &models.RateData{
+			Date: date,
+			Rates: map[models.Currency]models.ExchangeRate{
+				models.USD: {Currency: models.USD, Rate: dec("80"), Nominal: 1, Date: date},
+				models.EUR: {Currency: models.EUR, Rate: dec("90"), Nominal: 1, Date: date},
+			},
+		},
+	}
+
+	conv := NewConverter(mockProvider, NewMockCache()).WithRounding(models.Rounding{Mode: models.RoundDown, Places: 0})
+
+	// 1000 / 80 = 12,5 → 12
+	fromRUB, err := conv.ConvertFromRUB(context.Background(), dec("1000"), models.USD, date)
+	if err != nil {
+		t.Fatalf("ConvertFromRUB() error = %v", err)
+	}
+	if fromRUB.TargetAmount.String() != "12" || fromRUB.FormattedStr != "$12 (1 000,00 руб. по курсу 80,0000)" {
+		t.Errorf("ConvertFromRUB() = %s, %q", fromRUB.TargetAmount, fromRUB.FormattedStr)
+	}
+
+	// 100 × 80 / 90 = 88,88... → 88
+	cross, err := conv.ConvertCross(context.Background(), dec("100"), models.USD, models.EUR, date)
+	if err != nil {
+		t.Fatalf("ConvertCross() error = %v", err)
+	}
+	if cross.TargetAmount.String() != "88" || !strings.HasPrefix(cross.FormattedStr, "€88 ($100,00") {
+		t.Errorf("ConvertCross() = %s, %q", cross.TargetAmount, cross.FormattedStr)
+	}
+}
+
+func TestConverter_WithRounding_Invalid(t *testing.T) {
+	date := testPastDateUTC()
+	conv := NewConverter(&MockRateProvider{}, NewMockCache()).WithRounding(models.Rounding{Mode: "ceiling", Places: 2})
+
+	if _, err := conv.Convert(context.Background(), dec("1"), models.USD, date); !errors.Is(err, models.ErrInvalidRounding) {
+		t.Errorf("Convert() error = %v, ожидается ErrInvalidRounding", err)
+	}
+	if _, err := conv.ConvertCross(context.Background(), dec("1"), models.USD, models.EUR, date); !errors.Is(err, models.ErrInvalidRounding) {
+		t.Errorf("ConvertCross() error = %v, ожидается ErrInvalidRounding", err)
+	}
+}
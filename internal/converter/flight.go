@@ -0,0 +1,62 @@
+package converter
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+// flightCall - загрузка курсов, которая выполняется прямо сейчас
+type flightCall struct {
+	done     chan struct{} // Закрывается по завершении загрузки
+	rateData *models.RateData
+	err      error
+	dups     int // Число вызывающих, присоединившихся к загрузке
+}
+
+// flightGroup объединяет одновременные запросы курсов на одну дату (single-flight):
+// пока загрузка выполняется, остальные вызывающие ждут её результата
+// вместо повторного обращения к ЦБ РФ
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall // Ключ - нормализованная дата "2006-01-02"
+}
+
+// do выполняет fetch для date или присоединяется к уже выполняющейся загрузке
+// Все вызывающие получают один и тот же результат или ошибку
+//
+// Загрузка выполняется с контекстом без отмены (значения контекста сохраняются):
+// отмена одного вызывающего не должна прерывать загрузку для остальных.
+// Каждый вызывающий перестает ждать при отмене своего ctx
+func (g *flightGroup) do(ctx context.Context, date string, fetch func(ctx context.Context) (*models.RateData, error)) (*models.RateData, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	call, inFlight := g.calls[date]
+	if inFlight {
+		call.dups++
+	} else {
+		call = &flightCall{done: make(chan struct{})}
+		g.calls[date] = call
+
+		fetchCtx := context.WithoutCancel(ctx)
+		go func() {
+			call.rateData, call.err = fetch(fetchCtx)
+
+			g.mu.Lock()
+			delete(g.calls, date)
+			g.mu.Unlock()
+			close(call.done)
+		}()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.rateData, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,208 @@
+package converter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+// blockingRateProvider - provider, который не отвечает до закрытия release
+// Потокобезопасен: считает вызовы атомарно
+type blockingRateProvider struct {
+	rateData *models.RateData
+	err      error
+	release  chan struct{}
+	calls    atomic.Int32
+}
+
+func newBlockingRateProvider(rateData *models.RateData, err error) *blockingRateProvider {
+	return &blockingRateProvider{
+		rateData: rateData,
+		err:      err,
+		release:  make(chan struct{}),
+	}
+}
+
+func (p *blockingRateProvider) FetchRates(_ context.Context, _ time.Time) (*models.RateData, error) {
+	p.calls.Add(1)
+	<-p.release
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.rateData, nil
+}
+
+// waitForDups ждет, пока к загрузке на date присоединятся dups вызывающих
+func waitForDups(t *testing.T, g *flightGroup, date time.Time, dups int) {
+	t.Helper()
+	key := date.Format("2006-01-02")
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		g.mu.Lock()
+		call, ok := g.calls[key]
+		joined := ok && call.dups == dups
+		g.mu.Unlock()
+		if joined {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("К загрузке на %s не присоединились %d вызывающих", key, dups)
+}
+
+func flightTestRateData(date time.Time) *models.RateData {
+	return &models.RateData{
+		Date: date,
+		Rates: map[models.Currency]models.ExchangeRate{
+			models.USD: {Currency: models.USD, Rate: dec("80.7220"), Nominal: 1, Date: date},
+		},
+	}
+}
+
+func TestConverter_ConcurrentFetchesAreCoalesced(t *testing.T) {
+	date := testPastDateUTC()
+
+	tests := []struct {
+		name    string
+		err     error
+		wantErr bool
+	}{
+		{name: "Все получают курс", err: nil, wantErr: false},
+		{name: "Все получают ошибку", err: errors.New("network down"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newBlockingRateProvider(flightTestRateData(date), tt.err)
+			conv := NewConverter(provider, nil)
+
+			const callers = 10
+			results := make([]models.Decimal, callers)
+			errs := make([]error, callers)
+
+			var wg sync.WaitGroup
+			for i := range callers {
+				wg.Go(func() {
+					results[i], errs[i] = conv.GetRate(context.Background(), models.USD, date)
+				})
+			}
+
+			waitForDups(t, conv.flight, normalizeDate(date), callers-1)
+			close(provider.release)
+			wg.Wait()
+
+			if got := provider.calls.Load(); got != 1 {
+				t.Errorf("FetchRates вызван %d раз, ожидался 1", got)
+			}
+			for i := range callers {
+				if tt.wantErr {
+					if !errors.Is(errs[i], tt.err) {
+						t.Errorf("Вызывающий %d: ожидалась ошибка %v, получено %v", i, tt.err, errs[i])
+					}
+					continue
+				}
+				if errs[i] != nil {
+					t.Errorf("Вызывающий %d: неожиданная ошибка %v", i, errs[i])
+				} else if !results[i].Equal(dec("80.7220")) {
+					t.Errorf("Вызывающий %d: ожидалось 80.7220, получено %v", i, results[i])
+				}
+			}
+		})
+	}
+}
+
+func TestConverter_ConcurrentFetches_GetRatesAndConvertShareFetch(t *testing.T) {
+	date := testPastDateUTC()
+	provider := newBlockingRateProvider(flightTestRateData(date), nil)
+	conv := NewConverter(provider, nil)
+
+	var wg sync.WaitGroup
+	var convertErr, ratesErr error
+	wg.Go(func() {
+		_, convertErr = conv.Convert(context.Background(), dec("100"), models.USD, date)
+	})
+	wg.Go(func() {
+		_, ratesErr = conv.GetRates(context.Background(), date)
+	})
+
+	waitForDups(t, conv.flight, normalizeDate(date), 1)
+	close(provider.release)
+	wg.Wait()
+
+	if convertErr != nil || ratesErr != nil {
+		t.Fatalf("Неожиданные ошибки: Convert=%v, GetRates=%v", convertErr, ratesErr)
+	}
+	if got := provider.calls.Load(); got != 1 {
+		t.Errorf("FetchRates вызван %d раз, ожидался 1", got)
+	}
+}
+
+func TestConverter_ConcurrentFetches_DifferentDates(t *testing.T) {
+	date := testPastDateUTC()
+	provider := newBlockingRateProvider(flightTestRateData(date), nil)
+	close(provider.release)
+	conv := NewConverter(provider, nil)
+
+	var wg sync.WaitGroup
+	for _, d := range []time.Time{date, date.AddDate(0, 0, -1)} {
+		wg.Go(func() {
+			if _, err := conv.GetRate(context.Background(), models.USD, d); err != nil {
+				t.Errorf("Неожиданная ошибка: %v", err)
+			}
+		})
+	}
+	wg.Wait()
+
+	if got := provider.calls.Load(); got != 2 {
+		t.Errorf("FetchRates вызван %d раз, ожидалось 2 (по одному на дату)", got)
+	}
+}
+
+func TestConverter_ConcurrentFetches_CancelledWaiter(t *testing.T) {
+	date := testPastDateUTC()
+	provider := newBlockingRateProvider(flightTestRateData(date), nil)
+	conv := NewConverter(provider, nil)
+
+	// Первый вызывающий запускает загрузку и ждет результата
+	var wg sync.WaitGroup
+	var firstErr error
+	wg.Go(func() {
+		_, firstErr = conv.GetRate(context.Background(), models.USD, date)
+	})
+
+	// Второй присоединяется и отменяет ожидание - загрузка для первого не прерывается
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := conv.GetRate(ctx, models.USD, date)
+		done <- err
+	}()
+	waitForDups(t, conv.flight, normalizeDate(date), 1)
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Отмененный вызывающий: ожидалась context.Canceled, получено %v", err)
+	}
+
+	close(provider.release)
+	wg.Wait()
+
+	if firstErr != nil {
+		t.Errorf("Первый вызывающий: неожиданная ошибка %v", firstErr)
+	}
+	if got := provider.calls.Load(); got != 1 {
+		t.Errorf("FetchRates вызван %d раз, ожидался 1", got)
+	}
+}
+
+func TestConverter_WithRoundingSharesFlight(t *testing.T) {
+	conv := NewConverter(&MockRateProvider{}, nil)
+	if conv.WithRounding(models.Rounding{Mode: models.RoundDown, Places: 0}).flight != conv.flight {
+		t.Error("Копия WithRounding должна объединять загрузки с исходным конвертером")
+	}
+}
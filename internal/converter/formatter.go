@@ -9,24 +9,92 @@ import (
 
 // FormatResult форматирует результат конвертации в читаемую строку
 // Формат: "80 722,00 руб. ($1000.00 по курсу 80,7220)"
+// Для валют без символа код ставится после суммы: "11 234,50 руб. (1 000,00 CNY по курсу 11,2345)"
 //
 // Пример использования:
 //
-//	formatted := FormatResult(1000.0, 80.7220, models.USD, 80722.0)
+//	formatted := FormatResult(
+//	    models.MustParseDecimal("1000"), models.MustParseDecimal("80.7220"),
+//	    models.USD, models.MustParseDecimal("80722.00"))
 //	// Результат: "80 722,00 руб. ($1 000,00 по курсу 80,7220)"
-func FormatResult(amount, rate float64, currency models.Currency, resultRUB float64) string {
+func FormatResult(amount, rate models.Decimal, currency models.Currency, resultRUB models.Decimal) string {
+	return formatResult(amount, rate, currency, resultRUB, AmountPlaces)
+}
+
+// formatResult - FormatResult с заданным количеством знаков в результате
+func formatResult(amount, rate models.Decimal, currency models.Currency, resultRUB models.Decimal, places int32) string {
 	// Форматирование с разделителями тысяч и запятой
-	resultStr := formatNumber(resultRUB)
-	amountStr := formatNumber(amount)
+	resultStr := formatNumberPlaces(resultRUB, places)
 
 	// Форматируем курс: 4 знака после запятой
-	rateStr := fmt.Sprintf("%.4f", rate)
-	rateStr = strings.ReplaceAll(rateStr, ".", ",")
+	rateStr := formatRate(rate)
 
-	symbol := currency.Symbol()
+	return fmt.Sprintf("%s руб. (%s по курсу %s)",
+		resultStr, formatCurrencyAmount(amount, currency), rateStr)
+}
 
-	return fmt.Sprintf("%s руб. (%s%s по курсу %s)",
-		resultStr, symbol, amountStr, rateStr)
+// FormatReverseResult форматирует результат обратной конвертации (рубли → валюта)
+// Формат: "$1 000,00 (80 722,00 руб. по курсу 80,7220)"
+//
+// Пример использования:
+//
+//	formatted := FormatReverseResult(
+//	    models.MustParseDecimal("80722"), models.MustParseDecimal("80.7220"),
+//	    models.USD, models.MustParseDecimal("1000.00"))
+//	// Результат: "$1 000,00 (80 722,00 руб. по курсу 80,7220)"
+func FormatReverseResult(amountRUB, rate models.Decimal, currency models.Currency, result models.Decimal) string {
+	return formatReverseResult(amountRUB, rate, currency, result, AmountPlaces)
+}
+
+// formatReverseResult - FormatReverseResult с заданным количеством знаков в результате
+func formatReverseResult(amountRUB, rate models.Decimal, currency models.Currency, result models.Decimal, places int32) string {
+	return fmt.Sprintf("%s (%s руб. по курсу %s)",
+		formatCurrencyAmountPlaces(result, currency, places), formatNumber(amountRUB), formatRate(rate))
+}
+
+// FormatCrossResult форматирует результат кросс-конвертации (валюта → валюта)
+// Формат: "€920,12 ($1 000,00 по кросс-курсу 0,9201; USD 80,7220 руб., EUR 87,7300 руб.)"
+//
+// Пример использования:
+//
+// Все значения выводятся после явного округления: суммы - до 2 знаков, курсы - до 4
+func FormatCrossResult(amount models.Decimal, from, to models.Currency, result, crossRate, fromRate, toRate models.Decimal) string {
+	return formatCrossResult(amount, from, to, result, crossRate, fromRate, toRate, AmountPlaces, models.RUB)
+}
+
+// formatCrossResult - FormatCrossResult с заданным количеством знаков в результате
+// base - валюта, в которой выражены курсы fromRate и toRate (у ЦБ РФ - рубль)
+func formatCrossResult(amount models.Decimal, from, to models.Currency, result, crossRate, fromRate, toRate models.Decimal, places int32, base models.Currency) string {
+	unit := string(base)
+	if base == models.RUB {
+		unit = "руб."
+	}
+	return fmt.Sprintf("%s (%s по кросс-курсу %s; %s %s %s, %s %s %s)",
+		formatCurrencyAmountPlaces(result, to, places), formatCurrencyAmount(amount, from), formatRate(crossRate),
+		from, formatRate(fromRate), unit, to, formatRate(toRate), unit)
+}
+
+// formatRate форматирует курс: 4 знака после запятой, запятая как десятичный разделитель
+func formatRate(rate models.Decimal) string {
+	return strings.ReplaceAll(rate.StringFixed(4), ".", ",")
+}
+
+// formatCurrencyAmount форматирует сумму в валюте с её символом
+// Примеры:
+//   - 1000, USD → "$1 000,00"
+//   - 1000, CNY → "1 000,00 CNY" (у валюты нет символа в справочнике)
+func formatCurrencyAmount(amount models.Decimal, currency models.Currency) string {
+	return formatCurrencyAmountPlaces(amount, currency, AmountPlaces)
+}
+
+// formatCurrencyAmountPlaces - formatCurrencyAmount с заданным количеством знаков
+func formatCurrencyAmountPlaces(amount models.Decimal, currency models.Currency, places int32) string {
+	amountStr := formatNumberPlaces(amount, places)
+	symbol := currency.Symbol()
+	if symbol == string(currency) {
+		return amountStr + " " + symbol
+	}
+	return symbol + amountStr
 }
 
 // formatNumber форматирует число с разделителями тысяч (пробел) и запятой
@@ -34,21 +102,27 @@ func FormatResult(amount, rate float64, currency models.Currency, resultRUB floa
 //   - 1000.5 → "1 000,50"
 //   - 80722.0 → "80 722,00"
 //   - 123456789.12 → "123 456 789,12"
-func formatNumber(num float64) string {
-	// Форматируем с 2 знаками после запятой
-	str := fmt.Sprintf("%.2f", num)
+//
+// Число округляется до 2 знаков явно (половина - от нуля), без промежуточного float64
+func formatNumber(num models.Decimal) string {
+	return formatNumberPlaces(num, AmountPlaces)
+}
 
-	// Заменяем точку на запятую
-	str = strings.ReplaceAll(str, ".", ",")
+// formatNumberPlaces форматирует число с places знаками после запятой
+// При places = 0 дробная часть не выводится: 80722 → "80 722"
+func formatNumberPlaces(num models.Decimal, places int32) string {
+	// Форматируем с places знаками после запятой
+	str := num.StringFixed(places)
 
 	// Разделение на целую и дробную части
-	parts := strings.Split(str, ",")
-	intPart := parts[0]
-	decPart := parts[1]
+	intPart, decPart, hasDec := strings.Cut(str, ".")
 
 	// Добавление разделителей тысяч
 	intPart = addThousandsSeparator(intPart)
 
+	if !hasDec {
+		return intPart
+	}
 	return intPart + "," + decPart
 }
 
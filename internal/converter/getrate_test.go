@@ -19,13 +19,13 @@ func TestConverter_GetRate(t *testing.T) {
 			Rates: map[models.Currency]models.ExchangeRate{
 				models.USD: {
 					Currency: models.USD,
-					Rate:     80.7220,
+					Rate:     dec("80.7220"),
 					Nominal:  1,
 					Date:     date,
 				},
 				models.EUR: {
 					Currency: models.EUR,
-					Rate:     88.1234,
+					Rate:     dec("88.1234"),
 					Nominal:  1,
 					Date:     date,
 				},
@@ -40,28 +40,28 @@ func TestConverter_GetRate(t *testing.T) {
 		name     string
 		currency models.Currency
 		date     time.Time
-		want     float64
+		want     string
 		wantErr  bool
 	}{
 		{
 			name:     "USD курс",
 			currency: models.USD,
 			date:     date,
-			want:     80.7220,
+			want:     "80.7220",
 			wantErr:  false,
 		},
 		{
 			name:     "EUR курс",
 			currency: models.EUR,
 			date:     date,
-			want:     88.1234,
+			want:     "88.1234",
 			wantErr:  false,
 		},
 		{
 			name:     "RUB всегда возвращает 1.0",
 			currency: models.RUB,
 			date:     date,
-			want:     1.0,
+			want:     "1.0",
 			wantErr:  false,
 		},
 	}
@@ -73,7 +73,7 @@ func TestConverter_GetRate(t *testing.T) {
 				t.Errorf("GetRate() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !tt.wantErr && got != tt.want {
+			if !tt.wantErr && !got.Equal(dec(tt.want)) {
 				t.Errorf("GetRate() = %v, want %v", got, tt.want)
 			}
 		})
@@ -90,7 +90,7 @@ func TestConverter_GetRate_ValidationErrors(t *testing.T) {
 			Rates: map[models.Currency]models.ExchangeRate{
 				models.USD: {
 					Currency: models.USD,
-					Rate:     80.7220,
+					Rate:     dec("80.7220"),
 					Nominal:  1,
 					Date:     date,
 				},
@@ -110,7 +110,7 @@ func TestConverter_GetRate_ValidationErrors(t *testing.T) {
 	}{
 		{
 			name:     "Неподдерживаемая валюта",
-			currency: models.Currency("GBP"),
+			currency: models.Currency("XYZ"),
 			date:     date,
 			wantErr:  models.ErrUnsupportedCurrency,
 		},
@@ -146,7 +146,7 @@ func TestConverter_GetRate_UsesCache(t *testing.T) {
 			Rates: map[models.Currency]models.ExchangeRate{
 				models.USD: {
 					Currency: models.USD,
-					Rate:     80.7220,
+					Rate:     dec("80.7220"),
 					Nominal:  1,
 					Date:     date,
 				},
@@ -184,3 +184,144 @@ func TestConverter_GetRate_UsesCache(t *testing.T) {
 		t.Errorf("Курсы не совпадают: rate1 = %v, rate2 = %v", rate1, rate2)
 	}
 }
+
+// TestConverter_GetRates тестирует получение всех курсов на дату и заполнение кэша
+func TestConverter_GetRateInBase(t *testing.T) {
+	date := time.Date(2025, 12, 19, 0, 0, 0, 0, time.UTC)
+	ecbData := models.NewRateData(date)
+	ecbData.Base = models.EUR
+	ecbData.AddRate(models.ExchangeRate{Currency: models.USD, Rate: dec("0.8531"), Nominal: 1, Date: date})
+	ecbData.AddRate(models.ExchangeRate{Currency: "JPY", Rate: dec("0.5432"), Nominal: 100, Date: date})
+	conv := NewConverter(&MockRateProvider{rateData: ecbData}, NewMockCache())
+
+	tests := []struct {
+		name     string
+		currency models.Currency
+		want     string
+		wantErr  error
+	}{
+		{name: "Курс в евро", currency: models.USD, want: "0.8531"},
+		{name: "Курс за единицу валюты", currency: "JPY", want: "0.005432"},
+		{name: "Базовая валюта", currency: models.EUR, want: "1"},
+		{name: "Рублевого курса нет", currency: "CNY", wantErr: ErrRateNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote, err := conv.GetRateInBase(context.Background(), tt.currency, date)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetRateInBase() error = %v, ожидалась %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetRateInBase() error = %v", err)
+			}
+			if !quote.Rate.Equal(dec(tt.want)) || quote.Base != models.EUR {
+				t.Errorf("GetRateInBase() = %s %s, ожидалось %s EUR", quote.Rate, quote.Base, tt.want)
+			}
+			if !quote.Date.Equal(date) {
+				t.Errorf("GetRateInBase() дата = %s, ожидалась %s", quote.Date.Format("02.01.2006"), date.Format("02.01.2006"))
+			}
+		})
+	}
+}
+
+func TestConverter_GetRates(t *testing.T) {
+	requested := time.Date(2025, 12, 21, 0, 0, 0, 0, time.UTC) // Воскресенье
+	actual := time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC)
+
+	mockProvider := &MockRateProvider{
+		rateData: &models.RateData{
+			Date: actual,
+			Rates: map[models.Currency]models.ExchangeRate{
+				models.USD: {Currency: models.USD, Rate: dec("80.7220"), Nominal: 1, Date: actual},
+				"JPY":      {Currency: "JPY", Rate: dec("51.2345"), Nominal: 100, Date: actual},
+			},
+		},
+	}
+	cache := NewMockCache()
+	converter := NewConverter(mockProvider, cache)
+
+	rateData, err := converter.GetRates(context.Background(), requested)
+	if err != nil {
+		t.Fatalf("GetRates() error = %v", err)
+	}
+	if len(rateData.Rates) != 2 || !rateData.Date.Equal(actual) {
+		t.Errorf("GetRates() = %d курсов на %v, ожидается 2 на %v", len(rateData.Rates), rateData.Date, actual)
+	}
+	// Курсы возвращаются как в XML ЦБ РФ - за номинал
+	if jpy := rateData.Rates["JPY"]; !jpy.Rate.Equal(dec("51.2345")) || jpy.Nominal != 100 {
+		t.Errorf("JPY = %s за %d, ожидается 51.2345 за 100", jpy.Rate, jpy.Nominal)
+	}
+
+	// В кэше - курсы за единицу по запрошенной и фактической дате
+	for _, date := range []time.Time{requested, actual} {
+		rate, cachedDate, found := cachedRate(cache, "JPY", date)
+		if !found || !rate.Equal(dec("0.512345")) || !cachedDate.Equal(actual) {
+			t.Errorf("cache.Get(JPY, %v) = %s, %v, %v; ожидается 0.512345, %v, true", date, rate, cachedDate, found, actual)
+		}
+	}
+
+	if _, err := converter.GetRate(context.Background(), models.USD, requested); err != nil {
+		t.Fatalf("GetRate() error = %v", err)
+	}
+	if mockProvider.callCount != 1 {
+		t.Errorf("FetchRates вызван %d раз, ожидается 1 (GetRate из кэша)", mockProvider.callCount)
+	}
+}
+
+// TestConverter_GetRates_Errors тестирует ошибки GetRates
+func TestConverter_GetRates_Errors(t *testing.T) {
+	date := time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC)
+	providerErr := errors.New("сеть недоступна")
+
+	tests := []struct {
+		name     string
+		provider RateProvider
+		date     time.Time
+		wantErr  error
+	}{
+		{"Дата в будущем", &MockRateProvider{}, time.Now().AddDate(0, 0, 1), ErrDateInFuture},
+		{"Источник не задан", nil, date, ErrNilRateProvider},
+		{"Ошибка источника", &MockRateProvider{err: providerErr}, date, providerErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewConverter(tt.provider, nil).GetRates(context.Background(), tt.date)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetRates() error = %v, ожидается %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConverter_WithCurrencies(t *testing.T) {
+	date := time.Date(2025, 12, 19, 0, 0, 0, 0, time.UTC)
+	ecbData := models.NewRateData(date)
+	ecbData.Base = models.EUR
+	ecbData.AddRate(models.ExchangeRate{Currency: "ISK", Rate: dec("0.6839"), Nominal: 100, Date: date})
+	provider := &MockRateProvider{rateData: ecbData}
+	ecbCurrencies := models.NewRegistry(models.CurrencyInfo{Code: models.EUR}, models.CurrencyInfo{Code: "ISK"})
+
+	// Справочник ЦБ РФ: исландской кроны в нем нет, запроса к источнику нет
+	if _, err := NewConverter(provider, NewMockCache()).GetRateInBase(context.Background(), "ISK", date); !errors.Is(err, models.ErrUnsupportedCurrency) {
+		t.Errorf("GetRateInBase(ISK) по справочнику ЦБ РФ: %v, ожидалась ErrUnsupportedCurrency", err)
+	}
+	if provider.callCount != 0 {
+		t.Errorf("Запросов к источнику: %d, ожидалось 0", provider.callCount)
+	}
+
+	conv := NewConverter(provider, NewMockCache()).WithCurrencies(ecbCurrencies)
+	if quote, err := conv.GetRateInBase(context.Background(), "ISK", date); err != nil || !quote.Rate.Equal(dec("0.006839")) {
+		t.Errorf("GetRateInBase(ISK) = %s, %v; ожидалось 0.006839", quote.Rate, err)
+	}
+	if _, err := conv.ParseCurrency("usd"); !errors.Is(err, models.ErrUnsupportedCurrency) {
+		t.Errorf("ParseCurrency(usd) по справочнику источника: %v, ожидалась ErrUnsupportedCurrency", err)
+	}
+	if _, err := conv.ConvertCross(context.Background(), dec("100"), "ISK", models.RUB, date); !errors.Is(err, models.ErrUnsupportedCurrency) {
+		t.Errorf("ConvertCross(ISK, RUB) = %v, ожидалась ErrUnsupportedCurrency", err)
+	}
+}
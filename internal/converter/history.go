@@ -0,0 +1,104 @@
+package converter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+const (
+	// MaxHistoryDays - максимальная длина периода для динамики и истории курса (в днях)
+	// Каждый день периода занимает запись в кэше, поэтому период ограничен годом
+	MaxHistoryDays = 366
+
+	// changePercentPlaces - знаков после запятой в изменении курса в процентах
+	changePercentPlaces = 2
+)
+
+// Ошибки получения динамики и истории курса
+var (
+	ErrPeriodTooLong = fmt.Errorf("период не может превышать %d дней", MaxHistoryDays)
+)
+
+// RateHistoryPoint - курс на одну дату установления ЦБ РФ
+type RateHistoryPoint struct {
+	Date          time.Time      // Дата установления курса
+	Rate          models.Decimal // Рублей за единицу валюты
+	Change        models.Decimal // Изменение к предыдущей точке (для первой - 0)
+	ChangePercent models.Decimal // Изменение в процентах, округлено до 2 знаков
+}
+
+// RateHistory - история курса валюты за период со сводной статистикой
+type RateHistory struct {
+	Currency models.Currency
+	From     time.Time
+	To       time.Time
+	Points   []RateHistoryPoint // По возрастанию даты
+	Min      models.Decimal     // Минимальный курс за период
+	Max      models.Decimal     // Максимальный курс за период
+	Average  models.Decimal     // Средний курс по точкам, округлен до 4 знаков
+}
+
+// GetRateHistory возвращает историю курса валюты за период для графика
+// Точки строятся по GetRateSeries (один запрос к ЦБ РФ, кэш заполняется на каждый день периода),
+// курсы приведены к единице валюты, как в GetRate
+//
+// Пример использования:
+//
+//	history, err := converter.GetRateHistory(ctx, models.USD, from, to)
+//	if err != nil {
+//	    return err
+//	}
+//	fmt.Println(history.Min, history.Max, history.Average)
+func (c *Converter) GetRateHistory(ctx context.Context, currency models.Currency, from, to time.Time) (*RateHistory, error) {
+	from, to = normalizeDate(from), normalizeDate(to)
+
+	series, err := c.GetRateSeries(ctx, currency, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(series.Rates) == 0 {
+		return nil, fmt.Errorf("%w: %s за период", ErrRateNotFound, currency)
+	}
+
+	history := &RateHistory{
+		Currency: currency,
+		From:     from,
+		To:       to,
+		Points:   make([]RateHistoryPoint, 0, len(series.Rates)),
+	}
+
+	sum := models.NewDecimalFromInt(0)
+	for i, rate := range series.Rates {
+		point := RateHistoryPoint{
+			Date:          normalizeDate(rate.Date),
+			Rate:          UnitRate(rate),
+			Change:        models.NewDecimalFromInt(0),
+			ChangePercent: models.NewDecimalFromInt(0),
+		}
+		if i > 0 {
+			prev := history.Points[i-1].Rate
+			point.Change = point.Rate.Sub(prev)
+			if !prev.IsZero() {
+				point.ChangePercent = point.Change.Mul(models.NewDecimalFromInt(100)).
+					DivRound(prev, changePercentPlaces, models.RoundHalfUp)
+			}
+		}
+
+		if i == 0 || point.Rate.Cmp(history.Min) < 0 {
+			history.Min = point.Rate
+		}
+		if i == 0 || point.Rate.Cmp(history.Max) > 0 {
+			history.Max = point.Rate
+		}
+		sum = sum.Add(point.Rate)
+
+		history.Points = append(history.Points, point)
+	}
+
+	history.Average = sum.DivRound(models.NewDecimalFromInt(int64(len(history.Points))), 4, models.RoundHalfUp)
+
+	return history, nil
+}
@@ -0,0 +1,101 @@
+package converter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+func TestConverter_GetRateHistory(t *testing.T) {
+	seriesProvider := &MockRateSeriesProvider{series: testRateSeries()}
+	conv := NewConverter(nil, NewMockCache()).WithSeriesProvider(seriesProvider)
+
+	history, err := conv.GetRateHistory(context.Background(), models.USD, seriesDay(1), seriesDay(8))
+	if err != nil {
+		t.Fatalf("GetRateHistory() error = %v", err)
+	}
+
+	want := []struct {
+		day                   int
+		rate, change, percent string
+	}{
+		{2, "78.2284", "0", "0"},
+		{3, "78.5000", "0.2716", "0.35"},
+		{6, "79.1000", "0.6000", "0.76"},
+	}
+	if len(history.Points) != len(want) {
+		t.Fatalf("len(Points) = %d, ожидается %d", len(history.Points), len(want))
+	}
+	for i, w := range want {
+		p := history.Points[i]
+		if !p.Date.Equal(seriesDay(w.day)) {
+			t.Errorf("Points[%d].Date = %v, ожидается %v", i, p.Date, seriesDay(w.day))
+		}
+		if !p.Rate.Equal(dec(w.rate)) || !p.Change.Equal(dec(w.change)) || !p.ChangePercent.Equal(dec(w.percent)) {
+			t.Errorf("Points[%d] = %s (%s, %s%%), ожидается %s (%s, %s%%)",
+				i, p.Rate, p.Change, p.ChangePercent, w.rate, w.change, w.percent)
+		}
+	}
+
+	if !history.Min.Equal(dec("78.2284")) {
+		t.Errorf("Min = %s, ожидается 78.2284", history.Min)
+	}
+	if !history.Max.Equal(dec("79.1")) {
+		t.Errorf("Max = %s, ожидается 79.1", history.Max)
+	}
+	// (78.2284 + 78.5 + 79.1) / 3 = 78.60946... → 78.6095
+	if !history.Average.Equal(dec("78.6095")) {
+		t.Errorf("Average = %s, ожидается 78.6095", history.Average)
+	}
+}
+
+func TestConverter_GetRateHistory_NominalPerUnit(t *testing.T) {
+	jpy := models.Currency("JPY")
+	seriesProvider := &MockRateSeriesProvider{series: &models.RateSeries{
+		Currency: jpy,
+		Rates: []models.ExchangeRate{
+			{Currency: jpy, Rate: dec("50.0000"), Nominal: 100, Date: seriesDay(2)},
+			{Currency: jpy, Rate: dec("49.0000"), Nominal: 100, Date: seriesDay(3)},
+		},
+	}}
+	conv := NewConverter(nil, NewMockCache()).WithSeriesProvider(seriesProvider)
+
+	history, err := conv.GetRateHistory(context.Background(), jpy, seriesDay(1), seriesDay(3))
+	if err != nil {
+		t.Fatalf("GetRateHistory() error = %v", err)
+	}
+	if !history.Points[1].Rate.Equal(dec("0.49")) {
+		t.Errorf("Курс = %s, ожидается 0.49 (за 1 единицу)", history.Points[1].Rate)
+	}
+	if !history.Points[1].ChangePercent.Equal(dec("-2")) {
+		t.Errorf("ChangePercent = %s, ожидается -2", history.Points[1].ChangePercent)
+	}
+}
+
+func TestConverter_GetRateHistory_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider *MockRateSeriesProvider
+		from     time.Time
+		wantErr  error
+	}{
+		{"Период больше года", &MockRateSeriesProvider{}, seriesDay(1).AddDate(-1, 0, -1), ErrPeriodTooLong},
+		{"Нет курсов за период", &MockRateSeriesProvider{series: &models.RateSeries{Currency: models.USD}}, seriesDay(1), ErrRateNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conv := NewConverter(nil, NewMockCache()).WithSeriesProvider(tt.provider)
+			_, err := conv.GetRateHistory(context.Background(), models.USD, tt.from, seriesDay(8))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetRateHistory() error = %v, ожидается %v", err, tt.wantErr)
+			}
+			if tt.wantErr == ErrPeriodTooLong && tt.provider.callCount != 0 {
+				t.Error("Для слишком длинного периода не должно быть запроса к ЦБ РФ")
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package converter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+// KeyRateProvider - интерфейс для получения истории ключевой ставки ЦБ РФ
+//
+// Контракт: возвращаются ставки на рабочие дни периода from..to включительно
+// (в выходные действует ставка последнего рабочего дня)
+type KeyRateProvider interface {
+	// FetchKeyRates получает ключевую ставку за период
+	FetchKeyRates(ctx context.Context, from, to time.Time) ([]models.KeyRate, error)
+}
+
+// FetchKeyRatesFunc адаптирует функцию к интерфейсу KeyRateProvider
+type FetchKeyRatesFunc func(ctx context.Context, from, to time.Time) ([]models.KeyRate, error)
+
+// FetchKeyRates реализует интерфейс KeyRateProvider
+func (f FetchKeyRatesFunc) FetchKeyRates(ctx context.Context, from, to time.Time) ([]models.KeyRate, error) {
+	return f(ctx, from, to)
+}
+
+// Ошибки получения ключевой ставки
+var (
+	ErrNilKeyRateProvider = errors.New("источник ключевой ставки не задан")
+	ErrKeyRateNotFound    = errors.New("ключевая ставка не установлена на указанную дату")
+)
+
+// keyRateLookbackDays - запас до начала периода: ставка на первые дни периода
+// (выходные, праздники) - ставка последнего рабочего дня перед ним
+const keyRateLookbackDays = 14
+
+// KeyRateCurrentTTL - время жизни в кэше KeyRates ставки на сегодня
+// Ставка на прошедшие даты не меняется и хранится бессрочно
+const KeyRateCurrentTTL = time.Hour
+
+// keyRateNow возвращает текущее время (подменяется в тестах)
+var keyRateNow = time.Now
+
+// keyRateEntry - ставка на календарный день в кэше KeyRates
+type keyRateEntry struct {
+	rate      models.Decimal
+	expiresAt time.Time // Нулевое значение - бессрочно
+}
+
+// KeyRates получает ключевую ставку ЦБ РФ и кэширует её на каждый календарный день
+// Кэш собственный (дата -> ставка), отдельный от снимков курсов: ставка на прошедшие даты
+// хранится бессрочно, на сегодня - KeyRateCurrentTTL. Запись - одно число на день, поэтому
+// даже ставки на все дни с введения ключевой ставки в 2013 году занимают немного памяти
+// Безопасен для конкурентного использования
+//
+// Пример использования:
+//
+//	keyRates := converter.NewKeyRates(parser.NewSOAPClient(parser.CBRSOAPURL))
+//	series, err := keyRates.KeyRateSeries(ctx, from, to)
+type KeyRates struct {
+	provider KeyRateProvider
+
+	mu   sync.RWMutex
+	days map[string]keyRateEntry // Ключ - календарный день "2025-12-20"
+}
+
+// NewKeyRates создает источник ключевой ставки с кэшем по дням
+func NewKeyRates(provider KeyRateProvider) *KeyRates {
+	return &KeyRates{provider: provider, days: make(map[string]keyRateEntry)}
+}
+
+// KeyRate возвращает ключевую ставку, действующую на дату
+func (k *KeyRates) KeyRate(ctx context.Context, date time.Time) (models.KeyRate, error) {
+	day := normalizeDate(date)
+	if err := ValidateDate(day); err != nil {
+		return models.KeyRate{}, err
+	}
+
+	if rate, found := k.cached(day); found {
+		return rate, nil
+	}
+
+	series, err := k.fetch(ctx, day, day)
+	if err != nil {
+		return models.KeyRate{}, err
+	}
+	return series.Rates[0], nil
+}
+
+// KeyRateSeries возвращает ключевую ставку на каждый календарный день периода
+// Если ставки всех дней есть в кэше, провайдер не опрашивается; иначе период загружается
+// одним запросом и кэшируется по дням: последующие KeyRate на даты периода берутся из кэша
+// Период длиннее MaxHistoryDays отклоняется с ErrPeriodTooLong до запроса к источнику
+func (k *KeyRates) KeyRateSeries(ctx context.Context, from, to time.Time) (*models.KeyRateSeries, error) {
+	from, to = normalizeDate(from), normalizeDate(to)
+	if err := ValidatePeriod(from, to); err != nil {
+		return nil, err
+	}
+	if to.After(from.AddDate(0, 0, MaxHistoryDays)) {
+		return nil, ErrPeriodTooLong
+	}
+
+	series := &models.KeyRateSeries{From: from, To: to}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		rate, found := k.cached(day)
+		if !found {
+			return k.fetch(ctx, from, to)
+		}
+		series.Rates = append(series.Rates, rate)
+	}
+	return series, nil
+}
+
+// cached возвращает ставку на день из кэша
+func (k *KeyRates) cached(day time.Time) (models.KeyRate, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	entry, exists := k.days[day.Format("2006-01-02")]
+	if !exists || (!entry.expiresAt.IsZero() && keyRateNow().After(entry.expiresAt)) {
+		return models.KeyRate{}, false
+	}
+	return models.KeyRate{Date: day, Rate: entry.rate}, true
+}
+
+// store сохраняет в кэш ставки на календарные дни
+// Ставка на сегодня (и позже) живет KeyRateCurrentTTL: ЦБ РФ может изменить её в течение дня
+func (k *KeyRates) store(rates []models.KeyRate) {
+	now := keyRateNow()
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	for _, rate := range rates {
+		entry := keyRateEntry{rate: rate.Rate}
+		if today := now.In(rate.Date.Location()).Format("2006-01-02"); rate.Date.Format("2006-01-02") >= today {
+			entry.expiresAt = now.Add(KeyRateCurrentTTL)
+		}
+		k.days[rate.Date.Format("2006-01-02")] = entry
+	}
+}
+
+// fetch загружает ставки за период и сохраняет в кэш ставку на каждый календарный день
+// (в выходные и праздники - ставку последнего рабочего дня)
+func (k *KeyRates) fetch(ctx context.Context, from, to time.Time) (*models.KeyRateSeries, error) {
+	if k.provider == nil {
+		return nil, ErrNilKeyRateProvider
+	}
+
+	records, err := k.provider.FetchKeyRates(ctx, from.AddDate(0, 0, -keyRateLookbackDays), to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch key rates: %w", err)
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Date.Before(records[j].Date)
+	})
+
+	series := &models.KeyRateSeries{From: from, To: to}
+	var current *models.KeyRate
+	next := 0
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		for next < len(records) && !normalizeDate(records[next].Date).After(day) {
+			current = &records[next]
+			next++
+		}
+		if current == nil {
+			// Ставка до первой записи неизвестна (например, до введения ключевой ставки в 2013 году)
+			continue
+		}
+
+		series.Rates = append(series.Rates, models.KeyRate{Date: day, Rate: current.Rate})
+	}
+
+	k.store(series.Rates)
+
+	if len(series.Rates) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrKeyRateNotFound, to.Format("02.01.2006"))
+	}
+	return series, nil
+}
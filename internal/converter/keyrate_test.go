@@ -0,0 +1,139 @@
+package converter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+// mockKeyRateProvider - мок для KeyRateProvider
+type mockKeyRateProvider struct {
+	rates     []models.KeyRate
+	err       error
+	callCount int
+	from, to  time.Time // Период последнего запроса
+}
+
+func (m *mockKeyRateProvider) FetchKeyRates(_ context.Context, from, to time.Time) ([]models.KeyRate, error) {
+	m.callCount++
+	m.from, m.to = from, to
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.rates, nil
+}
+
+func TestKeyRates_KeyRateSeries(t *testing.T) {
+	// Пятница перед периодом, смена ставки во вторник
+	friday := time.Date(2025, 12, 19, 0, 0, 0, 0, time.UTC)
+	monday := friday.AddDate(0, 0, 3)
+	tuesday := friday.AddDate(0, 0, 4)
+	provider := &mockKeyRateProvider{rates: []models.KeyRate{
+		{Date: tuesday, Rate: dec("16.00")},
+		{Date: monday, Rate: dec("16.50")},
+		{Date: friday, Rate: dec("16.50")},
+	}}
+	keyRates := NewKeyRates(provider)
+
+	sunday := friday.AddDate(0, 0, 2)
+	wednesday := friday.AddDate(0, 0, 5)
+	series, err := keyRates.KeyRateSeries(context.Background(), sunday, wednesday)
+	if err != nil {
+		t.Fatalf("KeyRateSeries() error = %v", err)
+	}
+
+	// Запрос с запасом до начала периода: ставка на воскресенье - пятничная
+	if !provider.from.Equal(sunday.AddDate(0, 0, -keyRateLookbackDays)) || !provider.to.Equal(wednesday) {
+		t.Errorf("Запрошен период %v - %v", provider.from, provider.to)
+	}
+	want := []string{"16.50", "16.50", "16.00", "16.00"}
+	if len(series.Rates) != len(want) {
+		t.Fatalf("Получено %d дней, ожидалось %d", len(series.Rates), len(want))
+	}
+	for i, w := range want {
+		day := sunday.AddDate(0, 0, i)
+		if got := series.Rates[i]; !got.Date.Equal(day) || !got.Rate.Equal(dec(w)) {
+			t.Errorf("День %d = %+v, ожидалось %s на %v", i, got, w, day)
+		}
+	}
+
+	// Ставки периода в кэше: повторные запросы не обращаются к провайдеру
+	if _, err := keyRates.KeyRateSeries(context.Background(), sunday, wednesday); err != nil {
+		t.Fatalf("KeyRateSeries() из кэша error = %v", err)
+	}
+	rate, err := keyRates.KeyRate(context.Background(), tuesday)
+	if err != nil {
+		t.Fatalf("KeyRate() error = %v", err)
+	}
+	if !rate.Rate.Equal(dec("16.00")) || !rate.Date.Equal(tuesday) {
+		t.Errorf("KeyRate() = %+v, ожидалось 16.00 на %v", rate, tuesday)
+	}
+	if provider.callCount != 1 {
+		t.Errorf("FetchKeyRates вызван %d раз, ожидался 1", provider.callCount)
+	}
+}
+
+func TestKeyRates_Errors(t *testing.T) {
+	date := testPastDateUTC()
+	providerErr := errors.New("soap fault")
+
+	tests := []struct {
+		name     string
+		keyRates *KeyRates
+		date     time.Time
+		wantErr  error
+	}{
+		{"Дата в будущем", NewKeyRates(&mockKeyRateProvider{}), time.Now().AddDate(0, 0, 2), ErrDateInFuture},
+		{"Провайдер не задан", NewKeyRates(nil), date, ErrNilKeyRateProvider},
+		{"Ошибка провайдера", NewKeyRates(&mockKeyRateProvider{err: providerErr}), date, providerErr},
+		{"Ставка не установлена", NewKeyRates(&mockKeyRateProvider{}), date, ErrKeyRateNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.keyRates.KeyRate(context.Background(), tt.date); !errors.Is(err, tt.wantErr) {
+				t.Errorf("KeyRate() error = %v, ожидалась %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := NewKeyRates(&mockKeyRateProvider{}).KeyRateSeries(context.Background(), date, date.AddDate(0, 0, -1)); !errors.Is(err, ErrInvalidPeriod) {
+		t.Errorf("KeyRateSeries() error = %v, ожидалась ErrInvalidPeriod", err)
+	}
+
+	// Период длиннее MaxHistoryDays отклоняется до запроса к источнику
+	provider := &mockKeyRateProvider{}
+	if _, err := NewKeyRates(provider).KeyRateSeries(context.Background(), date.AddDate(0, 0, -MaxHistoryDays-1), date); !errors.Is(err, ErrPeriodTooLong) {
+		t.Errorf("KeyRateSeries() error = %v, ожидалась ErrPeriodTooLong", err)
+	}
+	if provider.callCount != 0 {
+		t.Errorf("FetchKeyRates вызван %d раз для слишком длинного периода", provider.callCount)
+	}
+}
+
+func TestKeyRates_CurrentRateExpires(t *testing.T) {
+	now := time.Now()
+	original := keyRateNow
+	keyRateNow = func() time.Time { return now }
+	t.Cleanup(func() { keyRateNow = original })
+
+	today := normalizeDate(now)
+	yesterday := today.AddDate(0, 0, -1)
+	provider := &mockKeyRateProvider{rates: []models.KeyRate{{Date: yesterday, Rate: dec("16.50")}}}
+	keyRates := NewKeyRates(provider)
+	if _, err := keyRates.KeyRateSeries(context.Background(), yesterday, today); err != nil {
+		t.Fatalf("KeyRateSeries() error = %v", err)
+	}
+
+	// Через KeyRateCurrentTTL ставка на сегодня загружается заново, на прошедшую дату - из кэша
+	now = now.Add(KeyRateCurrentTTL + time.Minute)
+	if _, err := keyRates.KeyRate(context.Background(), yesterday); err != nil || provider.callCount != 1 {
+		t.Errorf("KeyRate(вчера) error = %v, запросов %d; ожидалась ставка из кэша", err, provider.callCount)
+	}
+	if _, err := keyRates.KeyRate(context.Background(), today); err != nil || provider.callCount != 2 {
+		t.Errorf("KeyRate(сегодня) error = %v, запросов %d; ожидалась повторная загрузка", err, provider.callCount)
+	}
+}
@@ -0,0 +1,85 @@
+package converter
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+// MergeProvider - составной RateProvider: курсы основного источника дополняются
+// курсами дополнительных (например, учетными ценами драгоценных металлов)
+// Снимок остается одним: валюты и металлы на дату кэшируются вместе, и кросс-конвертация
+// между ними берет оба курса из одного снимка
+// Если дополнительный источник недоступен, снимок возвращается с Partial = true:
+// отсутствующие курсы будут запрошены повторно при следующем обращении к ним
+//
+// Пример использования:
+//
+//	provider := converter.NewMergeProvider(chain, converter.FetchRatesFunc(parser.FetchMetalRates))
+//	conv := converter.NewConverter(provider, cacheStorage)
+type MergeProvider struct {
+	primary RateProvider
+	extras  []RateProvider
+}
+
+// NewMergeProvider создает составной источник курсов
+// Паникует, если primary или один из extras не задан (ошибка конфигурации)
+func NewMergeProvider(primary RateProvider, extras ...RateProvider) *MergeProvider {
+	if primary == nil {
+		panic("converter: NewMergeProvider без основного источника")
+	}
+	for _, extra := range extras {
+		if extra == nil {
+			panic("converter: NewMergeProvider с пустым дополнительным источником")
+		}
+	}
+	return &MergeProvider{primary: primary, extras: append([]RateProvider(nil), extras...)}
+}
+
+// FetchRates реализует интерфейс RateProvider
+// Ошибка основного источника возвращается как есть; дата, база и источник снимка берутся из него
+// Курсы дополнительных источников не заменяют курсы основного и сохраняют свою дату
+func (p *MergeProvider) FetchRates(ctx context.Context, date time.Time) (*models.RateData, error) {
+	return p.FetchRatesConditional(ctx, date, nil)
+}
+
+// FetchRatesConditional реализует интерфейс ConditionalRateProvider
+// Снимок cached передается основному источнику; его models.ErrNotModified возвращается как есть
+func (p *MergeProvider) FetchRatesConditional(ctx context.Context, date time.Time, cached *models.RateData) (*models.RateData, error) {
+	primary, err := fetchConditional(ctx, p.primary, date, cached)
+	if err != nil {
+		return nil, err
+	}
+	if primary == nil {
+		return nil, errors.New("rate provider returned nil data")
+	}
+
+	// Снимок источника может храниться у него (например, в тестах) - не изменяем его
+	merged := *primary
+	merged.Rates = make(map[models.Currency]models.ExchangeRate, len(primary.Rates))
+	for currency, rate := range primary.Rates {
+		merged.Rates[currency] = rate
+	}
+
+	for _, extra := range p.extras {
+		extraData, err := extra.FetchRates(ctx, date)
+		if err == nil && extraData == nil {
+			err = errors.New("rate provider returned nil data")
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			merged.Partial = true
+			continue
+		}
+		for currency, rate := range extraData.Rates {
+			if _, exists := merged.Rates[currency]; !exists {
+				merged.Rates[currency] = rate
+			}
+		}
+	}
+	return &merged, nil
+}
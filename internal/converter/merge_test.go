@@ -0,0 +1,100 @@
+package converter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+func TestMergeProvider_FetchRates(t *testing.T) {
+	date := testPastDateUTC()
+	metalDate := date.AddDate(0, 0, -1)
+
+	currencies := &MockRateProvider{rateData: rateSnapshot(date, models.USD, "80.0000")}
+	currencies.rateData.Source = "cbr-xml"
+	metals := &MockRateProvider{rateData: rateSnapshot(metalDate, models.XAU, "10712.04")}
+	metals.rateData.AddRate(models.ExchangeRate{Currency: models.USD, Rate: dec("1"), Nominal: 1, Date: metalDate})
+
+	rateData, err := NewMergeProvider(currencies, metals).FetchRates(context.Background(), date)
+	if err != nil {
+		t.Fatalf("FetchRates() error = %v", err)
+	}
+
+	// Дата и источник - основного источника, цена металла - со своей датой
+	if !rateData.Date.Equal(date) || rateData.Source != "cbr-xml" || rateData.Partial {
+		t.Errorf("Снимок: дата %v, Source %q, Partial %v", rateData.Date, rateData.Source, rateData.Partial)
+	}
+	if gold := rateData.Rates[models.XAU]; !gold.Rate.Equal(dec("10712.04")) || !gold.Date.Equal(metalDate) {
+		t.Errorf("XAU = %+v, ожидалось 10712.04 на %v", gold, metalDate)
+	}
+	if usd := rateData.Rates[models.USD]; !usd.Rate.Equal(dec("80.0000")) {
+		t.Errorf("USD = %s, курс основного источника не должен заменяться", usd.Rate)
+	}
+	if len(currencies.rateData.Rates) != 1 {
+		t.Error("Снимок основного источника не должен изменяться")
+	}
+}
+
+func TestMergeProvider_Errors(t *testing.T) {
+	date := testPastDateUTC()
+	primaryErr := errors.New("cbr.ru unreachable")
+
+	// Ошибка основного источника возвращается, дополнительные не опрашиваются
+	metals := &MockRateProvider{rateData: rateSnapshot(date, models.XAU, "10712.04")}
+	if _, err := NewMergeProvider(&MockRateProvider{err: primaryErr}, metals).FetchRates(context.Background(), date); !errors.Is(err, primaryErr) {
+		t.Errorf("FetchRates() error = %v, ожидалась %v", err, primaryErr)
+	}
+	if metals.callCount != 0 {
+		t.Errorf("Дополнительный источник опрошен %d раз, ожидалось 0", metals.callCount)
+	}
+
+	// Недоступный дополнительный источник - неполный снимок
+	currencies := &MockRateProvider{rateData: rateSnapshot(date, models.USD, "80.0000")}
+	rateData, err := NewMergeProvider(currencies, &MockRateProvider{err: errors.New("timeout")}).FetchRates(context.Background(), date)
+	if err != nil {
+		t.Fatalf("FetchRates() error = %v", err)
+	}
+	if !rateData.Partial || len(rateData.Rates) != 1 {
+		t.Errorf("Partial = %v, курсов %d; ожидалось true и 1", rateData.Partial, len(rateData.Rates))
+	}
+}
+
+func TestConverter_Convert_Metal(t *testing.T) {
+	date := testPastDateUTC()
+	metals := &MockRateProvider{err: errors.New("timeout")}
+	provider := NewMergeProvider(&MockRateProvider{rateData: rateSnapshot(date, models.USD, "80.0000")}, metals)
+	converter := NewConverter(provider, NewMockCache())
+
+	// Цены металлов недоступны - курс валюты из неполного снимка, металл не найден
+	if _, err := converter.Convert(context.Background(), dec("10"), models.USD, date); err != nil {
+		t.Fatalf("Convert(USD) error = %v", err)
+	}
+	if _, err := converter.Convert(context.Background(), dec("10"), models.XAU, date); !errors.Is(err, ErrRateNotFound) {
+		t.Fatalf("Convert(XAU) error = %v, ожидалась ErrRateNotFound", err)
+	}
+
+	// Цены появились: неполный снимок в кэше загружается заново
+	metals.err = nil
+	metals.rateData = rateSnapshot(date, models.XAU, "10712.04")
+	result, err := converter.Convert(context.Background(), dec("10"), models.XAU, date)
+	if err != nil {
+		t.Fatalf("Convert(XAU) error = %v", err)
+	}
+	if !result.TargetAmount.Equal(dec("107120.40")) {
+		t.Errorf("TargetAmount = %v, ожидается 107120.40", result.TargetAmount)
+	}
+	if result.FormattedStr != "107 120,40 руб. (10,00 XAU по курсу 10712,0400)" {
+		t.Errorf("FormattedStr = %q", result.FormattedStr)
+	}
+
+	// Граммы золота в доллары - оба курса из одного снимка
+	cross, err := converter.ConvertCross(context.Background(), dec("1"), models.XAU, models.USD, date)
+	if err != nil {
+		t.Fatalf("ConvertCross() error = %v", err)
+	}
+	if !cross.TargetAmount.Equal(dec("133.90")) {
+		t.Errorf("ConvertCross() TargetAmount = %v, ожидается 133.90", cross.TargetAmount)
+	}
+}
@@ -0,0 +1,135 @@
+package converter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+// RateSeriesProvider - интерфейс для получения динамики курса за период
+// Позволяет получить курсы за месяц одним запросом вместо запроса на каждый день
+//
+// Контракт: при err == nil возвращаемый *RateSeries не должен быть nil.
+type RateSeriesProvider interface {
+	// FetchRateSeries получает курсы валюты за период from..to включительно
+	FetchRateSeries(ctx context.Context, currency models.Currency, from, to time.Time) (*models.RateSeries, error)
+}
+
+// FetchRateSeriesFunc адаптирует функцию к интерфейсу RateSeriesProvider
+type FetchRateSeriesFunc func(ctx context.Context, currency models.Currency, from, to time.Time) (*models.RateSeries, error)
+
+// FetchRateSeries реализует интерфейс RateSeriesProvider
+func (f FetchRateSeriesFunc) FetchRateSeries(ctx context.Context, currency models.Currency, from, to time.Time) (*models.RateSeries, error) {
+	return f(ctx, currency, from, to)
+}
+
+// Ошибки получения динамики курса
+var (
+	ErrNilSeriesProvider = errors.New("источник динамики курсов не задан")
+)
+
+// WithSeriesProvider возвращает конвертер с источником динамики курсов
+// Если provider конвертера сам реализует RateSeriesProvider, он используется автоматически
+//
+// Пример использования:
+//
+//	conv := converter.NewConverter(converter.FetchRatesFunc(parser.FetchRates), cacheStorage).
+//	    WithSeriesProvider(converter.FetchRateSeriesFunc(parser.FetchRateSeries))
+func (c *Converter) WithSeriesProvider(provider RateSeriesProvider) *Converter {
+	clone := *c
+	clone.seriesProvider = provider
+	return &clone
+}
+
+// GetRateSeries получает динамику курса валюты за период одним запросом
+// и заполняет кэш на каждый календарный день периода: последующие Convert и GetRate
+// на любую дату периода обслуживаются без обращения к ЦБ РФ
+// Для выходных и праздников в кэш попадает последний установленный курс
+// (так же, как XML_daily ЦБ РФ отвечает на запрос за выходной день)
+// Период длиннее MaxHistoryDays отклоняется с ErrPeriodTooLong до запроса к источнику
+//
+// Пример использования:
+//
+//	series, err := converter.GetRateSeries(ctx, models.USD, from, to)
+//	if err != nil {
+//	    return err
+//	}
+//	for _, rate := range series.Rates {
+//	    fmt.Println(rate.Date.Format("02.01.2006"), rate.Rate)
+//	}
+func (c *Converter) GetRateSeries(ctx context.Context, currency models.Currency, from, to time.Time) (*models.RateSeries, error) {
+	from, to = normalizeDate(from), normalizeDate(to)
+
+	// Валидация входных данных
+	if err := c.validateCurrency(currency); err != nil {
+		return nil, err
+	}
+
+	if err := ValidatePeriod(from, to); err != nil {
+		return nil, err
+	}
+	if to.After(from.AddDate(0, 0, MaxHistoryDays)) {
+		return nil, ErrPeriodTooLong
+	}
+
+	// Для RUB курс всегда 1 (provider не нужен)
+	if currency == models.RUB {
+		series := &models.RateSeries{Currency: models.RUB, From: from, To: to}
+		for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+			series.Rates = append(series.Rates, models.ExchangeRate{
+				Currency: models.RUB, Rate: models.NewDecimalFromInt(1), Nominal: 1, Date: day,
+			})
+		}
+		return series, nil
+	}
+
+	if c.seriesProvider == nil {
+		return nil, ErrNilSeriesProvider
+	}
+
+	series, err := c.seriesProvider.FetchRateSeries(ctx, currency, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rate series: %w", err)
+	}
+	if series == nil {
+		return nil, errors.New("rate series provider returned nil data")
+	}
+
+	c.cacheSeries(series, from, to)
+
+	return series, nil
+}
+
+// cacheSeries сохраняет в кэш курс на каждый календарный день периода
+// Ключ - календарный день, фактическая дата - дата записи ЦБ РФ, действующей в этот день
+// Курс добавляется в частичный снимок (Partial) дня; полный снимок из XML на дату не изменяется
+func (c *Converter) cacheSeries(series *models.RateSeries, from, to time.Time) {
+	snapshots := make(map[time.Time]*models.RateData)
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		rate, ok := series.RateOn(day)
+		if !ok {
+			// Курс, действовавший до первой записи периода, серии неизвестен
+			continue
+		}
+
+		existing, found := c.cache.Get(day)
+		if found && !existing.Partial {
+			continue
+		}
+
+		// Снимки в кэше не изменяются: дополняем копию
+		snapshot := models.NewRateData(normalizeDate(rate.Date))
+		snapshot.Partial = true
+		if found {
+			for _, cached := range existing.Rates {
+				snapshot.AddRate(cached)
+			}
+		}
+		snapshot.AddRate(rate)
+		snapshots[day] = snapshot
+	}
+	setMany(c.cache, snapshots)
+}
@@ -0,0 +1,293 @@
+package converter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+// MockRateSeriesProvider - мок для RateSeriesProvider
+type MockRateSeriesProvider struct {
+	series    *models.RateSeries
+	err       error
+	callCount int
+}
+
+func (m *MockRateSeriesProvider) FetchRateSeries(_ context.Context, _ models.Currency, _, _ time.Time) (*models.RateSeries, error) {
+	m.callCount++
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.series, nil
+}
+
+// mockRatesAndSeriesProvider - provider, реализующий оба интерфейса
+type mockRatesAndSeriesProvider struct {
+	MockRateProvider
+	MockRateSeriesProvider
+}
+
+func seriesDay(day int) time.Time {
+	return time.Date(2025, 12, day, 0, 0, 0, 0, time.UTC)
+}
+
+func testRateSeries() *models.RateSeries {
+	return &models.RateSeries{
+		Currency: models.USD,
+		From:     seriesDay(1),
+		To:       seriesDay(8),
+		Rates: []models.ExchangeRate{
+			{Currency: models.USD, Rate: dec("78.2284"), Nominal: 1, Date: seriesDay(2)},
+			{Currency: models.USD, Rate: dec("78.5000"), Nominal: 1, Date: seriesDay(3)},
+			{Currency: models.USD, Rate: dec("79.1000"), Nominal: 1, Date: seriesDay(6)},
+		},
+	}
+}
+
+func TestConverter_GetRateSeries_FillsCache(t *testing.T) {
+	provider := &MockRateProvider{}
+	seriesProvider := &MockRateSeriesProvider{series: testRateSeries()}
+	cache := NewMockCache()
+	conv := NewConverter(provider, cache).WithSeriesProvider(seriesProvider)
+
+	series, err := conv.GetRateSeries(context.Background(), models.USD, seriesDay(1), seriesDay(8))
+	if err != nil {
+		t.Fatalf("GetRateSeries() error = %v", err)
+	}
+	if len(series.Rates) != 3 {
+		t.Errorf("len(Rates) = %d, ожидается 3", len(series.Rates))
+	}
+	if seriesProvider.callCount != 1 {
+		t.Errorf("FetchRateSeries вызван %d раз, ожидается 1", seriesProvider.callCount)
+	}
+
+	// Каждый день периода, начиная с первой записи, берется из кэша
+	// с датой действующей записи ЦБ РФ
+	tests := []struct {
+		name       string
+		day        int
+		wantRate   string
+		wantActual int
+	}{
+		{"День записи", 2, "78.2284", 2},
+		{"Следующая запись", 3, "78.5000", 3},
+		{"Дни без записи - последний курс", 5, "78.5000", 3},
+		{"Выходной после записи", 8, "79.1000", 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := conv.Convert(context.Background(), dec("1"), models.USD, seriesDay(tt.day))
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			if !result.Rate.Equal(dec(tt.wantRate)) {
+				t.Errorf("Convert() курс = %s, ожидается %s", result.Rate, tt.wantRate)
+			}
+			if !result.Date.Equal(seriesDay(tt.wantActual)) {
+				t.Errorf("Convert() дата = %v, ожидается %v", result.Date, seriesDay(tt.wantActual))
+			}
+		})
+	}
+
+	if provider.callCount != 0 {
+		t.Errorf("FetchRates вызван %d раз, ожидается 0 (курсы из кэша)", provider.callCount)
+	}
+
+	// Курс, действовавший до первой записи периода, в кэш не попадает
+	if _, _, found := cachedRate(cache, models.USD, seriesDay(1)); found {
+		t.Error("Курс за день до первой записи не должен кэшироваться")
+	}
+}
+
+// batchMockCache - кэш с SetMany, считающий вызовы
+type batchMockCache struct {
+	*MockCacheStorage
+	setCalls     int
+	setManyCalls int
+}
+
+func (m *batchMockCache) Set(requestedDate time.Time, rateData *models.RateData) {
+	m.setCalls++
+	m.MockCacheStorage.Set(requestedDate, rateData)
+}
+
+func (m *batchMockCache) SetMany(snapshots map[time.Time]*models.RateData) {
+	m.setManyCalls++
+	for requestedDate, rateData := range snapshots {
+		m.MockCacheStorage.Set(requestedDate, rateData)
+	}
+}
+
+func TestConverter_GetRateSeries_BatchCache(t *testing.T) {
+	cache := &batchMockCache{MockCacheStorage: NewMockCache()}
+	conv := NewConverter(&MockRateProvider{}, cache).
+		WithSeriesProvider(&MockRateSeriesProvider{series: testRateSeries()})
+
+	if _, err := conv.GetRateSeries(context.Background(), models.USD, seriesDay(1), seriesDay(8)); err != nil {
+		t.Fatalf("GetRateSeries() error = %v", err)
+	}
+	// Дни со 2 по 8 сохраняются одним пакетом
+	if cache.setManyCalls != 1 || cache.setCalls != 0 {
+		t.Errorf("SetMany вызван %d раз, Set - %d; ожидался один SetMany", cache.setManyCalls, cache.setCalls)
+	}
+	if _, found := cache.Get(seriesDay(8)); !found {
+		t.Error("Снимок последнего дня периода должен быть в кэше")
+	}
+}
+
+func TestConverter_GetRateSeries_PartialSnapshot(t *testing.T) {
+	day := seriesDay(3)
+	provider := &MockRateProvider{rateData: &models.RateData{
+		Date: day,
+		Rates: map[models.Currency]models.ExchangeRate{
+			models.USD: {Currency: models.USD, Rate: dec("78.5000"), Nominal: 1, Date: day},
+			models.EUR: {Currency: models.EUR, Rate: dec("91.2000"), Nominal: 1, Date: day},
+		},
+	}}
+	cache := NewMockCache()
+	conv := NewConverter(provider, cache).WithSeriesProvider(&MockRateSeriesProvider{series: testRateSeries()})
+
+	if _, err := conv.GetRateSeries(context.Background(), models.USD, seriesDay(1), seriesDay(8)); err != nil {
+		t.Fatalf("GetRateSeries() error = %v", err)
+	}
+	if rateData, found := cache.Get(day); !found || !rateData.Partial {
+		t.Fatal("Курс из динамики должен сохраняться в частичный снимок дня")
+	}
+
+	// Валюты нет в частичном снимке - это не ErrRateNotFound, а промах кэша
+	result, err := conv.Convert(context.Background(), dec("1"), models.EUR, day)
+	if err != nil {
+		t.Fatalf("Convert(EUR) error = %v", err)
+	}
+	if !result.Rate.Equal(dec("91.2000")) {
+		t.Errorf("Convert(EUR) курс = %s, ожидается 91.2000", result.Rate)
+	}
+	if provider.callCount != 1 {
+		t.Errorf("FetchRates вызван %d раз, ожидается 1", provider.callCount)
+	}
+
+	// Полный снимок из XML на дату заменил частичный и не затирается повторной динамикой
+	if _, err := conv.GetRateSeries(context.Background(), models.USD, seriesDay(1), seriesDay(8)); err != nil {
+		t.Fatalf("GetRateSeries() error = %v", err)
+	}
+	rateData, found := cache.Get(day)
+	if !found || rateData.Partial || len(rateData.Rates) != 2 {
+		t.Errorf("cache.Get() = %+v, %v; ожидается полный снимок с 2 курсами", rateData, found)
+	}
+}
+
+func TestConverter_GetRates_IgnoresPartialSnapshot(t *testing.T) {
+	day := seriesDay(3)
+	provider := &MockRateProvider{rateData: rateSnapshot(day, models.EUR, "91.2000")}
+	cache := NewMockCache()
+	conv := NewConverter(provider, cache).WithSeriesProvider(&MockRateSeriesProvider{series: testRateSeries()})
+
+	if _, err := conv.GetRateSeries(context.Background(), models.USD, day, day); err != nil {
+		t.Fatalf("GetRateSeries() error = %v", err)
+	}
+
+	rateData, err := conv.GetRates(context.Background(), day)
+	if err != nil {
+		t.Fatalf("GetRates() error = %v", err)
+	}
+	if rateData.Partial {
+		t.Error("GetRates() не должен возвращать частичный снимок из кэша")
+	}
+	if provider.callCount != 1 {
+		t.Errorf("FetchRates вызван %d раз, ожидается 1", provider.callCount)
+	}
+}
+
+func TestConverter_GetRateSeries_NormalizesNominal(t *testing.T) {
+	date := seriesDay(3)
+	seriesProvider := &MockRateSeriesProvider{series: &models.RateSeries{
+		Currency: models.Currency("JPY"),
+		Rates: []models.ExchangeRate{
+			{Currency: models.Currency("JPY"), Rate: dec("50.1234"), Nominal: 100, Date: date},
+		},
+	}}
+	cache := NewMockCache()
+	conv := NewConverter(nil, cache).WithSeriesProvider(seriesProvider)
+
+	if _, err := conv.GetRateSeries(context.Background(), models.Currency("JPY"), date, date); err != nil {
+		t.Fatalf("GetRateSeries() error = %v", err)
+	}
+
+	rate, _, found := cachedRate(cache, models.Currency("JPY"), date)
+	if !found {
+		t.Fatal("Курс JPY не сохранен в кэш")
+	}
+	if !rate.Equal(dec("0.501234")) {
+		t.Errorf("Курс в кэше = %s, ожидается 0.501234 (за 1 единицу)", rate)
+	}
+}
+
+func TestConverter_GetRateSeries_AutoDetectsProvider(t *testing.T) {
+	provider := &mockRatesAndSeriesProvider{
+		MockRateSeriesProvider: MockRateSeriesProvider{series: testRateSeries()},
+	}
+	conv := NewConverter(provider, NewMockCache())
+
+	if _, err := conv.GetRateSeries(context.Background(), models.USD, seriesDay(1), seriesDay(8)); err != nil {
+		t.Fatalf("GetRateSeries() error = %v", err)
+	}
+	if provider.MockRateSeriesProvider.callCount != 1 {
+		t.Errorf("FetchRateSeries вызван %d раз, ожидается 1", provider.MockRateSeriesProvider.callCount)
+	}
+}
+
+func TestConverter_GetRateSeries_RUB(t *testing.T) {
+	conv := NewConverter(nil, nil)
+
+	series, err := conv.GetRateSeries(context.Background(), models.RUB, seriesDay(1), seriesDay(3))
+	if err != nil {
+		t.Fatalf("GetRateSeries() error = %v", err)
+	}
+	if len(series.Rates) != 3 {
+		t.Fatalf("len(Rates) = %d, ожидается 3 (по записи на день)", len(series.Rates))
+	}
+	for _, rate := range series.Rates {
+		if !rate.Rate.Equal(dec("1")) {
+			t.Errorf("Курс RUB на %s = %s, ожидается 1", rate.Date.Format("02.01.2006"), rate.Rate)
+		}
+	}
+}
+
+func TestConverter_GetRateSeries_Errors(t *testing.T) {
+	providerErr := errors.New("сеть недоступна")
+	future := time.Now().AddDate(0, 0, 2)
+
+	tests := []struct {
+		name     string
+		provider RateSeriesProvider
+		currency models.Currency
+		from, to time.Time
+		wantErr  error
+	}{
+		{"Источник не задан", nil, models.USD, seriesDay(1), seriesDay(8), ErrNilSeriesProvider},
+		{"Начало позже конца", &MockRateSeriesProvider{}, models.USD, seriesDay(8), seriesDay(1), ErrInvalidPeriod},
+		{"Конец в будущем", &MockRateSeriesProvider{}, models.USD, seriesDay(1), future, ErrDateInFuture},
+		{"Некорректная валюта", &MockRateSeriesProvider{}, models.Currency("XYZ"), seriesDay(1), seriesDay(8), models.ErrUnsupportedCurrency},
+		{"Ошибка источника", &MockRateSeriesProvider{err: providerErr}, models.USD, seriesDay(1), seriesDay(8), providerErr},
+		{"Период больше года", &MockRateSeriesProvider{}, models.USD, seriesDay(1).AddDate(-1, 0, -2), seriesDay(1), ErrPeriodTooLong},
+		{"Период больше года для RUB", nil, models.RUB, seriesDay(1).AddDate(-1, 0, -2), seriesDay(1), ErrPeriodTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conv := NewConverter(nil, nil)
+			if tt.provider != nil {
+				conv = conv.WithSeriesProvider(tt.provider)
+			}
+
+			_, err := conv.GetRateSeries(context.Background(), tt.currency, tt.from, tt.to)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetRateSeries() error = %v, ожидается %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package converter
+
+import (
+	"context"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+// StaleCacheStorage - кэш, который хранит истекшие записи и может их вернуть
+// Нужен для режима stale-while-revalidate (см. Converter.WithStaleWhileRevalidate)
+type StaleCacheStorage interface {
+	CacheStorage
+
+	// GetStale получает снимок курсов без учета срока жизни записи
+	// Возвращает (rateData, found); снимок общий для всех вызывающих и не должен изменяться
+	GetStale(date time.Time) (*models.RateData, bool)
+}
+
+// WithStaleWhileRevalidate возвращает конвертер, который при истекшей записи кэша
+// сразу отдает её курсы (ConversionResult.Stale = true) и обновляет запись в фоне
+// Если ЦБ РФ недоступен, конвертация продолжает работать по последним загруженным курсам
+//
+// Режим работает, только если кэш реализует StaleCacheStorage (cache.LRUCache, cache.TieredCache);
+// для остальных кэшей поведение не меняется
+//
+// Пример использования:
+//
+//	conv := converter.NewConverter(provider, cache.NewLRUCacheWithPolicy(1000, cache.DefaultDatePolicy)).
+//		WithStaleWhileRevalidate()
+//	result, err := conv.Convert(ctx, amount, models.USD, date)
+//	if err == nil && result.Stale {
+//	    fmt.Println("Курс из кэша, данные могут быть неактуальны")
+//	}
+func (c *Converter) WithStaleWhileRevalidate() *Converter {
+	clone := *c
+	clone.staleWhileRevalidate = true
+	return &clone
+}
+
+// getStale получает истекший снимок курсов на дату, если режим stale-while-revalidate включен
+// Частичный снимок (из динамики курса) подходит, только если в нем есть все запрошенные валюты
+func (c *Converter) getStale(normalizedDate time.Time, currencies []models.Currency) (*models.RateData, bool) {
+	if !c.staleWhileRevalidate {
+		return nil, false
+	}
+	staleCache, ok := c.cache.(StaleCacheStorage)
+	if !ok {
+		return nil, false
+	}
+
+	rateData, found := staleCache.GetStale(normalizedDate)
+	if !found || (rateData.Partial && !hasRates(rateData, currencies)) {
+		return nil, false
+	}
+	return rateData, true
+}
+
+// revalidate обновляет снимок курсов на дату в фоне
+// Одновременные обновления объединяются с другими загрузками той же даты (single-flight),
+// ошибка не возвращается: при недоступном ЦБ РФ остается истекшая запись
+func (c *Converter) revalidate(ctx context.Context, normalizedDate time.Time) {
+	if c.provider == nil {
+		return
+	}
+	// Обновление не должно прерываться вместе с запросом, который его запустил
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		_, _ = c.fetchRates(ctx, normalizedDate)
+	}()
+}
+
+// conditionalSnapshot возвращает истекший полный снимок на дату с валидаторами ETag/Last-Modified
+// для условного запроса к источнику (nil - снимка нет, запрос безусловный)
+// Работает, только если кэш реализует StaleCacheStorage, независимо от режима stale-while-revalidate
+func (c *Converter) conditionalSnapshot(normalizedDate time.Time) *models.RateData {
+	staleCache, ok := c.cache.(StaleCacheStorage)
+	if !ok {
+		return nil
+	}
+	rateData, found := staleCache.GetStale(normalizedDate)
+	if !found || rateData.Partial || (rateData.ETag == "" && rateData.LastModified == "") {
+		return nil
+	}
+	return rateData
+}
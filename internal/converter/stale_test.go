@@ -0,0 +1,213 @@
+package converter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+// mockStaleCache - мок для StaleCacheStorage
+// Записи, добавленные через expire, считаются истекшими: Get их не находит, GetStale возвращает
+// Потокобезопасен: фоновое обновление пишет в кэш из другой горутины
+type mockStaleCache struct {
+	mu    sync.Mutex
+	fresh map[string]*models.RateData
+	stale map[string]*models.RateData
+}
+
+func newMockStaleCache() *mockStaleCache {
+	return &mockStaleCache{
+		fresh: make(map[string]*models.RateData),
+		stale: make(map[string]*models.RateData),
+	}
+}
+
+// expire добавляет истекшую запись
+func (m *mockStaleCache) expire(date time.Time, rateData *models.RateData) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stale[date.Format("2006-01-02")] = rateData
+}
+
+func (m *mockStaleCache) Get(date time.Time) (*models.RateData, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rateData, exists := m.fresh[date.Format("2006-01-02")]
+	return rateData, exists
+}
+
+func (m *mockStaleCache) GetStale(date time.Time) (*models.RateData, bool) {
+	if rateData, found := m.Get(date); found {
+		return rateData, true
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rateData, exists := m.stale[date.Format("2006-01-02")]
+	return rateData, exists
+}
+
+func (m *mockStaleCache) Set(requestedDate time.Time, rateData *models.RateData) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fresh[requestedDate.Format("2006-01-02")] = rateData
+}
+
+func (m *mockStaleCache) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fresh = make(map[string]*models.RateData)
+	m.stale = make(map[string]*models.RateData)
+}
+
+// waitFor ждет выполнения условия (фоновое обновление кэша)
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Не дождались: %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestConverter_StaleWhileRevalidate_Refreshes(t *testing.T) {
+	date := testPastDateUTC()
+	provider := newBlockingRateProvider(rateSnapshot(date, models.USD, "81.0000"), nil)
+	cache := newMockStaleCache()
+	cache.expire(date, rateSnapshot(date, models.USD, "80.0000"))
+	conv := NewConverter(provider, cache).WithStaleWhileRevalidate()
+
+	// Истекшая запись отдается сразу, не дожидаясь ЦБ РФ
+	result, err := conv.Convert(context.Background(), dec("100"), models.USD, date)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if !result.Stale || !result.Rate.Equal(dec("80.0000")) {
+		t.Errorf("Convert() = курс %s, Stale %v; ожидался курс 80.0000 из истекшей записи", result.Rate, result.Stale)
+	}
+
+	// Обновление в фоне сохраняет свежие курсы
+	close(provider.release)
+	waitFor(t, "обновление кэша в фоне", func() bool {
+		_, found := cache.Get(date)
+		return found
+	})
+
+	result, err = conv.Convert(context.Background(), dec("100"), models.USD, date)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if result.Stale || !result.Rate.Equal(dec("81.0000")) {
+		t.Errorf("Convert() = курс %s, Stale %v; ожидался свежий курс 81.0000", result.Rate, result.Stale)
+	}
+	if got := provider.calls.Load(); got != 1 {
+		t.Errorf("FetchRates вызван %d раз, ожидался 1", got)
+	}
+}
+
+func TestConverter_StaleWhileRevalidate_Offline(t *testing.T) {
+	date := testPastDateUTC()
+	provider := newBlockingRateProvider(nil, errors.New("cbr.ru unreachable"))
+	close(provider.release)
+	cache := newMockStaleCache()
+	cache.expire(date, rateSnapshot(date, models.USD, "80.0000"))
+	conv := NewConverter(provider, cache).WithStaleWhileRevalidate()
+
+	// ЦБ РФ недоступен - каждая конвертация работает по истекшей записи
+	for i := range 2 {
+		result, err := conv.Convert(context.Background(), dec("100"), models.USD, date)
+		if err != nil {
+			t.Fatalf("Convert() #%d error = %v", i+1, err)
+		}
+		if !result.Stale || !result.TargetAmount.Equal(dec("8000.00")) {
+			t.Errorf("Convert() #%d = %s, Stale %v; ожидалось 8000.00 из истекшей записи", i+1, result.TargetAmount, result.Stale)
+		}
+		waitFor(t, "попытка обновления в фоне", func() bool {
+			return provider.calls.Load() == int32(i+1)
+		})
+	}
+}
+
+func TestConverter_StaleWhileRevalidate_ConvertCross(t *testing.T) {
+	date := testPastDateUTC()
+	provider := newBlockingRateProvider(nil, errors.New("cbr.ru unreachable"))
+	close(provider.release)
+
+	snapshot := rateSnapshot(date, models.USD, "80.0000")
+	snapshot.AddRate(models.ExchangeRate{Currency: models.EUR, Rate: dec("90.0000"), Nominal: 1, Date: date})
+	cache := newMockStaleCache()
+	cache.expire(date, snapshot)
+	conv := NewConverter(provider, cache).WithStaleWhileRevalidate()
+
+	result, err := conv.ConvertCross(context.Background(), dec("90"), models.EUR, models.USD, date)
+	if err != nil {
+		t.Fatalf("ConvertCross() error = %v", err)
+	}
+	if !result.Stale || !result.TargetAmount.Equal(dec("101.25")) {
+		t.Errorf("ConvertCross() = %s, Stale %v; ожидалось 101.25 из истекшей записи", result.TargetAmount, result.Stale)
+	}
+	waitFor(t, "попытка обновления в фоне", func() bool { return provider.calls.Load() == 1 })
+}
+
+func TestConverter_StaleWhileRevalidate_Disabled(t *testing.T) {
+	date := testPastDateUTC()
+	providerErr := errors.New("cbr.ru unreachable")
+
+	tests := []struct {
+		name  string
+		cache CacheStorage
+		conv  func(*Converter) *Converter
+	}{
+		{
+			name:  "Режим не включен",
+			cache: newMockStaleCache(),
+			conv:  func(c *Converter) *Converter { return c },
+		},
+		{
+			name:  "Кэш без GetStale",
+			cache: NewMockCache(),
+			conv:  (*Converter).WithStaleWhileRevalidate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if staleCache, ok := tt.cache.(*mockStaleCache); ok {
+				staleCache.expire(date, rateSnapshot(date, models.USD, "80.0000"))
+			}
+			provider := &MockRateProvider{err: providerErr}
+			conv := tt.conv(NewConverter(provider, tt.cache))
+
+			if _, err := conv.Convert(context.Background(), dec("100"), models.USD, date); !errors.Is(err, providerErr) {
+				t.Errorf("Convert() error = %v, ожидалась ошибка provider", err)
+			}
+			if provider.callCount != 1 {
+				t.Errorf("FetchRates вызван %d раз, ожидался 1", provider.callCount)
+			}
+		})
+	}
+}
+
+func TestConverter_StaleWhileRevalidate_FreshEntry(t *testing.T) {
+	date := testPastDateUTC()
+	provider := &MockRateProvider{err: errors.New("provider should not be called")}
+	cache := newMockStaleCache()
+	cache.Set(date, rateSnapshot(date, models.USD, "80.0000"))
+	conv := NewConverter(provider, cache).WithStaleWhileRevalidate()
+
+	result, err := conv.Convert(context.Background(), dec("100"), models.USD, date)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if result.Stale {
+		t.Error("Курс из свежей записи не должен помечаться как устаревший")
+	}
+	if provider.callCount != 0 {
+		t.Errorf("FetchRates вызван %d раз, ожидалось 0", provider.callCount)
+	}
+}
@@ -1,7 +1,36 @@
 package converter
 
-import "time"
+import (
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
 
 func testPastDateUTC() time.Time {
 	return time.Now().UTC().AddDate(0, 0, -30)
 }
+
+// dec создает Decimal из строкового литерала для тестовых данных
+func dec(s string) models.Decimal {
+	return models.MustParseDecimal(s)
+}
+
+// rateSnapshot создает снимок курсов на дату с курсом одной валюты (номинал 1)
+func rateSnapshot(date time.Time, currency models.Currency, rate string) *models.RateData {
+	rateData := models.NewRateData(date)
+	rateData.AddRate(models.ExchangeRate{Currency: currency, Rate: dec(rate), Nominal: 1, Date: date})
+	return rateData
+}
+
+// cachedRate возвращает курс валюты за единицу и фактическую дату из снимка в кэше
+func cachedRate(cache CacheStorage, currency models.Currency, date time.Time) (models.Decimal, time.Time, bool) {
+	rateData, found := cache.Get(date)
+	if !found {
+		return models.Decimal{}, time.Time{}, false
+	}
+	rate, exists := rateData.Rates[currency]
+	if !exists {
+		return models.Decimal{}, time.Time{}, false
+	}
+	return UnitRate(rate), normalizeDate(rateData.Date), true
+}
@@ -3,14 +3,16 @@ package converter
 
 import (
 	"errors"
-	"math"
 	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
 )
 
 // Ошибки валидации
 var (
 	ErrInvalidAmount = errors.New("сумма должна быть положительным числом")
 	ErrDateInFuture  = errors.New("дата не может быть в будущем")
+	ErrInvalidPeriod = errors.New("начало периода не может быть позже конца")
 )
 
 // ValidateAmount проверяет корректность суммы для конвертации
@@ -21,8 +23,8 @@ var (
 //	if err := ValidateAmount(amount); err != nil {
 //	    return err
 //	}
-func ValidateAmount(amount float64) error {
-	if math.IsNaN(amount) || math.IsInf(amount, 0) || amount <= 0 {
+func ValidateAmount(amount models.Decimal) error {
+	if amount.Sign() <= 0 {
 		return ErrInvalidAmount
 	}
 	return nil
@@ -60,6 +62,21 @@ func ValidateDate(date time.Time) error {
 	return nil
 }
 
+// ValidatePeriod проверяет период для получения динамики курса
+// Обе границы не могут быть в будущем, начало не может быть позже конца
+func ValidatePeriod(from, to time.Time) error {
+	if err := ValidateDate(from); err != nil {
+		return err
+	}
+	if err := ValidateDate(to); err != nil {
+		return err
+	}
+	if normalizeDate(from).After(normalizeDate(to)) {
+		return ErrInvalidPeriod
+	}
+	return nil
+}
+
 func normalizeDate(date time.Time) time.Time {
 	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
 }
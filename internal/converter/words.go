@@ -0,0 +1,215 @@
+package converter
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+// grammaticalGender - род существительного, с которым согласуются "один"/"два"
+type grammaticalGender int
+
+const (
+	masculine grammaticalGender = iota // один рубль, два доллара
+	feminine                           // одна копейка, две лиры
+)
+
+// unitForms - формы существительного для согласования с числительным:
+// one - 1, 21, 101 (рубль); few - 2-4, 22-24 (рубля); many - 0, 5-20, 25-30 (рублей)
+type unitForms struct {
+	one, few, many string
+	gender         grammaticalGender
+}
+
+// currencyWords - названия целой и дробной единиц валюты
+type currencyWords struct {
+	major unitForms
+	minor unitForms
+}
+
+var (
+	kopecks = unitForms{"копейка", "копейки", "копеек", feminine}
+	cents   = unitForms{"цент", "цента", "центов", masculine}
+)
+
+// currencyWordForms - валюты с известными склонениями
+// Для остальных валют сумма пишется прописью с кодом валюты, а дробная часть - как "45/100"
+var currencyWordForms = map[models.Currency]currencyWords{
+	models.RUB: {unitForms{"рубль", "рубля", "рублей", masculine}, kopecks},
+	models.USD: {unitForms{"доллар США", "доллара США", "долларов США", masculine}, cents},
+	models.EUR: {unitForms{"евро", "евро", "евро", masculine}, cents},
+	"CNY":      {unitForms{"юань", "юаня", "юаней", masculine}, unitForms{"фэнь", "фэня", "фэней", masculine}},
+	"GBP":      {unitForms{"фунт стерлингов", "фунта стерлингов", "фунтов стерлингов", masculine}, unitForms{"пенс", "пенса", "пенсов", masculine}},
+	"CHF":      {unitForms{"швейцарский франк", "швейцарских франка", "швейцарских франков", masculine}, unitForms{"сантим", "сантима", "сантимов", masculine}},
+	"JPY":      {unitForms{"иена", "иены", "иен", feminine}, unitForms{"сен", "сена", "сенов", masculine}},
+	"KZT":      {unitForms{"тенге", "тенге", "тенге", masculine}, unitForms{"тиын", "тиына", "тиынов", masculine}},
+	"BYN":      {unitForms{"белорусский рубль", "белорусских рубля", "белорусских рублей", masculine}, kopecks},
+	"UAH":      {unitForms{"гривна", "гривны", "гривен", feminine}, kopecks},
+	"TRY":      {unitForms{"турецкая лира", "турецкие лиры", "турецких лир", feminine}, unitForms{"куруш", "куруша", "курушей", masculine}},
+	"AUD":      {unitForms{"австралийский доллар", "австралийских доллара", "австралийских долларов", masculine}, cents},
+	"CAD":      {unitForms{"канадский доллар", "канадских доллара", "канадских долларов", masculine}, cents},
+}
+
+var (
+	onesMasculine = [10]string{"", "один", "два", "три", "четыре", "пять", "шесть", "семь", "восемь", "девять"}
+	teens         = [10]string{"десять", "одиннадцать", "двенадцать", "тринадцать", "четырнадцать", "пятнадцать", "шестнадцать", "семнадцать", "восемнадцать", "девятнадцать"}
+	tens          = [10]string{"", "", "двадцать", "тридцать", "сорок", "пятьдесят", "шестьдесят", "семьдесят", "восемьдесят", "девяносто"}
+	hundreds      = [10]string{"", "сто", "двести", "триста", "четыреста", "пятьсот", "шестьсот", "семьсот", "восемьсот", "девятьсот"}
+
+	// scales - названия разрядов начиная с тысяч (индекс 0 - тысячи)
+	scales = []unitForms{
+		{"тысяча", "тысячи", "тысяч", feminine},
+		{"миллион", "миллиона", "миллионов", masculine},
+		{"миллиард", "миллиарда", "миллиардов", masculine},
+		{"триллион", "триллиона", "триллионов", masculine},
+	}
+)
+
+// AmountInWords возвращает сумму прописью для счетов и актов:
+// целая часть словами в нужном роде и падеже, дробная - двумя цифрами
+// Сумма округляется до копеек/центов по DefaultRounding (половина - от нуля)
+//
+// Примеры:
+//   - 80722, RUB → "Восемьдесят тысяч семьсот двадцать два рубля 00 копеек"
+//   - 1001.21, USD → "Одна тысяча один доллар США 21 цент"
+//   - 2.5, CNY → "Два юаня 50 фэней"
+//   - 12.34, HKD → "Двенадцать HKD 34/100" (склонения валюты неизвестны)
+func AmountInWords(amount models.Decimal, currency models.Currency) string {
+	return AmountInWordsRounded(amount, currency, DefaultRounding)
+}
+
+// AmountInWordsRounded возвращает сумму прописью, округленную по политике rounding
+// Копейки/центы всегда записываются двумя цифрами: при rounding.Places > 2 сумма округляется
+// до копеек режимом rounding.Mode, при меньшем количестве знаков дробная часть дополняется нулями
+// Converter записывает прописью TargetAmount по своей политике (см. Converter.WithRounding)
+//
+// Пример: 80.7381, RUB, {down, 4} → "Восемьдесят рублей 73 копейки"
+func AmountInWordsRounded(amount models.Decimal, currency models.Currency, rounding models.Rounding) string {
+	rounding.Places = min(rounding.Places, 2)
+	str := rounding.Apply(amount).StringFixed(2)
+	negative := strings.HasPrefix(str, "-")
+	intPart, fracPart, _ := strings.Cut(strings.TrimPrefix(str, "-"), ".")
+
+	forms, known := currencyWordForms[currency]
+	if !known {
+		forms.major = unitForms{string(currency), string(currency), string(currency), masculine}
+	}
+
+	var b strings.Builder
+	if negative {
+		b.WriteString("минус ")
+	}
+	b.WriteString(integerInWords(intPart, forms.major.gender))
+	b.WriteString(" ")
+	b.WriteString(pluralForm(intPart, forms.major))
+	b.WriteString(" ")
+	b.WriteString(fracPart)
+	if known {
+		b.WriteString(" ")
+		b.WriteString(pluralForm(fracPart, forms.minor))
+	} else {
+		b.WriteString("/100")
+	}
+
+	return capitalize(b.String())
+}
+
+// integerInWords записывает неотрицательное целое (строка цифр) словами
+// gender - род единицы измерения, с которой согласуются последние "один"/"два"
+// Числа больше триллионов записываются цифрами
+func integerInWords(digits string, gender grammaticalGender) string {
+	digits = strings.TrimLeft(digits, "0")
+	if digits == "" {
+		return "ноль"
+	}
+	if len(digits) > 3*(len(scales)+1) {
+		return digits
+	}
+
+	// Дополняем слева нулями до кратного трём и разбиваем на тройки
+	if pad := (3 - len(digits)%3) % 3; pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+
+	var words []string
+	groups := len(digits) / 3
+	for i := range groups {
+		triple := digits[i*3 : i*3+3]
+		if triple == "000" {
+			continue
+		}
+
+		scale := groups - i - 2 // -1 - единицы, 0 - тысячи, 1 - миллионы...
+		if scale < 0 {
+			words = append(words, tripleInWords(triple, gender)...)
+			continue
+		}
+		words = append(words, tripleInWords(triple, scales[scale].gender)...)
+		words = append(words, pluralForm(triple, scales[scale]))
+	}
+
+	return strings.Join(words, " ")
+}
+
+// tripleInWords записывает число от 1 до 999 (три цифры) словами
+func tripleInWords(triple string, gender grammaticalGender) []string {
+	h, t, o := triple[0]-'0', triple[1]-'0', triple[2]-'0'
+
+	var words []string
+	if h > 0 {
+		words = append(words, hundreds[h])
+	}
+	switch {
+	case t == 1:
+		return append(words, teens[o])
+	case t > 1:
+		words = append(words, tens[t])
+	}
+	if o > 0 {
+		words = append(words, onesWord(o, gender))
+	}
+	return words
+}
+
+// onesWord возвращает "один"/"одна" и "два"/"две" в нужном роде
+func onesWord(digit byte, gender grammaticalGender) string {
+	switch {
+	case digit == 1 && gender == feminine:
+		return "одна"
+	case digit == 2 && gender == feminine:
+		return "две"
+	default:
+		return onesMasculine[digit]
+	}
+}
+
+// pluralForm выбирает форму существительного по последним двум цифрам числа
+// Примеры: 1 → рубль, 3 → рубля, 11 → рублей, 21 → рубль, 25 → рублей
+func pluralForm(digits string, forms unitForms) string {
+	lastTwo := 0
+	for _, c := range digits[max(0, len(digits)-2):] {
+		lastTwo = lastTwo*10 + int(c-'0')
+	}
+
+	switch {
+	case lastTwo%100 >= 11 && lastTwo%100 <= 14:
+		return forms.many
+	case lastTwo%10 == 1:
+		return forms.one
+	case lastTwo%10 >= 2 && lastTwo%10 <= 4:
+		return forms.few
+	default:
+		return forms.many
+	}
+}
+
+// capitalize делает первую букву строки заглавной
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
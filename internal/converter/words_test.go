@@ -0,0 +1,116 @@
+package converter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+func TestAmountInWords(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		currency models.Currency
+		want     string
+	}{
+		{name: "Пример из счёта", amount: "80722", currency: models.RUB, want: "Восемьдесят тысяч семьсот двадцать два рубля 00 копеек"},
+		{name: "Один рубль одна копейка", amount: "1.01", currency: models.RUB, want: "Один рубль 01 копейка"},
+		{name: "Две копейки", amount: "2.02", currency: models.RUB, want: "Два рубля 02 копейки"},
+		{name: "Одиннадцать рублей", amount: "11.11", currency: models.RUB, want: "Одиннадцать рублей 11 копеек"},
+		{name: "Двадцать один рубль", amount: "21.21", currency: models.RUB, want: "Двадцать один рубль 21 копейка"},
+		{name: "Ноль", amount: "0", currency: models.RUB, want: "Ноль рублей 00 копеек"},
+		{name: "Тысяча - женский род", amount: "1000", currency: models.RUB, want: "Одна тысяча рублей 00 копеек"},
+		{name: "Две тысячи", amount: "2002", currency: models.RUB, want: "Две тысячи два рубля 00 копеек"},
+		{name: "Двенадцать тысяч", amount: "12345", currency: models.RUB, want: "Двенадцать тысяч триста сорок пять рублей 00 копеек"},
+		{name: "Миллионы", amount: "1000000", currency: models.RUB, want: "Один миллион рублей 00 копеек"},
+		{name: "Миллиард с пропуском разрядов", amount: "3000004000.5", currency: models.RUB, want: "Три миллиарда четыре тысячи рублей 50 копеек"},
+		{name: "Округление копеек", amount: "99.995", currency: models.RUB, want: "Сто рублей 00 копеек"},
+		{name: "Доллары", amount: "1001.21", currency: models.USD, want: "Одна тысяча один доллар США 21 цент"},
+		{name: "Евро", amount: "3.05", currency: models.EUR, want: "Три евро 05 центов"},
+		{name: "Юани", amount: "2.5", currency: "CNY", want: "Два юаня 50 фэней"},
+		{name: "Лиры - женский род", amount: "2.01", currency: "TRY", want: "Две турецкие лиры 01 куруш"},
+		{name: "Валюта без склонений", amount: "12.34", currency: "HKD", want: "Двенадцать HKD 34/100"},
+		{name: "Отрицательная сумма", amount: "-5", currency: models.RUB, want: "Минус пять рублей 00 копеек"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AmountInWords(dec(tt.amount), tt.currency); got != tt.want {
+				t.Errorf("AmountInWords(%s, %s) = %q, ожидается %q", tt.amount, tt.currency, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmountInWordsRounded(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		rounding models.Rounding
+		want     string
+	}{
+		{name: "Отбрасывание до копеек", amount: "80.7381", rounding: models.Rounding{Mode: models.RoundDown, Places: 4}, want: "Восемьдесят рублей 73 копейки"},
+		{name: "Вверх до копеек", amount: "80.7301", rounding: models.Rounding{Mode: models.RoundUp, Places: 4}, want: "Восемьдесят рублей 74 копейки"},
+		{name: "Банковское округление", amount: "2.345", rounding: models.Rounding{Mode: models.RoundHalfEven, Places: 3}, want: "Два рубля 34 копейки"},
+		{name: "До целых рублей", amount: "121", rounding: models.Rounding{Mode: models.RoundHalfUp, Places: 0}, want: "Сто двадцать один рубль 00 копеек"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AmountInWordsRounded(dec(tt.amount), models.RUB, tt.rounding); got != tt.want {
+				t.Errorf("AmountInWordsRounded(%s, %+v) = %q, ожидается %q", tt.amount, tt.rounding, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConverter_Convert_AmountInWords(t *testing.T) {
+	date := testPastDateUTC()
+
+	mockProvider := &MockRateProvider{
+		rateData: &models.RateData{
+			Date: date,
+			Rates: map[models.Currency]models.ExchangeRate{
+				models.USD: {Currency: models.USD, Rate: dec("80.7220"), Nominal: 1, Date: date},
+				models.EUR: {Currency: models.EUR, Rate: dec("87.7300"), Nominal: 1, Date: date},
+			},
+		},
+	}
+
+	conv := NewConverter(mockProvider, NewMockCache())
+
+	toRUB, err := conv.Convert(context.Background(), dec("1000"), models.USD, date)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if toRUB.AmountInWords != "Восемьдесят тысяч семьсот двадцать два рубля 00 копеек" {
+		t.Errorf("Convert() AmountInWords = %q", toRUB.AmountInWords)
+	}
+
+	fromRUB, err := conv.ConvertFromRUB(context.Background(), dec("80722"), models.USD, date)
+	if err != nil {
+		t.Fatalf("ConvertFromRUB() error = %v", err)
+	}
+	if fromRUB.AmountInWords != "Одна тысяча долларов США 00 центов" {
+		t.Errorf("ConvertFromRUB() AmountInWords = %q", fromRUB.AmountInWords)
+	}
+
+	cross, err := conv.ConvertCross(context.Background(), dec("1000"), models.USD, models.EUR, date)
+	if err != nil {
+		t.Fatalf("ConvertCross() error = %v", err)
+	}
+	if cross.AmountInWords != "Девятьсот двадцать евро 12 центов" {
+		t.Errorf("ConvertCross() AmountInWords = %q", cross.AmountInWords)
+	}
+
+	// Сумма прописью округляется по политике конвертера: 80,7381 с отбрасыванием - 73 копейки, а не 74
+	down := conv.WithRounding(models.Rounding{Mode: models.RoundDown, Places: 4})
+	result, err := down.Convert(context.Background(), dec("1.0002"), models.USD, date)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if !result.TargetAmount.Equal(dec("80.7381")) || result.AmountInWords != "Восемьдесят рублей 73 копейки" {
+		t.Errorf("Convert() с отбрасыванием = %s, %q; ожидалось 80.7381 и 73 копейки", result.TargetAmount, result.AmountInWords)
+	}
+}
@@ -3,8 +3,6 @@ package models
 
 import (
 	"errors"
-	"fmt"
-	"strings"
 )
 
 // Ошибки валидации валют
@@ -12,38 +10,50 @@ var (
 	ErrUnsupportedCurrency = errors.New("неподдерживаемая валюта")
 )
 
-// Currency представляет тип валюты
+// Currency представляет тип валюты (буквенный код ISO 4217)
 type Currency string
 
-// Поддерживаемые валюты
+// Валюты, используемые в коде напрямую
+// Полный перечень поддерживаемых валют хранится в справочнике (см. Currencies)
 const (
 	USD Currency = "USD" // Доллар США
 	EUR Currency = "EUR" // Евро
 	RUB Currency = "RUB" // Российский рубль
 )
 
+// Драгоценные металлы (коды ISO 4217)
+// ЦБ РФ устанавливает учетные цены металлов в рублях за грамм: металл - такой же инструмент
+// с курсом, как валюта, а сумма в металле - масса в граммах
+const (
+	XAU Currency = "XAU" // Золото
+	XAG Currency = "XAG" // Серебро
+	XPT Currency = "XPT" // Платина
+	XPD Currency = "XPD" // Палладий
+)
+
 // Validate проверяет, является ли валюта поддерживаемой
+// Поддерживаемой считается любая валюта из справочника ЦБ РФ
 func (c Currency) Validate() error {
+	return defaultRegistry.Validate(c)
+}
+
+// IsMetal проверяет, что код обозначает драгоценный металл (курс - рублей за грамм)
+func (c Currency) IsMetal() bool {
 	switch c {
-	case USD, EUR, RUB:
-		return nil
+	case XAU, XAG, XPT, XPD:
+		return true
 	default:
-		return fmt.Errorf("%w: %s", ErrUnsupportedCurrency, c)
+		return false
 	}
 }
 
 // Symbol возвращает символ валюты
+// Для валют без общепринятого символа возвращается буквенный код
 func (c Currency) Symbol() string {
-	switch c {
-	case USD:
-		return "$"
-	case EUR:
-		return "€"
-	case RUB:
-		return "₽"
-	default:
-		return string(c)
+	if info, ok := LookupCurrency(c); ok && info.Symbol != "" {
+		return info.Symbol
 	}
+	return string(c)
 }
 
 // String возвращает строковое представление валюты
@@ -53,16 +63,24 @@ func (c Currency) String() string {
 
 // Name возвращает полное название валюты на русском
 func (c Currency) Name() string {
-	switch c {
-	case USD:
-		return "Доллар США"
-	case EUR:
-		return "Евро"
-	case RUB:
-		return "Российский рубль"
-	default:
-		return string(c)
+	if info, ok := LookupCurrency(c); ok && info.Name != "" {
+		return info.Name
+	}
+	return string(c)
+}
+
+// IsCurrencyCode проверяет, что строка является буквенным кодом валюты:
+// ровно три заглавные латинские буквы (например, "USD", "CNY")
+func IsCurrencyCode(s string) bool {
+	if len(s) != 3 {
+		return false
 	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < 'A' || s[i] > 'Z' {
+			return false
+		}
+	}
+	return true
 }
 
 // ParseCurrency парсит строку в Currency и валидирует её
@@ -72,13 +90,7 @@ func (c Currency) Name() string {
 // Примеры:
 //   - "usd" -> USD
 //   - " eur " -> EUR
-//   - "Usd" -> USD
+//   - "cny" -> CNY
 func ParseCurrency(s string) (Currency, error) {
-	// Нормализуем ввод: убираем пробелы и приводим к верхнему регистру
-	normalized := strings.ToUpper(strings.TrimSpace(s))
-	currency := Currency(normalized)
-	if err := currency.Validate(); err != nil {
-		return "", err
-	}
-	return currency, nil
+	return defaultRegistry.Parse(s)
 }
@@ -23,9 +23,14 @@ func TestCurrencyValidate(t *testing.T) {
 			curr:    RUB,
 			wantErr: false,
 		},
+		{
+			name:    "CNY из справочника ЦБ РФ валидна",
+			curr:    Currency("CNY"),
+			wantErr: false,
+		},
 		{
 			name:    "Неизвестная валюта",
-			curr:    Currency("GBP"),
+			curr:    Currency("XYZ"),
 			wantErr: true,
 		},
 		{
@@ -68,8 +73,8 @@ func TestCurrencySymbol(t *testing.T) {
 		},
 		{
 			name: "Неизвестная валюта возвращает код",
-			curr: Currency("GBP"),
-			want: "GBP",
+			curr: Currency("XYZ"),
+			want: "XYZ",
 		},
 	}
 
@@ -114,6 +119,29 @@ func TestCurrencyString(t *testing.T) {
 	}
 }
 
+func TestCurrencyIsMetal(t *testing.T) {
+	tests := []struct {
+		curr Currency
+		want bool
+	}{
+		{XAU, true},
+		{XAG, true},
+		{XPT, true},
+		{XPD, true},
+		{USD, false},
+		{RUB, false},
+		{"XDR", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.curr), func(t *testing.T) {
+			if got := tt.curr.IsMetal(); got != tt.want {
+				t.Errorf("Currency.IsMetal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestCurrencyName(t *testing.T) {
 	tests := []struct {
 		name string
@@ -137,8 +165,8 @@ func TestCurrencyName(t *testing.T) {
 		},
 		{
 			name: "Неизвестная валюта возвращает код",
-			curr: Currency("GBP"),
-			want: "GBP",
+			curr: Currency("XYZ"),
+			want: "XYZ",
 		},
 	}
 
@@ -188,9 +216,15 @@ func TestParseCurrency(t *testing.T) {
 			want:      USD,
 			wantError: false,
 		},
+		{
+			name:      "Валюта из справочника ЦБ РФ",
+			input:     "kzt",
+			want:      Currency("KZT"),
+			wantError: false,
+		},
 		{
 			name:      "Неподдерживаемая валюта",
-			input:     "GBP",
+			input:     "XYZ",
 			want:      "",
 			wantError: true,
 		},
@@ -0,0 +1,335 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Ошибки десятичных чисел
+var (
+	ErrInvalidDecimal = errors.New("некорректное десятичное число")
+)
+
+// Decimal - десятичное число с фиксированной точкой для денежных сумм и курсов
+// Значение хранится как целое coef и масштаб scale: value = coef × 10^(-scale)
+// Сложение, вычитание и умножение точные, округление выполняется только явно
+// (Round, Div), поэтому результат не зависит от погрешностей двоичного float64
+//
+// Нулевое значение Decimal{} равно 0 и готово к использованию
+type Decimal struct {
+	coef  *big.Int // nil означает 0
+	scale int32    // Количество знаков после запятой (>= 0)
+}
+
+// NewDecimal создает Decimal из целого значения и масштаба: NewDecimal(807220, 4) = 80.7220
+// Отрицательный масштаб означает умножение на степень 10: NewDecimal(5, -2) = 500
+func NewDecimal(unscaled int64, scale int32) Decimal {
+	coef := big.NewInt(unscaled)
+	if scale < 0 {
+		coef.Mul(coef, pow10(-scale))
+		scale = 0
+	}
+	return Decimal{coef: coef, scale: scale}
+}
+
+// NewDecimalFromInt создает целое Decimal
+func NewDecimalFromInt(n int64) Decimal {
+	return NewDecimal(n, 0)
+}
+
+// NewDecimalFromFloat создает Decimal из float64 по его кратчайшему десятичному
+// представлению: 0.1 превращается ровно в 0.1, а не в 0.1000000000000000055...
+// Используется на границе с JSON/GUI, где суммы приходят как числа
+func NewDecimalFromFloat(f float64) (Decimal, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}, fmt.Errorf("%w: %v", ErrInvalidDecimal, f)
+	}
+	return ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+// ParseDecimal парсит строку в Decimal
+// Допускает знак, точку или запятую как десятичный разделитель и пробелы по краям
+//
+// Примеры:
+//   - "80,7220" → 80.7220
+//   - "1000.5" → 1000.5
+//   - "-0,01" → -0.01
+func ParseDecimal(s string) (Decimal, error) {
+	str := strings.TrimSpace(s)
+	if str == "" {
+		return Decimal{}, fmt.Errorf("%w: пустая строка", ErrInvalidDecimal)
+	}
+
+	negative := false
+	switch str[0] {
+	case '-':
+		negative = true
+		str = str[1:]
+	case '+':
+		str = str[1:]
+	}
+
+	intPart, fracPart := str, ""
+	if i := strings.IndexAny(str, ".,"); i >= 0 {
+		intPart, fracPart = str[:i], str[i+1:]
+	}
+
+	if intPart == "" && fracPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return Decimal{}, fmt.Errorf("%w: %s", ErrInvalidDecimal, s)
+	}
+
+	coef, ok := new(big.Int).SetString(intPart+fracPart, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("%w: %s", ErrInvalidDecimal, s)
+	}
+	if negative {
+		coef.Neg(coef)
+	}
+
+	return Decimal{coef: coef, scale: int32(len(fracPart))}, nil
+}
+
+// MustParseDecimal как ParseDecimal, но паникует при ошибке
+// Предназначена для констант и тестов
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// Scale возвращает количество знаков после запятой
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Sign возвращает -1, 0 или +1 в зависимости от знака числа
+func (d Decimal) Sign() int {
+	if d.coef == nil {
+		return 0
+	}
+	return d.coef.Sign()
+}
+
+// IsZero проверяет, равно ли число нулю
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Cmp сравнивает числа: -1 если d < o, 0 если d == o, +1 если d > o
+// Масштаб не учитывается: 1.50 == 1.5
+func (d Decimal) Cmp(o Decimal) int {
+	a, b := align(d, o)
+	return a.Cmp(b)
+}
+
+// Equal проверяет равенство значений (без учета масштаба)
+func (d Decimal) Equal(o Decimal) bool {
+	return d.Cmp(o) == 0
+}
+
+// Neg возвращает число с противоположным знаком
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+// Add возвращает точную сумму d + o
+func (d Decimal) Add(o Decimal) Decimal {
+	a, b := align(d, o)
+	return Decimal{coef: a.Add(a, b), scale: max(d.scale, o.scale)}
+}
+
+// Sub возвращает точную разность d - o
+func (d Decimal) Sub(o Decimal) Decimal {
+	a, b := align(d, o)
+	return Decimal{coef: a.Sub(a, b), scale: max(d.scale, o.scale)}
+}
+
+// Mul возвращает точное произведение d × o
+func (d Decimal) Mul(o Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(d.int(), o.int()), scale: d.scale + o.scale}
+}
+
+// Div возвращает частное d / o, округленное до places знаков после запятой
+// (половина округляется от нуля)
+// Паникует при делении на ноль, как и целочисленное деление в Go
+func (d Decimal) Div(o Decimal, places int32) Decimal {
+	return d.DivRound(o, places, RoundHalfUp)
+}
+
+// DivRound возвращает частное d / o, округленное до places знаков в режиме mode
+// Паникует при делении на ноль, как и целочисленное деление в Go
+func (d Decimal) DivRound(o Decimal, places int32, mode RoundingMode) Decimal {
+	if o.IsZero() {
+		panic("models: decimal division by zero")
+	}
+
+	// d / o = (dc / oc) × 10^(os - ds); приводим к масштабу places
+	num := new(big.Int).Set(d.int())
+	den := new(big.Int).Set(o.int())
+	if exp := o.scale - d.scale + places; exp >= 0 {
+		num.Mul(num, pow10(exp))
+	} else {
+		den.Mul(den, pow10(-exp))
+	}
+
+	return Decimal{coef: quoRound(num, den, mode), scale: places}
+}
+
+// Round округляет число до places знаков после запятой
+// (половина округляется от нуля: 2.345 → 2.35, -2.345 → -2.35)
+// Если знаков уже не больше places, число возвращается без изменений
+func (d Decimal) Round(places int32) Decimal {
+	return d.RoundMode(places, RoundHalfUp)
+}
+
+// RoundMode округляет число до places знаков после запятой в режиме mode
+// Если знаков уже не больше places, число возвращается без изменений
+func (d Decimal) RoundMode(places int32, mode RoundingMode) Decimal {
+	if places < 0 {
+		places = 0
+	}
+	if d.scale <= places {
+		return d
+	}
+	return Decimal{coef: quoRound(d.int(), pow10(d.scale-places), mode), scale: places}
+}
+
+// StringFixed возвращает число, округленное до places знаков,
+// ровно с places знаками после точки: 80.722 → "80.72", 5 → "5.00"
+func (d Decimal) StringFixed(places int32) string {
+	if places < 0 {
+		places = 0
+	}
+	r := d.Round(places)
+	if r.scale < places {
+		r = Decimal{coef: new(big.Int).Mul(r.int(), pow10(places-r.scale)), scale: places}
+	}
+	return r.String()
+}
+
+// String возвращает точное представление числа с точкой как разделителем,
+// сохраняя масштаб: NewDecimal(807220, 4) → "80.7220"
+func (d Decimal) String() string {
+	coef := d.int()
+	digits := new(big.Int).Abs(coef).String()
+
+	sign := ""
+	if coef.Sign() < 0 {
+		sign = "-"
+	}
+
+	if d.scale == 0 {
+		return sign + digits
+	}
+
+	if pad := int(d.scale) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+	point := len(digits) - int(d.scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// Float64 возвращает ближайшее значение float64
+// Предназначена только для отображения (JSON для GUI), не для расчетов
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// MarshalJSON сериализует число как JSON-число без потери точности
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON принимает JSON-число или строку с числом
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "null" {
+		*d = Decimal{}
+		return nil
+	}
+	parsed, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// int возвращает коэффициент, трактуя nil как 0
+func (d Decimal) int() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// align возвращает копии коэффициентов, приведенные к общему масштабу
+func align(a, b Decimal) (*big.Int, *big.Int) {
+	x := new(big.Int).Set(a.int())
+	y := new(big.Int).Set(b.int())
+	switch {
+	case a.scale < b.scale:
+		x.Mul(x, pow10(b.scale-a.scale))
+	case b.scale < a.scale:
+		y.Mul(y, pow10(a.scale-b.scale))
+	}
+	return x, y
+}
+
+// quoRound делит num на den с округлением в режиме mode
+// Неизвестный режим трактуется как RoundHalfUp
+func quoRound(num, den *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+
+	// QuoRem отбрасывает дробную часть (к нулю); при необходимости сдвигаем от нуля
+	away := false
+	switch mode {
+	case RoundDown:
+	case RoundUp:
+		away = true
+	default:
+		// Сравниваем 2|r| с |den|: больше половины - от нуля, ровно половина - по режиму
+		twice := new(big.Int).Abs(r)
+		twice.Lsh(twice, 1)
+		switch c := twice.Cmp(new(big.Int).Abs(den)); {
+		case c > 0:
+			away = true
+		case c == 0:
+			away = mode != RoundHalfEven || q.Bit(0) == 1
+		}
+	}
+
+	if away {
+		if num.Sign()*den.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// pow10 возвращает 10^n
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// isDigits проверяет, что строка состоит только из ASCII-цифр (пустая строка допустима)
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,202 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "Курс с запятой", input: "80,7220", want: "80.7220"},
+		{name: "Число с точкой", input: "1000.5", want: "1000.5"},
+		{name: "Целое число", input: "42", want: "42"},
+		{name: "Отрицательное число", input: "-0,01", want: "-0.01"},
+		{name: "Знак плюс и пробелы", input: " +3.14 ", want: "3.14"},
+		{name: "Без целой части", input: ".5", want: "0.5"},
+		{name: "Пустая строка", input: "", wantErr: true},
+		{name: "Только разделитель", input: ",", wantErr: true},
+		{name: "Буквы", input: "abc", wantErr: true},
+		{name: "Два разделителя", input: "1.2.3", wantErr: true},
+		{name: "Экспонента", input: "1e5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDecimal(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidDecimal) {
+					t.Fatalf("ParseDecimal(%q) ошибка = %v, ожидалась ErrInvalidDecimal", tt.input, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDecimal(%q) неожиданная ошибка: %v", tt.input, err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("ParseDecimal(%q) = %s, ожидается %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDecimalFromFloat(t *testing.T) {
+	d, err := NewDecimalFromFloat(0.1)
+	if err != nil {
+		t.Fatalf("NewDecimalFromFloat(0.1) ошибка: %v", err)
+	}
+	if d.String() != "0.1" {
+		t.Errorf("NewDecimalFromFloat(0.1) = %s, ожидается 0.1", d)
+	}
+
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if _, err := NewDecimalFromFloat(f); !errors.Is(err, ErrInvalidDecimal) {
+			t.Errorf("NewDecimalFromFloat(%v) ошибка = %v, ожидалась ErrInvalidDecimal", f, err)
+		}
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	a := MustParseDecimal("0.1")
+	b := MustParseDecimal("0.2")
+
+	if got := a.Add(b); !got.Equal(MustParseDecimal("0.3")) {
+		t.Errorf("0.1 + 0.2 = %s, ожидается 0.3", got)
+	}
+	if got := a.Sub(b); got.String() != "-0.1" {
+		t.Errorf("0.1 - 0.2 = %s, ожидается -0.1", got)
+	}
+	if got := MustParseDecimal("80.7220").Mul(MustParseDecimal("1000")); got.String() != "80722.0000" {
+		t.Errorf("80.7220 × 1000 = %s, ожидается 80722.0000", got)
+	}
+	if got := MustParseDecimal("1.50").Neg(); got.String() != "-1.50" {
+		t.Errorf("Neg(1.50) = %s, ожидается -1.50", got)
+	}
+
+	var zero Decimal
+	if !zero.IsZero() || zero.String() != "0" {
+		t.Errorf("Нулевое значение Decimal = %s, ожидается 0", zero)
+	}
+	if got := zero.Add(a); !got.Equal(a) {
+		t.Errorf("0 + 0.1 = %s, ожидается 0.1", got)
+	}
+}
+
+func TestDecimalCmp(t *testing.T) {
+	if !MustParseDecimal("1.50").Equal(MustParseDecimal("1.5")) {
+		t.Error("1.50 должно быть равно 1.5")
+	}
+	if MustParseDecimal("2").Cmp(MustParseDecimal("10")) != -1 {
+		t.Error("2 должно быть меньше 10")
+	}
+	if MustParseDecimal("-1").Cmp(MustParseDecimal("-1.01")) != 1 {
+		t.Error("-1 должно быть больше -1.01")
+	}
+}
+
+func TestDecimalRound(t *testing.T) {
+	tests := []struct {
+		input  string
+		places int32
+		want   string
+	}{
+		{input: "2.345", places: 2, want: "2.35"},
+		{input: "-2.345", places: 2, want: "-2.35"},
+		{input: "2.344", places: 2, want: "2.34"},
+		{input: "0.005", places: 2, want: "0.01"},
+		{input: "1.5", places: 0, want: "2"},
+		{input: "1.2", places: 4, want: "1.2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := MustParseDecimal(tt.input).Round(tt.places); got.String() != tt.want {
+				t.Errorf("Round(%s, %d) = %s, ожидается %s", tt.input, tt.places, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimalDiv(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		places int32
+		want   string
+	}{
+		{a: "1000", b: "80.7220", places: 2, want: "12.39"},
+		{a: "1", b: "3", places: 4, want: "0.3333"},
+		{a: "2", b: "3", places: 4, want: "0.6667"},
+		{a: "-2", b: "3", places: 4, want: "-0.6667"},
+		{a: "1", b: "8", places: 2, want: "0.13"},
+		{a: "11.2345", b: "100", places: 6, want: "0.112345"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			got := MustParseDecimal(tt.a).Div(MustParseDecimal(tt.b), tt.places)
+			if got.String() != tt.want {
+				t.Errorf("%s / %s = %s, ожидается %s", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimalDivByZeroPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Деление на ноль должно вызывать панику")
+		}
+	}()
+	MustParseDecimal("1").Div(Decimal{}, 2)
+}
+
+func TestDecimalStringFixed(t *testing.T) {
+	tests := []struct {
+		input  string
+		places int32
+		want   string
+	}{
+		{input: "80.722", places: 2, want: "80.72"},
+		{input: "5", places: 2, want: "5.00"},
+		{input: "0.125", places: 2, want: "0.13"},
+		{input: "80.7220", places: 4, want: "80.7220"},
+		{input: "-0.001", places: 2, want: "0.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := MustParseDecimal(tt.input).StringFixed(tt.places); got != tt.want {
+				t.Errorf("StringFixed(%s, %d) = %s, ожидается %s", tt.input, tt.places, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Rate Decimal `json:"rate"`
+	}{Rate: MustParseDecimal("80.7220")})
+	if err != nil {
+		t.Fatalf("json.Marshal ошибка: %v", err)
+	}
+	if string(data) != `{"rate":80.7220}` {
+		t.Errorf("json.Marshal = %s, ожидается {\"rate\":80.7220}", data)
+	}
+
+	var parsed struct {
+		A Decimal `json:"a"`
+		B Decimal `json:"b"`
+	}
+	if err := json.Unmarshal([]byte(`{"a":0.1,"b":"12,5"}`), &parsed); err != nil {
+		t.Fatalf("json.Unmarshal ошибка: %v", err)
+	}
+	if parsed.A.String() != "0.1" || parsed.B.String() != "12.5" {
+		t.Errorf("json.Unmarshal = %s, %s; ожидается 0.1, 12.5", parsed.A, parsed.B)
+	}
+}
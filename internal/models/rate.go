@@ -1,11 +1,46 @@
 package models
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Ошибки валидации направления конвертации
+var (
+	ErrInvalidDirection = errors.New("неизвестное направление конвертации")
+)
+
+// Direction задаёт направление конвертации относительно рубля
+type Direction string
+
+// Поддерживаемые направления конвертации
+const (
+	ToRUB   Direction = "toRUB"   // Валюта → рубли (по умолчанию)
+	FromRUB Direction = "fromRUB" // Рубли → валюта
+	Cross   Direction = "cross"   // Валюта → валюта через рублёвые курсы ЦБ РФ
+)
+
+// ParseDirection парсит строку в Direction
+// Пустая строка означает направление по умолчанию (ToRUB) для совместимости
+// со старыми клиентами, которые не передают направление
+// Cross не принимается: кросс-конвертация задаётся парой валют, а не направлением
+func ParseDirection(s string) (Direction, error) {
+	switch strings.TrimSpace(s) {
+	case "", string(ToRUB):
+		return ToRUB, nil
+	case string(FromRUB):
+		return FromRUB, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrInvalidDirection, s)
+	}
+}
 
 // ExchangeRate представляет курс валюты ЦБ РФ
 type ExchangeRate struct {
 	Currency Currency  // Валюта
-	Rate     float64   // Курс к рублю
+	Rate     Decimal   // Курс к рублю (за Nominal единиц валюты, как в XML ЦБ РФ)
 	Nominal  int       // Номинал (количество единиц валюты)
 	Date     time.Time // Дата курса
 }
@@ -13,18 +48,31 @@ type ExchangeRate struct {
 // ConversionResult представляет результат конвертации валюты
 type ConversionResult struct {
 	SourceCurrency Currency  // Исходная валюта
-	TargetCurrency Currency  // Целевая валюта (всегда RUB)
-	SourceAmount   float64   // Исходная сумма
-	TargetAmount   float64   // Результат конвертации
-	Rate           float64   // Использованный курс
+	TargetCurrency Currency  // Целевая валюта
+	Direction      Direction // Направление конвертации (ToRUB, FromRUB или Cross)
+	SourceAmount   Decimal   // Исходная сумма
+	TargetAmount   Decimal   // Результат конвертации (округлён по политике конвертера, по умолчанию до копеек)
+	Rate           Decimal   // Использованный курс: рублей за единицу валюты, для Cross - кросс-курс (единиц целевой валюты за единицу исходной)
+	SourceRate     Decimal   // Курс исходной валюты (у ЦБ РФ - рублей за единицу, для RUB - 1; см. RateData.Base)
+	TargetRate     Decimal   // Курс целевой валюты (у ЦБ РФ - рублей за единицу, для RUB - 1; см. RateData.Base)
 	Date           time.Time // Дата курса
 	FormattedStr   string    // Отформатированная строка для отображения
+	AmountInWords  string    // TargetAmount прописью в целевой валюте ("Восемьдесят тысяч ... рубля 00 копеек")
+	Stale          bool      // Курс из истекшей записи кэша (ЦБ РФ недоступен или курс обновляется в фоне)
+	Source         string    // Источник курсов (RateData.Source), пусто - источник не указан
 }
 
 // RateData представляет полные данные о курсах валют на определенную дату
 type RateData struct {
-	Date  time.Time                 // Дата курса
-	Rates map[Currency]ExchangeRate // Курсы валют (ключ - валюта)
+	Date    time.Time                 // Дата курса
+	Rates   map[Currency]ExchangeRate // Курсы валют (ключ - валюта)
+	Partial bool                      // Курсы не всех опубликованных валют (снимок из динамики курса или без недоступного дополнительного источника)
+	Source  string                    // Источник, отдавший курсы (имя в converter.ProviderChain), пусто - не указан
+	Base    Currency                  // Валюта, в которой выражены курсы (пусто - рубль, как у ЦБ РФ)
+
+	// Валидаторы HTTP ответа для условного запроса (If-None-Match / If-Modified-Since), пусто - не получены
+	ETag         string
+	LastModified string
 }
 
 // NewRateData создает новый RateData с инициализированной картой
@@ -35,6 +83,15 @@ func NewRateData(date time.Time) *RateData {
 	}
 }
 
+// BaseCurrency возвращает валюту, в которой выражены курсы: Rate - единиц базовой валюты за Nominal единиц
+// Курс самой базовой валюты равен 1 и в Rates не хранится
+func (rd *RateData) BaseCurrency() Currency {
+	if rd.Base == "" {
+		return RUB
+	}
+	return rd.Base
+}
+
 // AddRate добавляет курс валюты в RateData
 func (rd *RateData) AddRate(rate ExchangeRate) {
 	rd.Rates[rate.Currency] = rate
@@ -45,3 +102,45 @@ func (rd *RateData) GetRate(currency Currency) (ExchangeRate, bool) {
 	rate, ok := rd.Rates[currency]
 	return rate, ok
 }
+
+// ErrNotModified - курсы не изменились с загрузки снимка, переданного в условный запрос
+// (ответ 304 Not Modified, см. converter.ConditionalRateProvider)
+var ErrNotModified = errors.New("курсы не изменились")
+
+// RateSeries представляет динамику курса одной валюты за период
+// Записи есть только на даты установления курса ЦБ РФ (без выходных и праздников)
+type RateSeries struct {
+	Currency Currency       // Валюта
+	From     time.Time      // Начало периода (включительно)
+	To       time.Time      // Конец периода (включительно)
+	Rates    []ExchangeRate // Курсы по возрастанию даты
+}
+
+// RateOn возвращает курс, действующий на указанную дату: последнюю запись
+// с датой не позже date (как XML_daily ЦБ РФ для выходного дня)
+// Возвращает false, если в серии нет записей до date включительно
+func (rs *RateSeries) RateOn(date time.Time) (ExchangeRate, bool) {
+	day := date.Format("2006-01-02")
+	for i := len(rs.Rates) - 1; i >= 0; i-- {
+		if rs.Rates[i].Date.Format("2006-01-02") <= day {
+			return rs.Rates[i], true
+		}
+	}
+	return ExchangeRate{}, false
+}
+
+// KeyRate представляет ключевую ставку ЦБ РФ на дату
+// С 01.01.2016 ставка рефинансирования приравнена к ключевой ставке
+type KeyRate struct {
+	Date time.Time // Дата, на которую действует ставка
+	Rate Decimal   // Процентов годовых (например, 16.50)
+}
+
+// KeyRateSeries представляет ключевую ставку на каждый календарный день периода
+// (для расчета неустойки и процентов по ст. 395 ГК РФ); в выходные и праздники
+// действует ставка последнего рабочего дня
+type KeyRateSeries struct {
+	From  time.Time // Начало периода (включительно)
+	To    time.Time // Конец периода (включительно)
+	Rates []KeyRate // Ставки по возрастанию даты; дни до первой известной ставки пропускаются
+}
@@ -1,6 +1,10 @@
 package models
 
-import "testing"
+import (
+	"errors"
+	"testing"
+	"time"
+)
 
 func TestNewRateData(t *testing.T) {
 	date := testPastDateUTC()
@@ -29,7 +33,7 @@ func TestRateDataAddRate(t *testing.T) {
 
 	rate := ExchangeRate{
 		Currency: USD,
-		Rate:     95.50,
+		Rate:     MustParseDecimal("95.50"),
 		Nominal:  1,
 		Date:     date,
 	}
@@ -45,7 +49,7 @@ func TestRateDataAddRate(t *testing.T) {
 		t.Error("AddRate() курс USD не найден")
 	}
 
-	if storedRate.Rate != rate.Rate {
+	if !storedRate.Rate.Equal(rate.Rate) {
 		t.Errorf("AddRate() курс = %v, ожидается %v", storedRate.Rate, rate.Rate)
 	}
 }
@@ -56,14 +60,14 @@ func TestRateDataGetRate(t *testing.T) {
 
 	usdRate := ExchangeRate{
 		Currency: USD,
-		Rate:     95.50,
+		Rate:     MustParseDecimal("95.50"),
 		Nominal:  1,
 		Date:     date,
 	}
 
 	eurRate := ExchangeRate{
 		Currency: EUR,
-		Rate:     105.20,
+		Rate:     MustParseDecimal("105.20"),
 		Nominal:  1,
 		Date:     date,
 	}
@@ -77,7 +81,7 @@ func TestRateDataGetRate(t *testing.T) {
 		if !ok {
 			t.Error("GetRate() не нашел USD курс")
 		}
-		if rate.Rate != usdRate.Rate {
+		if !rate.Rate.Equal(usdRate.Rate) {
 			t.Errorf("GetRate() курс = %v, ожидается %v", rate.Rate, usdRate.Rate)
 		}
 	})
@@ -96,7 +100,7 @@ func TestExchangeRateStruct(t *testing.T) {
 
 	rate := ExchangeRate{
 		Currency: USD,
-		Rate:     95.50,
+		Rate:     MustParseDecimal("95.50"),
 		Nominal:  1,
 		Date:     date,
 	}
@@ -105,7 +109,7 @@ func TestExchangeRateStruct(t *testing.T) {
 		t.Errorf("ExchangeRate.Currency = %v, ожидается %v", rate.Currency, USD)
 	}
 
-	if rate.Rate != 95.50 {
+	if !rate.Rate.Equal(MustParseDecimal("95.50")) {
 		t.Errorf("ExchangeRate.Rate = %v, ожидается %v", rate.Rate, 95.50)
 	}
 
@@ -124,9 +128,9 @@ func TestConversionResultStruct(t *testing.T) {
 	result := ConversionResult{
 		SourceCurrency: USD,
 		TargetCurrency: RUB,
-		SourceAmount:   100.0,
-		TargetAmount:   9550.0,
-		Rate:           95.50,
+		SourceAmount:   MustParseDecimal("100.0"),
+		TargetAmount:   MustParseDecimal("9550.0"),
+		Rate:           MustParseDecimal("95.50"),
 		Date:           date,
 	}
 
@@ -138,19 +142,84 @@ func TestConversionResultStruct(t *testing.T) {
 		t.Errorf("ConversionResult.TargetCurrency = %v, ожидается %v", result.TargetCurrency, RUB)
 	}
 
-	if result.SourceAmount != 100.0 {
-		t.Errorf("ConversionResult.SourceAmount = %v, ожидается %v", result.SourceAmount, 100.0)
+	if !result.SourceAmount.Equal(MustParseDecimal("100.0")) {
+		t.Errorf("ConversionResult.SourceAmount = %v, ожидается %v", result.SourceAmount, "100.0")
 	}
 
-	if result.TargetAmount != 9550.0 {
-		t.Errorf("ConversionResult.TargetAmount = %v, ожидается %v", result.TargetAmount, 9550.0)
+	if !result.TargetAmount.Equal(MustParseDecimal("9550.0")) {
+		t.Errorf("ConversionResult.TargetAmount = %v, ожидается %v", result.TargetAmount, "9550.0")
 	}
 
-	if result.Rate != 95.50 {
-		t.Errorf("ConversionResult.Rate = %v, ожидается %v", result.Rate, 95.50)
+	if !result.Rate.Equal(MustParseDecimal("95.50")) {
+		t.Errorf("ConversionResult.Rate = %v, ожидается %v", result.Rate, "95.50")
 	}
 
 	if !result.Date.Equal(date) {
 		t.Errorf("ConversionResult.Date = %v, ожидается %v", result.Date, date)
 	}
 }
+
+func TestParseDirection(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Direction
+		wantErr bool
+	}{
+		{input: "", want: ToRUB},
+		{input: "toRUB", want: ToRUB},
+		{input: " fromRUB ", want: FromRUB},
+		{input: "sideways", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDirection(tt.input)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidDirection) {
+				t.Errorf("ParseDirection(%q) error = %v, ожидается ErrInvalidDirection", tt.input, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseDirection(%q) неожиданная ошибка: %v", tt.input, err)
+		}
+		if got != tt.want {
+			t.Errorf("ParseDirection(%q) = %v, ожидается %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestRateSeriesRateOn(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 12, d, 0, 0, 0, 0, time.UTC) }
+	series := &RateSeries{
+		Currency: USD,
+		Rates: []ExchangeRate{
+			{Currency: USD, Rate: MustParseDecimal("78.2284"), Nominal: 1, Date: day(2)},
+			{Currency: USD, Rate: MustParseDecimal("79.1000"), Nominal: 1, Date: day(6)},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		date      time.Time
+		wantFound bool
+		wantDate  time.Time
+	}{
+		{"До первой записи", day(1), false, time.Time{}},
+		{"Дата записи", day(2), true, day(2)},
+		{"Между записями - предыдущая", day(5), true, day(2)},
+		{"Время суток не учитывается", day(6).Add(15 * time.Hour), true, day(6)},
+		{"После последней записи", day(8), true, day(6)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, found := series.RateOn(tt.date)
+			if found != tt.wantFound {
+				t.Fatalf("RateOn() found = %v, ожидается %v", found, tt.wantFound)
+			}
+			if found && !rate.Date.Equal(tt.wantDate) {
+				t.Errorf("RateOn() дата = %v, ожидается %v", rate.Date, tt.wantDate)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// CurrencyInfo описывает валюту из справочника ЦБ РФ
+type CurrencyInfo struct {
+	Code    Currency // Буквенный код ISO 4217 (CharCode)
+	NumCode string   // Цифровой код ISO 4217 (NumCode)
+	CBRID   string   // Внутренний идентификатор ЦБ РФ (атрибут ID у Valute, например R01235)
+	Name    string   // Название на русском
+	Nominal int      // Номинал, за который ЦБ публикует курс (0 - ещё неизвестен)
+	Symbol  string   // Символ валюты (пустая строка, если общепринятого символа нет)
+}
+
+// Registry - потокобезопасный справочник валют
+// Заполняется встроенными данными и дополняется из ежедневного XML ЦБ РФ
+type Registry struct {
+	mu    sync.RWMutex
+	items map[Currency]CurrencyInfo
+}
+
+// NewRegistry создает справочник, заполненный переданными записями
+func NewRegistry(seed ...CurrencyInfo) *Registry {
+	r := &Registry{
+		items: make(map[Currency]CurrencyInfo, len(seed)),
+	}
+	for _, info := range seed {
+		r.Register(info)
+	}
+	return r
+}
+
+// Register добавляет или обновляет запись о валюте
+// Пустые поля новой записи не затирают уже известные значения
+// (например, символ валюты, которого нет в XML ЦБ РФ)
+// Записи с некорректным кодом игнорируются
+func (r *Registry) Register(info CurrencyInfo) {
+	if !IsCurrencyCode(string(info.Code)) {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.items[info.Code]; ok {
+		if info.NumCode == "" {
+			info.NumCode = existing.NumCode
+		}
+		if info.CBRID == "" {
+			info.CBRID = existing.CBRID
+		}
+		if info.Name == "" {
+			info.Name = existing.Name
+		}
+		if info.Nominal <= 0 {
+			info.Nominal = existing.Nominal
+		}
+		if info.Symbol == "" {
+			info.Symbol = existing.Symbol
+		}
+	}
+	r.items[info.Code] = info
+}
+
+// Lookup возвращает запись о валюте, если она есть в справочнике
+func (r *Registry) Lookup(c Currency) (CurrencyInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	info, ok := r.items[c]
+	return info, ok
+}
+
+// Validate проверяет, что валюта есть в справочнике
+func (r *Registry) Validate(c Currency) error {
+	if _, ok := r.Lookup(c); !ok {
+		return fmt.Errorf("%w: %s", ErrUnsupportedCurrency, c)
+	}
+	return nil
+}
+
+// Parse парсит строку в Currency (без пробелов, в верхнем регистре) и проверяет её по справочнику
+func (r *Registry) Parse(s string) (Currency, error) {
+	currency := Currency(strings.ToUpper(strings.TrimSpace(s)))
+	if err := r.Validate(currency); err != nil {
+		return "", err
+	}
+	return currency, nil
+}
+
+// All возвращает все валюты справочника, отсортированные по коду
+func (r *Registry) All() []CurrencyInfo {
+	r.mu.RLock()
+	result := make([]CurrencyInfo, 0, len(r.items))
+	for _, info := range r.items {
+		result = append(result, info)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Code < result[j].Code
+	})
+	return result
+}
+
+// builtinCurrencies - валюты, которые ЦБ РФ публикует в XML_daily.asp, и драгоценные металлы
+// Нужны, чтобы валюту можно было выбрать до первого обращения к API;
+// номинал и актуальное название приходят из XML при загрузке курсов
+var builtinCurrencies = []CurrencyInfo{
+	{Code: RUB, NumCode: "643", Name: "Российский рубль", Nominal: 1, Symbol: "₽"},
+	{Code: USD, NumCode: "840", CBRID: "R01235", Name: "Доллар США", Symbol: "$"},
+	{Code: EUR, NumCode: "978", CBRID: "R01239", Name: "Евро", Symbol: "€"},
+	{Code: "AUD", NumCode: "036", CBRID: "R01010", Name: "Австралийский доллар"},
+	{Code: "AZN", NumCode: "944", CBRID: "R01020A", Name: "Азербайджанский манат"},
+	{Code: "GBP", NumCode: "826", CBRID: "R01035", Name: "Фунт стерлингов Соединенного королевства", Symbol: "£"},
+	{Code: "AMD", NumCode: "051", CBRID: "R01060", Name: "Армянских драмов"},
+	{Code: "BYN", NumCode: "933", CBRID: "R01090B", Name: "Белорусский рубль"},
+	{Code: "BGN", NumCode: "975", CBRID: "R01100", Name: "Болгарский лев"},
+	{Code: "BRL", NumCode: "986", CBRID: "R01115", Name: "Бразильский реал"},
+	{Code: "HUF", NumCode: "348", CBRID: "R01135", Name: "Венгерских форинтов"},
+	{Code: "VND", NumCode: "704", CBRID: "R01150", Name: "Вьетнамских донгов"},
+	{Code: "HKD", NumCode: "344", CBRID: "R01200", Name: "Гонконгский доллар"},
+	{Code: "GEL", NumCode: "981", CBRID: "R01210", Name: "Грузинский лари"},
+	{Code: "DKK", NumCode: "208", CBRID: "R01215", Name: "Датская крона"},
+	{Code: "AED", NumCode: "784", CBRID: "R01230", Name: "Дирхам ОАЭ"},
+	{Code: "EGP", NumCode: "818", CBRID: "R01240", Name: "Египетских фунтов"},
+	{Code: "INR", NumCode: "356", CBRID: "R01270", Name: "Индийских рупий"},
+	{Code: "IDR", NumCode: "360", CBRID: "R01280", Name: "Индонезийских рупий"},
+	{Code: "KZT", NumCode: "398", CBRID: "R01335", Name: "Казахстанских тенге"},
+	{Code: "CAD", NumCode: "124", CBRID: "R01350", Name: "Канадский доллар"},
+	{Code: "QAR", NumCode: "634", CBRID: "R01355", Name: "Катарский риал"},
+	{Code: "KGS", NumCode: "417", CBRID: "R01370", Name: "Киргизских сомов"},
+	{Code: "CNY", NumCode: "156", CBRID: "R01375", Name: "Китайский юань"},
+	{Code: "MDL", NumCode: "498", CBRID: "R01500", Name: "Молдавских леев"},
+	{Code: "NZD", NumCode: "554", CBRID: "R01530", Name: "Новозеландский доллар"},
+	{Code: "NOK", NumCode: "578", CBRID: "R01535", Name: "Норвежских крон"},
+	{Code: "PLN", NumCode: "985", CBRID: "R01565", Name: "Польский злотый"},
+	{Code: "RON", NumCode: "946", CBRID: "R01585F", Name: "Румынский лей"},
+	{Code: "XDR", NumCode: "960", CBRID: "R01589", Name: "СДР (специальные права заимствования)"},
+	{Code: "SGD", NumCode: "702", CBRID: "R01625", Name: "Сингапурский доллар"},
+	{Code: "TJS", NumCode: "972", CBRID: "R01670", Name: "Таджикских сомони"},
+	{Code: "THB", NumCode: "764", CBRID: "R01675", Name: "Таиландских батов"},
+	{Code: "TRY", NumCode: "949", CBRID: "R01700J", Name: "Турецких лир"},
+	{Code: "TMT", NumCode: "934", CBRID: "R01710A", Name: "Новый туркменский манат"},
+	{Code: "UZS", NumCode: "860", CBRID: "R01717", Name: "Узбекских сумов"},
+	{Code: "UAH", NumCode: "980", CBRID: "R01720", Name: "Украинских гривен"},
+	{Code: "CZK", NumCode: "203", CBRID: "R01760", Name: "Чешских крон"},
+	{Code: "SEK", NumCode: "752", CBRID: "R01770", Name: "Шведских крон"},
+	{Code: "CHF", NumCode: "756", CBRID: "R01775", Name: "Швейцарский франк"},
+	{Code: "RSD", NumCode: "941", CBRID: "R01805F", Name: "Сербских динаров"},
+	{Code: "ZAR", NumCode: "710", CBRID: "R01810", Name: "Южноафриканских рэндов"},
+	{Code: "KRW", NumCode: "410", CBRID: "R01815", Name: "Вон Республики Корея"},
+	{Code: "JPY", NumCode: "392", CBRID: "R01820", Name: "Японских иен"},
+
+	// Драгоценные металлы из xml_metall.asp: учетная цена за грамм, ID ЦБ РФ нет
+	{Code: XAU, NumCode: "959", Name: "Золото", Nominal: 1},
+	{Code: XAG, NumCode: "961", Name: "Серебро", Nominal: 1},
+	{Code: XPT, NumCode: "962", Name: "Платина", Nominal: 1},
+	{Code: XPD, NumCode: "964", Name: "Палладий", Nominal: 1},
+}
+
+// defaultRegistry - справочник валют приложения
+var defaultRegistry = NewRegistry(builtinCurrencies...)
+
+// RegisterCurrency добавляет или обновляет валюту в справочнике приложения
+// Вызывается парсером для каждой валюты из XML ЦБ РФ; валюты других источников
+// (например, ЕЦБ) хранятся в собственных справочниках и сюда не попадают
+func RegisterCurrency(info CurrencyInfo) {
+	defaultRegistry.Register(info)
+}
+
+// LookupCurrency возвращает сведения о валюте из справочника приложения
+func LookupCurrency(c Currency) (CurrencyInfo, bool) {
+	return defaultRegistry.Lookup(c)
+}
+
+// Currencies возвращает все известные валюты, отсортированные по коду
+func Currencies() []CurrencyInfo {
+	return defaultRegistry.All()
+}
@@ -0,0 +1,132 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRegistryRegisterAndLookup(t *testing.T) {
+	r := NewRegistry()
+
+	r.Register(CurrencyInfo{Code: "CNY", NumCode: "156", CBRID: "R01375", Name: "Китайский юань", Nominal: 1})
+
+	info, ok := r.Lookup("CNY")
+	if !ok {
+		t.Fatal("Lookup() не нашел CNY")
+	}
+	if info.CBRID != "R01375" || info.NumCode != "156" || info.Nominal != 1 {
+		t.Errorf("Lookup() = %+v", info)
+	}
+
+	if _, ok := r.Lookup("KZT"); ok {
+		t.Error("Lookup() нашел незарегистрированную валюту KZT")
+	}
+}
+
+func TestRegistryRegisterKeepsKnownFields(t *testing.T) {
+	r := NewRegistry(CurrencyInfo{Code: USD, CBRID: "R01235", Name: "Доллар США", Symbol: "$"})
+
+	// В XML ЦБ РФ нет символа валюты - он не должен затираться
+	r.Register(CurrencyInfo{Code: USD, NumCode: "840", Name: "Доллар США", Nominal: 1})
+
+	info, _ := r.Lookup(USD)
+	if info.Symbol != "$" {
+		t.Errorf("Symbol = %q, ожидается $", info.Symbol)
+	}
+	if info.CBRID != "R01235" {
+		t.Errorf("CBRID = %q, ожидается R01235", info.CBRID)
+	}
+	if info.NumCode != "840" || info.Nominal != 1 {
+		t.Errorf("Register() не обновил поля: %+v", info)
+	}
+}
+
+func TestRegistryRegisterIgnoresInvalidCode(t *testing.T) {
+	r := NewRegistry()
+
+	for _, code := range []Currency{"", "usd", "US", "USDT", "U$D"} {
+		r.Register(CurrencyInfo{Code: code, Name: "test"})
+	}
+
+	if got := len(r.All()); got != 0 {
+		t.Errorf("All() len = %d, ожидается 0", got)
+	}
+}
+
+func TestRegistryParse(t *testing.T) {
+	r := NewRegistry(CurrencyInfo{Code: EUR}, CurrencyInfo{Code: "ISK"})
+
+	tests := []struct {
+		input   string
+		want    Currency
+		wantErr bool
+	}{
+		{" isk ", "ISK", false},
+		{"EUR", EUR, false},
+		{"USD", "", true}, // Есть в справочнике ЦБ РФ, но не в этом справочнике
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := r.Parse(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("Parse(%q) = %q, %v; ожидалось %q, ошибка %v", tt.input, got, err, tt.want, tt.wantErr)
+		}
+		if err != nil && !errors.Is(err, ErrUnsupportedCurrency) {
+			t.Errorf("Parse(%q) error = %v, ожидалась ErrUnsupportedCurrency", tt.input, err)
+		}
+	}
+}
+
+func TestRegistryAllSorted(t *testing.T) {
+	r := NewRegistry(
+		CurrencyInfo{Code: USD},
+		CurrencyInfo{Code: "CNY"},
+		CurrencyInfo{Code: EUR},
+	)
+
+	all := r.All()
+	want := []Currency{"CNY", EUR, USD}
+	if len(all) != len(want) {
+		t.Fatalf("All() len = %d, ожидается %d", len(all), len(want))
+	}
+	for i, c := range want {
+		if all[i].Code != c {
+			t.Errorf("All()[%d] = %s, ожидается %s", i, all[i].Code, c)
+		}
+	}
+}
+
+func TestBuiltinCurrencies(t *testing.T) {
+	for _, c := range []Currency{USD, EUR, RUB, "CNY", "KZT", "GBP", "TRY", XAU, XAG, XPT, XPD} {
+		if err := c.Validate(); err != nil {
+			t.Errorf("%s.Validate() = %v, ожидается nil", c, err)
+		}
+	}
+
+	info, ok := LookupCurrency(USD)
+	if !ok || info.CBRID != "R01235" {
+		t.Errorf("LookupCurrency(USD) = %+v, %v", info, ok)
+	}
+}
+
+func TestIsCurrencyCode(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"USD", true},
+		{"CNY", true},
+		{"usd", false},
+		{"US", false},
+		{"USDT", false},
+		{"U1D", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsCurrencyCode(tt.input); got != tt.want {
+			t.Errorf("IsCurrencyCode(%q) = %v, ожидается %v", tt.input, got, tt.want)
+		}
+	}
+}
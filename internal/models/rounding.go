@@ -0,0 +1,61 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Ошибки политики округления
+var (
+	ErrInvalidRounding = errors.New("некорректная политика округления")
+)
+
+// MaxRoundingPlaces - максимальное количество знаков после запятой в результате
+const MaxRoundingPlaces = 8
+
+// RoundingMode задаёт правило округления отброшенной части числа
+type RoundingMode string
+
+// Поддерживаемые режимы округления
+const (
+	RoundHalfUp   RoundingMode = "halfUp"   // Половина - от нуля: 2,345 → 2,35 (по умолчанию, бухгалтерский учёт)
+	RoundHalfEven RoundingMode = "halfEven" // Половина - к чётному: 2,345 → 2,34, 2,355 → 2,36 (банковское)
+	RoundDown     RoundingMode = "down"     // Отбрасывание (к нулю): 2,349 → 2,34
+	RoundUp       RoundingMode = "up"       // От нуля при любом остатке: 2,341 → 2,35
+)
+
+// ParseRoundingMode парсит строку в RoundingMode
+// Пустая строка означает режим по умолчанию (RoundHalfUp)
+func ParseRoundingMode(s string) (RoundingMode, error) {
+	switch mode := RoundingMode(strings.TrimSpace(s)); mode {
+	case "":
+		return RoundHalfUp, nil
+	case RoundHalfUp, RoundHalfEven, RoundDown, RoundUp:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%w: неизвестный режим %s", ErrInvalidRounding, s)
+	}
+}
+
+// Rounding - политика округления результата конвертации
+type Rounding struct {
+	Mode   RoundingMode // Режим округления
+	Places int32        // Количество знаков после запятой (0 - до целых рублей)
+}
+
+// Validate проверяет, что режим известен, а количество знаков в допустимом диапазоне
+func (r Rounding) Validate() error {
+	if _, err := ParseRoundingMode(string(r.Mode)); err != nil || r.Mode == "" {
+		return fmt.Errorf("%w: неизвестный режим %q", ErrInvalidRounding, r.Mode)
+	}
+	if r.Places < 0 || r.Places > MaxRoundingPlaces {
+		return fmt.Errorf("%w: количество знаков %d вне диапазона 0..%d", ErrInvalidRounding, r.Places, MaxRoundingPlaces)
+	}
+	return nil
+}
+
+// Apply округляет число по политике
+func (r Rounding) Apply(d Decimal) Decimal {
+	return d.RoundMode(r.Places, r.Mode)
+}
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseRoundingMode(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    RoundingMode
+		wantErr bool
+	}{
+		{input: "", want: RoundHalfUp},
+		{input: "halfUp", want: RoundHalfUp},
+		{input: " halfEven ", want: RoundHalfEven},
+		{input: "down", want: RoundDown},
+		{input: "up", want: RoundUp},
+		{input: "ceiling", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseRoundingMode(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidRounding) {
+					t.Fatalf("ParseRoundingMode(%q) ошибка = %v, ожидалась ErrInvalidRounding", tt.input, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ParseRoundingMode(%q) = %q, %v; ожидается %q", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundingValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		rounding Rounding
+		wantErr  bool
+	}{
+		{name: "До копеек", rounding: Rounding{Mode: RoundHalfUp, Places: 2}},
+		{name: "До целых", rounding: Rounding{Mode: RoundDown, Places: 0}},
+		{name: "Максимум знаков", rounding: Rounding{Mode: RoundUp, Places: MaxRoundingPlaces}},
+		{name: "Пустой режим", rounding: Rounding{Places: 2}, wantErr: true},
+		{name: "Неизвестный режим", rounding: Rounding{Mode: "ceiling", Places: 2}, wantErr: true},
+		{name: "Отрицательные знаки", rounding: Rounding{Mode: RoundHalfUp, Places: -1}, wantErr: true},
+		{name: "Слишком много знаков", rounding: Rounding{Mode: RoundHalfUp, Places: MaxRoundingPlaces + 1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rounding.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() ошибка = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, ErrInvalidRounding) {
+				t.Errorf("Validate() ошибка = %v, ожидалась ErrInvalidRounding", err)
+			}
+		})
+	}
+}
+
+func TestRoundingApply(t *testing.T) {
+	tests := []struct {
+		input string
+		mode  RoundingMode
+		want  string
+	}{
+		{input: "2.345", mode: RoundHalfUp, want: "2.35"},
+		{input: "2.345", mode: RoundHalfEven, want: "2.34"},
+		{input: "2.355", mode: RoundHalfEven, want: "2.36"},
+		{input: "2.3451", mode: RoundHalfEven, want: "2.35"},
+		{input: "2.349", mode: RoundDown, want: "2.34"},
+		{input: "2.341", mode: RoundUp, want: "2.35"},
+		{input: "-2.345", mode: RoundHalfUp, want: "-2.35"},
+		{input: "-2.345", mode: RoundHalfEven, want: "-2.34"},
+		{input: "-2.349", mode: RoundDown, want: "-2.34"},
+		{input: "-2.341", mode: RoundUp, want: "-2.35"},
+		{input: "2.34", mode: RoundUp, want: "2.34"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode)+" "+tt.input, func(t *testing.T) {
+			got := Rounding{Mode: tt.mode, Places: 2}.Apply(MustParseDecimal(tt.input))
+			if got.String() != tt.want {
+				t.Errorf("Apply(%s) = %s, ожидается %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimalDivRound(t *testing.T) {
+	tests := []struct {
+		mode RoundingMode
+		want string
+	}{
+		{mode: RoundHalfUp, want: "0.13"},
+		{mode: RoundHalfEven, want: "0.12"},
+		{mode: RoundDown, want: "0.12"},
+		{mode: RoundUp, want: "0.13"},
+	}
+
+	// 1 / 8 = 0.125 - ровно половина на третьем знаке
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			got := MustParseDecimal("1").DivRound(MustParseDecimal("8"), 2, tt.mode)
+			if got.String() != tt.want {
+				t.Errorf("1 / 8 (%s) = %s, ожидается %s", tt.mode, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Параметры circuit breaker по умолчанию
+const (
+	// DefaultFailureThreshold - сколько запросов подряд должно завершиться сбоем, чтобы breaker открылся
+	DefaultFailureThreshold = 3
+
+	// DefaultCoolDown - сколько breaker остается открытым перед пробным запросом
+	DefaultCoolDown = 30 * time.Second
+)
+
+// ErrSourceUnavailable - источник недоступен: breaker открыт, запрос не выполнялся
+var ErrSourceUnavailable = errors.New("source temporarily unavailable")
+
+// BreakerState - состояние circuit breaker
+type BreakerState int
+
+const (
+	// BreakerClosed - запросы выполняются, сбои подряд считаются
+	BreakerClosed BreakerState = iota
+	// BreakerOpen - запросы отклоняются с ErrSourceUnavailable до истечения cool-down
+	BreakerOpen
+	// BreakerHalfOpen - cool-down истек, выполняется один пробный запрос
+	BreakerHalfOpen
+)
+
+// String возвращает название состояния
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("BreakerState(%d)", int(s))
+	}
+}
+
+// breakerNow возвращает текущее время (подменяется в тестах)
+var breakerNow = time.Now
+
+// CircuitBreaker - circuit breaker одного источника
+// Когда cbr.ru недоступен, каждый запрос тратит секунды на повторы; после threshold сбоев подряд
+// breaker открывается, и запросы сразу завершаются ErrSourceUnavailable (ProviderChain
+// переходит к резервному источнику без ожидания). По истечении cool-down выполняется один
+// пробный запрос: успех закрывает breaker, сбой открывает его снова
+// Сбоем считаются только недоступность сервера (сетевые ошибки, 5xx, исчерпанные повторы, таймаут):
+// ответы 4xx и некорректный XML означают, что сервер работает
+// Безопасен для конкурентного использования
+type CircuitBreaker struct {
+	threshold int
+	coolDown  time.Duration
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool // Пробный запрос в состоянии half-open уже выполняется
+}
+
+// NewCircuitBreaker создает breaker в состоянии closed
+// threshold и coolDown <= 0 заменяются DefaultFailureThreshold и DefaultCoolDown
+func NewCircuitBreaker(threshold int, coolDown time.Duration) *CircuitBreaker {
+	if threshold <= 0 {
+		threshold = DefaultFailureThreshold
+	}
+	if coolDown <= 0 {
+		coolDown = DefaultCoolDown
+	}
+	return &CircuitBreaker{threshold: threshold, coolDown: coolDown}
+}
+
+// State возвращает текущее состояние breaker
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerOpen && !breakerNow().Before(b.openedAt.Add(b.coolDown)) {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// allow разрешает запрос или возвращает ErrSourceUnavailable
+// Разрешенный запрос должен завершиться вызовом done
+func (b *CircuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen {
+		retryIn := b.openedAt.Add(b.coolDown).Sub(breakerNow())
+		if retryIn > 0 {
+			return fmt.Errorf("%w: circuit breaker open, retry in %s", ErrSourceUnavailable, retryIn.Round(time.Second))
+		}
+		b.state = BreakerHalfOpen
+	}
+	if b.state == BreakerHalfOpen {
+		if b.probing {
+			return fmt.Errorf("%w: circuit breaker half-open, probe in progress", ErrSourceUnavailable)
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// done учитывает результат разрешенного запроса
+func (b *CircuitBreaker) done(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	switch {
+	case isSourceFailure(err):
+		b.failures++
+		if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+			b.state = BreakerOpen
+			b.openedAt = breakerNow()
+		}
+	case errors.Is(err, context.Canceled):
+		// Запрос отменен вызывающим - о доступности источника ничего не известно
+	default:
+		b.state = BreakerClosed
+		b.failures = 0
+	}
+}
+
+// hostBreakers - circuit breaker каждого хоста, к которому обращается клиент
+// XML API, динамика курса, цены металлов и SOAP сервис ЦБ РФ на одном хосте (www.cbr.ru) делят
+// один breaker: когда сервер недоступен, ни один из запросов не ждет исчерпания повторов
+// Breaker хоста создается при первом запросе с DefaultFailureThreshold и DefaultCoolDown
+// Безопасен для конкурентного использования
+type hostBreakers struct {
+	mu     sync.Mutex
+	byHost map[string]*CircuitBreaker
+}
+
+// newHostBreakers создает набор breaker; breaker (если не nil) закрепляется за хостом rawURL
+func newHostBreakers(rawURL string, breaker *CircuitBreaker) *hostBreakers {
+	b := &hostBreakers{byHost: make(map[string]*CircuitBreaker)}
+	if breaker != nil {
+		b.byHost[breakerHost(rawURL)] = breaker
+	}
+	return b
+}
+
+// forURL возвращает breaker хоста rawURL
+func (b *hostBreakers) forURL(rawURL string) *CircuitBreaker {
+	host := breakerHost(rawURL)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	breaker, ok := b.byHost[host]
+	if !ok {
+		breaker = NewCircuitBreaker(DefaultFailureThreshold, DefaultCoolDown)
+		b.byHost[host] = breaker
+	}
+	return breaker
+}
+
+// breakerHost возвращает хост URL без учета регистра (некорректный URL - сам URL)
+func breakerHost(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return rawURL
+	}
+	return strings.ToLower(parsed.Host)
+}
+
+// isSourceFailure сообщает, означает ли ошибка недоступность источника
+// Истекший таймаут (в том числе таймаут источника в ProviderChain) - тоже сбой: сервер не ответил
+func isSourceFailure(err error) bool {
+	return errors.Is(err, ErrHTTPFailed) || errors.Is(err, ErrMaxRetries) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,176 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+func setTestBreakerNow(t *testing.T, now *time.Time) {
+	t.Helper()
+	original := breakerNow
+	breakerNow = func() time.Time { return *now }
+	t.Cleanup(func() {
+		breakerNow = original
+	})
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2025, 12, 20, 12, 0, 0, 0, time.UTC)
+	setTestBreakerNow(t, &now)
+
+	serverDown := fmt.Errorf("%w: connection refused", ErrHTTPFailed)
+	breaker := NewCircuitBreaker(2, time.Minute)
+
+	// Один сбой и ответ 4xx не открывают breaker; 4xx сбрасывает счетчик сбоев
+	for _, err := range []error{serverDown, &StatusError{StatusCode: http.StatusNotFound}, serverDown} {
+		if allowErr := breaker.allow(); allowErr != nil {
+			t.Fatalf("allow() = %v в состоянии closed", allowErr)
+		}
+		breaker.done(err)
+	}
+	if breaker.State() != BreakerClosed {
+		t.Fatalf("State() = %v, ожидалось closed", breaker.State())
+	}
+
+	// Второй сбой подряд открывает breaker: запросы отклоняются без обращения к источнику
+	_ = breaker.allow()
+	breaker.done(serverDown)
+	if err := breaker.allow(); !errors.Is(err, ErrSourceUnavailable) {
+		t.Fatalf("allow() = %v, ожидалась ErrSourceUnavailable", err)
+	}
+	if breaker.State() != BreakerOpen {
+		t.Fatalf("State() = %v, ожидалось open", breaker.State())
+	}
+
+	// По истечении cool-down - один пробный запрос; его сбой снова открывает breaker
+	now = now.Add(time.Minute)
+	if breaker.State() != BreakerHalfOpen {
+		t.Fatalf("State() = %v, ожидалось half-open", breaker.State())
+	}
+	if err := breaker.allow(); err != nil {
+		t.Fatalf("Пробный запрос отклонен: %v", err)
+	}
+	if err := breaker.allow(); !errors.Is(err, ErrSourceUnavailable) {
+		t.Fatalf("Второй запрос во время пробного: %v, ожидалась ErrSourceUnavailable", err)
+	}
+	breaker.done(context.DeadlineExceeded)
+	if breaker.State() != BreakerOpen {
+		t.Fatalf("State() = %v после сбоя пробного запроса, ожидалось open", breaker.State())
+	}
+
+	// Отмена пробного запроса освобождает его, успешный пробный запрос закрывает breaker
+	now = now.Add(time.Minute)
+	_ = breaker.allow()
+	breaker.done(context.Canceled)
+	if err := breaker.allow(); err != nil {
+		t.Fatalf("Пробный запрос после отмены отклонен: %v", err)
+	}
+	breaker.done(nil)
+	if breaker.State() != BreakerClosed {
+		t.Errorf("State() = %v после успешного пробного запроса, ожидалось closed", breaker.State())
+	}
+}
+
+func TestCBRClient_CircuitBreaker(t *testing.T) {
+	setTestSleep(t)
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewCBRClient(CBRClientOptions{
+		BaseURL: server.URL,
+		Retry:   RetryPolicy{MaxAttempts: 1},
+		Breaker: NewCircuitBreaker(2, time.Hour),
+	})
+	date := testPastDateUTC()
+	for i := 0; i < 2; i++ {
+		if _, err := client.FetchRates(context.Background(), date); !errors.Is(err, ErrMaxRetries) {
+			t.Fatalf("FetchRates() error = %v, ожидалась ErrMaxRetries", err)
+		}
+	}
+
+	// Breaker открыт: запрос завершается сразу, сервер не опрашивается
+	if _, err := client.FetchRates(context.Background(), date); !errors.Is(err, ErrSourceUnavailable) {
+		t.Fatalf("FetchRates() error = %v, ожидалась ErrSourceUnavailable", err)
+	}
+	if attempts != 2 {
+		t.Errorf("Запросов к серверу: %d, ожидалось 2", attempts)
+	}
+}
+
+func TestCBRClient_CircuitBreakerPerHost(t *testing.T) {
+	setTestSleep(t)
+
+	newDownServer := func(attempts *int) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*attempts++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+	var cbrAttempts, otherAttempts int
+	cbr := newDownServer(&cbrAttempts)
+	other := newDownServer(&otherAttempts)
+
+	client := NewCBRClient(CBRClientOptions{
+		BaseURL: cbr.URL + "/scripts/XML_daily.asp",
+		Retry:   RetryPolicy{MaxAttempts: 1},
+		Breaker: NewCircuitBreaker(2, time.Hour),
+	})
+	ctx := context.Background()
+	date := testPastDateUTC()
+	for i := 0; i < 2; i++ {
+		if _, err := client.FetchRates(ctx, date); !errors.Is(err, ErrMaxRetries) {
+			t.Fatalf("FetchRates() error = %v, ожидалась ErrMaxRetries", err)
+		}
+	}
+
+	// Breaker хоста открыт: SOAP, динамика и металлы на том же хосте не ждут повторов
+	soap := NewSOAPClient(cbr.URL + "/DailyInfoWebServ/DailyInfo.asmx").WithClient(client)
+	if _, err := soap.FetchRates(ctx, date); !errors.Is(err, ErrSourceUnavailable) {
+		t.Errorf("SOAP FetchRates() error = %v, ожидалась ErrSourceUnavailable", err)
+	}
+	if _, err := client.fetchMetalRatesFromURL(ctx, cbr.URL+"/scripts/xml_metall.asp", date); !errors.Is(err, ErrSourceUnavailable) {
+		t.Errorf("fetchMetalRatesFromURL() error = %v, ожидалась ErrSourceUnavailable", err)
+	}
+	if _, err := client.fetchRateSeriesFromURL(ctx, cbr.URL+"/scripts/XML_dynamic.asp", models.USD, date, date); !errors.Is(err, ErrSourceUnavailable) {
+		t.Errorf("fetchRateSeriesFromURL() error = %v, ожидалась ErrSourceUnavailable", err)
+	}
+	if cbrAttempts != 2 {
+		t.Errorf("Запросов к хосту с открытым breaker: %d, ожидалось 2", cbrAttempts)
+	}
+
+	// У другого хоста свой breaker: запрос выполняется
+	if _, err := client.fetchXML(ctx, other.URL); !errors.Is(err, ErrMaxRetries) {
+		t.Errorf("fetchXML() к другому хосту: error = %v, ожидалась ErrMaxRetries", err)
+	}
+	if otherAttempts != 1 {
+		t.Errorf("Запросов к другому хосту: %d, ожидался 1", otherAttempts)
+	}
+}
+
+func TestBreakerState_String(t *testing.T) {
+	tests := map[BreakerState]string{
+		BreakerClosed:   "closed",
+		BreakerOpen:     "open",
+		BreakerHalfOpen: "half-open",
+		BreakerState(7): "BreakerState(7)",
+	}
+	for state, want := range tests {
+		if got := state.String(); got != want {
+			t.Errorf("String() = %q, ожидалось %q", got, want)
+		}
+	}
+}
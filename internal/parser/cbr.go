@@ -4,12 +4,14 @@ package parser
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/bivlked/currate-go/internal/models"
 )
 
 // FetchRates получает курсы валют с сайта ЦБ РФ на указанную дату
+// Тонкая обертка над CBRClient с настройками по умолчанию
 // ctx - контекст для отмены запроса
 // date - дата, на которую нужно получить курсы валют
 // Возвращает *models.RateData с курсами валют или ошибку
@@ -23,26 +25,56 @@ import (
 //	}
 //	fmt.Printf("USD курс: %.4f\n", rates.Rates[models.USD].Rate)
 func FetchRates(ctx context.Context, date time.Time) (*models.RateData, error) {
-	// Строим URL для запроса с датой
-	url := buildURL(date)
-	return fetchRatesFromURL(ctx, url, date)
+	return defaultClient().FetchRates(ctx, date)
 }
 
-// fetchRatesFromURL - внутренняя функция для получения курсов с произвольного URL
-// Используется для тестирования и внутри FetchRates
+// FetchRates получает курсы валют на дату с BaseURL клиента
+// Реализует интерфейс converter.RateProvider
+// Пока circuit breaker хоста BaseURL открыт, сразу возвращает ErrSourceUnavailable
+func (c *CBRClient) FetchRates(ctx context.Context, date time.Time) (*models.RateData, error) {
+	return c.fetchRatesFromURL(ctx, dailyURL(c.baseURL, date), date, nil)
+}
+
+// FetchRatesConditional получает курсы на дату условным запросом с валидаторами снимка cached
+// (If-None-Match / If-Modified-Since); возвращает models.ErrNotModified, если курсы не изменились
+// Снимок без валидаторов - безусловный запрос, как FetchRates
+// Реализует интерфейс converter.ConditionalRateProvider
+func (c *CBRClient) FetchRatesConditional(ctx context.Context, date time.Time, cached *models.RateData) (*models.RateData, error) {
+	return c.fetchRatesFromURL(ctx, dailyURL(c.baseURL, date), date, cached)
+}
+
+// fetchRatesFromURL - внутренняя функция для получения курсов с произвольного URL клиентом по умолчанию
+// Используется для тестирования
 func fetchRatesFromURL(ctx context.Context, url string, date time.Time) (*models.RateData, error) {
+	return defaultClient().fetchRatesFromURL(ctx, url, date, nil)
+}
+
+// fetchRatesFromURL получает и парсит курсы с произвольного URL
+// cached - снимок для условного запроса (nil - запрос безусловный)
+func (c *CBRClient) fetchRatesFromURL(ctx context.Context, url string, date time.Time, cached *models.RateData) (*models.RateData, error) {
 	// Выполняем HTTP запрос с retry логикой и exponential backoff
-	body, err := fetchXML(ctx, url)
+	// Запрос со снимком, у которого есть валидаторы, условный (If-None-Match / If-Modified-Since)
+	if cached != nil && cached.ETag == "" && cached.LastModified == "" {
+		cached = nil
+	}
+	resp, err := c.fetch(ctx, url, cached)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch rates from CBR: %w", err)
 	}
-	defer body.Close()
+	defer resp.Body.Close()
+
+	// 304 Not Modified: документ не изменился, снимок в кэше конвертера актуален
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, models.ErrNotModified
+	}
 
 	// Парсим XML в структуру данных
-	data, err := ParseXML(body, date)
+	data, err := ParseXML(resp.Body, date)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse CBR XML rates: %w", err)
 	}
 
+	data.ETag = resp.Header.Get("ETag")
+	data.LastModified = resp.Header.Get("Last-Modified")
 	return data, nil
 }
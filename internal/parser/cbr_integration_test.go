@@ -44,26 +44,26 @@ func TestFetchRatesIntegration(t *testing.T) {
 			t.Error("USD должен присутствовать в результатах")
 		} else {
 			// Проверяем, что курс в разумных пределах (не ноль, не отрицательный)
-			if usd.Rate <= 0 {
-				t.Errorf("USD курс должен быть положительным, получено: %.4f", usd.Rate)
+			if usd.Rate.Sign() <= 0 {
+				t.Errorf("USD курс должен быть положительным, получено: %s", usd.Rate)
 			}
 			if usd.Nominal != 1 {
 				t.Errorf("USD nominal должен быть 1, получено: %d", usd.Nominal)
 			}
-			t.Logf("USD курс: %.4f (номинал: %d)", usd.Rate, usd.Nominal)
+			t.Logf("USD курс: %s (номинал: %d)", usd.Rate, usd.Nominal)
 		}
 
 		eur, hasEUR := data.Rates[models.EUR]
 		if !hasEUR {
 			t.Error("EUR должен присутствовать в результатах")
 		} else {
-			if eur.Rate <= 0 {
-				t.Errorf("EUR курс должен быть положительным, получено: %.4f", eur.Rate)
+			if eur.Rate.Sign() <= 0 {
+				t.Errorf("EUR курс должен быть положительным, получено: %s", eur.Rate)
 			}
 			if eur.Nominal != 1 {
 				t.Errorf("EUR nominal должен быть 1, получено: %d", eur.Nominal)
 			}
-			t.Logf("EUR курс: %.4f (номинал: %d)", eur.Rate, eur.Nominal)
+			t.Logf("EUR курс: %s (номинал: %d)", eur.Rate, eur.Nominal)
 		}
 
 		// Проверяем, что есть хотя бы одна валюта с номиналом > 1
@@ -71,7 +71,7 @@ func TestFetchRatesIntegration(t *testing.T) {
 		for code, rate := range data.Rates {
 			if rate.Nominal > 1 {
 				foundMultiNominal = true
-				t.Logf("Валюта с номиналом > 1: %s курс: %.4f (номинал: %d)", code, rate.Rate, rate.Nominal)
+				t.Logf("Валюта с номиналом > 1: %s курс: %s (номинал: %d)", code, rate.Rate, rate.Nominal)
 				break
 			}
 		}
@@ -141,7 +141,7 @@ func TestXMLAPIResponseFormat(t *testing.T) {
 			if count >= 5 {
 				break
 			}
-			t.Logf("%s: %.4f (номинал: %d)", code, rate.Rate, rate.Nominal)
+			t.Logf("%s: %s (номинал: %d)", code, rate.Rate, rate.Nominal)
 			count++
 		}
 
@@ -2,14 +2,19 @@ package parser
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/bivlked/currate-go/internal/converter"
 	"github.com/bivlked/currate-go/internal/models"
 )
 
@@ -21,10 +26,11 @@ func (fn roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
 
 func setTestHTTPClientFactory(t *testing.T, rt http.RoundTripper) {
 	t.Helper()
-	originalClient := defaultHTTPClient
+	originalClient, originalBreakers := defaultHTTPClient, defaultBreakers
 	defaultHTTPClient = &http.Client{Transport: rt}
+	defaultBreakers = newHostBreakers(CBRURL, nil)
 	t.Cleanup(func() {
-		defaultHTTPClient = originalClient
+		defaultHTTPClient, defaultBreakers = originalClient, originalBreakers
 	})
 }
 
@@ -92,7 +98,7 @@ func TestFetchRates(t *testing.T) {
 		if !ok {
 			t.Fatal("USD не найден")
 		}
-		if usd.Rate != 80.7220 {
+		if !usd.Rate.Equal(dec("80.7220")) {
 			t.Errorf("USD курс: ожидалось 80.7220, получено %v", usd.Rate)
 		}
 
@@ -101,7 +107,7 @@ func TestFetchRates(t *testing.T) {
 		if !ok {
 			t.Fatal("EUR не найден")
 		}
-		if eur.Rate != 94.5120 {
+		if !eur.Rate.Equal(dec("94.5120")) {
 			t.Errorf("EUR курс: ожидалось 94.5120, получено %v", eur.Rate)
 		}
 	})
@@ -231,7 +237,133 @@ func TestFetchRates_UsesBuildURLAndParsesResponse(t *testing.T) {
 	if !ok {
 		t.Fatal("USD курс не найден")
 	}
-	if usd.Rate != 80.7220 {
+	if !usd.Rate.Equal(dec("80.7220")) {
 		t.Errorf("USD курс = %v, want 80.7220", usd.Rate)
 	}
 }
+
+// CBRClient реализует converter.RateProvider
+var _ converter.RateProvider = (*CBRClient)(nil)
+
+const testDailyXML = `<?xml version="1.0" encoding="UTF-8"?>
+<ValCurs Date="20.12.2025" name="Foreign Currency Market">
+    <Valute ID="R01235">
+        <NumCode>840</NumCode>
+        <CharCode>USD</CharCode>
+        <Nominal>1</Nominal>
+        <Name>Доллар США</Name>
+        <Value>80,7220</Value>
+    </Valute>
+</ValCurs>`
+
+func TestCBRClient_FetchRates(t *testing.T) {
+	var attempts int
+	var userAgent, path, dateReq string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		userAgent, path, dateReq = r.Header.Get("User-Agent"), r.URL.Path, r.URL.Query().Get("date_req")
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(testDailyXML))
+	}))
+	defer server.Close()
+
+	client := NewCBRClient(CBRClientOptions{
+		BaseURL:   server.URL + "/mirror/XML_daily.asp",
+		UserAgent: "corp-gateway/1.0",
+		Retry:     RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond},
+	})
+	date := time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC)
+	data, err := client.FetchRates(context.Background(), date)
+	if err != nil {
+		t.Fatalf("FetchRates() error = %v", err)
+	}
+
+	if attempts != 2 {
+		t.Errorf("Попыток: %d, ожидалось 2", attempts)
+	}
+	if userAgent != "corp-gateway/1.0" || path != "/mirror/XML_daily.asp" || dateReq != "20/12/2025" {
+		t.Errorf("Запрос: User-Agent %q, путь %q, date_req %q", userAgent, path, dateReq)
+	}
+	if usd := data.Rates[models.USD]; !usd.Rate.Equal(dec("80.7220")) {
+		t.Errorf("USD = %s, ожидалось 80.7220", usd.Rate)
+	}
+}
+
+func TestCBRClient_RetryPolicy(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewCBRClient(CBRClientOptions{BaseURL: server.URL, Retry: RetryPolicy{MaxAttempts: 1}})
+	_, err := client.FetchRates(context.Background(), testPastDateUTC())
+	if !errors.Is(err, ErrMaxRetries) || !errors.Is(err, ErrHTTPFailed) {
+		t.Errorf("FetchRates() error = %v, ожидалась ErrMaxRetries с ErrHTTPFailed", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Попыток: %d, ожидалась 1 (без повторов)", attempts)
+	}
+}
+
+func TestCBRClient_ProxyAndTLS(t *testing.T) {
+	date := time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC)
+	noRetry := RetryPolicy{MaxAttempts: 1}
+
+	t.Run("Прокси", func(t *testing.T) {
+		var proxiedHost string
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proxiedHost = r.URL.Host
+			_, _ = w.Write([]byte(testDailyXML))
+		}))
+		defer proxy.Close()
+
+		proxyURL, _ := url.Parse(proxy.URL)
+		client := NewCBRClient(CBRClientOptions{BaseURL: "http://cbr.example/XML_daily.asp", Proxy: proxyURL, Retry: noRetry})
+		if _, err := client.FetchRates(context.Background(), date); err != nil {
+			t.Fatalf("FetchRates() error = %v", err)
+		}
+		if proxiedHost != "cbr.example" {
+			t.Errorf("Прокси получил запрос к %q, ожидался cbr.example", proxiedHost)
+		}
+	})
+
+	t.Run("Собственный корневой сертификат", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(testDailyXML))
+		}))
+		defer server.Close()
+
+		// Без сертификата сервера в RootCAs соединение не устанавливается
+		untrusted := NewCBRClient(CBRClientOptions{BaseURL: server.URL, Retry: noRetry})
+		if _, err := untrusted.FetchRates(context.Background(), date); !errors.Is(err, ErrHTTPFailed) {
+			t.Fatalf("FetchRates() error = %v, ожидалась ErrHTTPFailed", err)
+		}
+
+		roots := x509.NewCertPool()
+		roots.AddCert(server.Certificate())
+		trusted := NewCBRClient(CBRClientOptions{BaseURL: server.URL, TLSConfig: &tls.Config{RootCAs: roots}, Retry: noRetry})
+		if _, err := trusted.FetchRates(context.Background(), date); err != nil {
+			t.Fatalf("FetchRates() error = %v", err)
+		}
+	})
+}
+
+func TestNewCBRClient_Defaults(t *testing.T) {
+	client := NewCBRClient(CBRClientOptions{})
+	if client.baseURL != CBRURL || client.userAgent != UserAgent || client.retry != DefaultRetryPolicy {
+		t.Errorf("Настройки по умолчанию: %+v", client)
+	}
+	if client.httpClient.Timeout != DefaultTimeout || client.httpClient.Transport != nil {
+		t.Error("Без прокси и TLS должен использоваться общий http.DefaultTransport")
+	}
+
+	custom := &http.Client{}
+	if got := NewCBRClient(CBRClientOptions{HTTPClient: custom, Timeout: time.Second}); got.httpClient != custom {
+		t.Error("Переданный HTTPClient должен использоваться как есть")
+	}
+}
@@ -2,11 +2,15 @@ package parser
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
 )
 
 // HTTP константы
@@ -14,6 +18,9 @@ const (
 	// CBRURL - базовый URL XML API ЦБ РФ для получения курсов валют
 	CBRURL = "https://www.cbr.ru/scripts/XML_daily.asp"
 
+	// CBRDynamicURL - URL XML API ЦБ РФ для получения динамики курса за период
+	CBRDynamicURL = "https://www.cbr.ru/scripts/XML_dynamic.asp"
+
 	// DefaultTimeout - таймаут для HTTP запросов
 	DefaultTimeout = 10 * time.Second
 
@@ -54,24 +61,160 @@ func defaultSleepWithContext(ctx context.Context, d time.Duration) error {
 // - Эффективного использования пула соединений
 var defaultHTTPClient = newHTTPClient()
 
-// fetchXML выполняет HTTP GET запрос с retry логикой и exponential backoff
-// ctx - контекст для отмены запросов и backoff ожидания
+// CBRClientOptions - настройки CBRClient
+// Нулевые поля заменяются значениями по умолчанию
+type CBRClientOptions struct {
+	BaseURL    string        // URL XML_daily.asp или его зеркала (по умолчанию CBRURL)
+	DynamicURL string        // URL XML_dynamic.asp - динамика курса (по умолчанию CBRDynamicURL)
+	MetalURL   string        // URL xml_metall.asp - цены драгоценных металлов (по умолчанию CBRMetalURL)
+	HTTPClient *http.Client  // Готовый HTTP клиент; если задан, Timeout, Proxy и TLSConfig не применяются
+	Timeout    time.Duration // Таймаут одного запроса (по умолчанию DefaultTimeout)
+	Retry      RetryStrategy // Стратегия повторов (по умолчанию DefaultRetryPolicy)
+	UserAgent  string        // User-Agent запросов (по умолчанию UserAgent)
+	Proxy      *url.URL      // Прокси (по умолчанию - из переменных окружения HTTPS_PROXY, NO_PROXY)
+	TLSConfig  *tls.Config   // Настройки TLS, например корпоративный корневой сертификат в RootCAs
+
+	// Breaker - circuit breaker хоста BaseURL (по умолчанию - свой breaker клиента
+	// с DefaultFailureThreshold и DefaultCoolDown); один breaker можно разделить между клиентами
+	// Запросы к другим хостам (ЕЦБ, SOAP сервис на другом адресе) проходят через собственные
+	// breaker клиента по хосту, запросы к хосту BaseURL (динамика курса, металлы, SOAP) - через Breaker
+	Breaker *CircuitBreaker
+}
+
+// CBRClient - клиент XML API ЦБ РФ с настраиваемым HTTP клиентом, прокси, TLS и повторами
+// Реализует converter.RateProvider и converter.RateSeriesProvider; parser.FetchRates,
+// parser.FetchRateSeries и parser.FetchMetalRates - тот же клиент с настройками по умолчанию
+// Настройки HTTP клиента, User-Agent и повторов применяются и к источникам на других адресах:
+// ECBProvider.WithClient, SOAPClient.WithClient
+//
+// Пример использования (корпоративная сеть):
+//
+//	roots, _ := x509.SystemCertPool()
+//	roots.AppendCertsFromPEM(corporateCA)
+//	client := parser.NewCBRClient(parser.CBRClientOptions{
+//	    Proxy:     proxyURL,
+//	    TLSConfig: &tls.Config{RootCAs: roots},
+//	    Timeout:   30 * time.Second,
+//	})
+//	conv := converter.NewConverter(client, cacheStorage)
+type CBRClient struct {
+	baseURL    string
+	dynamicURL string
+	metalURL   string
+	httpClient *http.Client
+	retry      RetryStrategy
+	userAgent  string
+	breakers   *hostBreakers // Circuit breaker по хосту запроса
+}
+
+// NewCBRClient создает клиент XML API ЦБ РФ
+func NewCBRClient(opts CBRClientOptions) *CBRClient {
+	c := &CBRClient{
+		baseURL:    opts.BaseURL,
+		dynamicURL: opts.DynamicURL,
+		metalURL:   opts.MetalURL,
+		httpClient: opts.HTTPClient,
+		retry:      opts.Retry,
+		userAgent:  opts.UserAgent,
+	}
+	if c.baseURL == "" {
+		c.baseURL = CBRURL
+	}
+	if c.dynamicURL == "" {
+		c.dynamicURL = CBRDynamicURL
+	}
+	if c.metalURL == "" {
+		c.metalURL = CBRMetalURL
+	}
+	if c.retry == nil {
+		c.retry = DefaultRetryPolicy
+	}
+	if c.userAgent == "" {
+		c.userAgent = UserAgent
+	}
+	c.breakers = newHostBreakers(c.baseURL, opts.Breaker)
+	if c.httpClient == nil {
+		c.httpClient = newHTTPClient()
+		if opts.Timeout > 0 {
+			c.httpClient.Timeout = opts.Timeout
+		}
+		if opts.Proxy != nil || opts.TLSConfig != nil {
+			c.httpClient.Transport = newTransport(opts.Proxy, opts.TLSConfig)
+		}
+	}
+	return c
+}
+
+// defaultBreakers - circuit breaker хостов для клиента по умолчанию (parser.FetchRates и др.)
+var defaultBreakers = newHostBreakers(CBRURL, nil)
+
+// defaultClient возвращает клиент с настройками по умолчанию поверх общего defaultHTTPClient
+// Создается на каждый вызов: тесты подменяют defaultHTTPClient
+func defaultClient() *CBRClient {
+	return &CBRClient{
+		baseURL:    CBRURL,
+		dynamicURL: CBRDynamicURL,
+		metalURL:   CBRMetalURL,
+		httpClient: defaultHTTPClient,
+		retry:      DefaultRetryPolicy,
+		userAgent:  UserAgent,
+		breakers:   defaultBreakers,
+	}
+}
+
+// fetchXML выполняет HTTP GET запрос клиентом по умолчанию
+func fetchXML(ctx context.Context, url string) (io.ReadCloser, error) {
+	return defaultClient().fetchXML(ctx, url)
+}
+
+// fetchXML выполняет HTTP GET запрос с повторами по стратегии клиента
+// ctx - контекст для отмены запросов и ожидания между попытками
 // url - URL для запроса
 // Возвращает io.ReadCloser с XML контентом (caller должен закрыть его)
-func fetchXML(ctx context.Context, url string) (io.ReadCloser, error) {
-	// Используем глобальный HTTP-клиент для переиспользования соединений
-	// Это значительно улучшает производительность при частых запросах
-	client := defaultHTTPClient
+func (c *CBRClient) fetchXML(ctx context.Context, url string) (io.ReadCloser, error) {
+	resp, err := c.fetch(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
 
-	var lastErr error
-	for attempt := 1; attempt <= MaxRetries; attempt++ {
-		resp, err := doRequest(ctx, client, url)
+// fetch выполняет HTTP GET запрос с повторами по стратегии клиента
+// cached - ранее загруженный снимок для условного запроса (nil - безусловный запрос);
+// ответ 304 Not Modified на условный запрос возвращается без ошибки (caller должен закрыть Body)
+// Ошибка, которую стратегия не повторяет (4xx), возвращается как есть;
+// после исчерпания попыток - *RetryError с ошибками всех попыток
+func (c *CBRClient) fetch(ctx context.Context, url string, cached *models.RateData) (*http.Response, error) {
+	return c.withRetries(ctx, url, func() (*http.Response, error) {
+		return c.doRequest(ctx, url, cached)
+	})
+}
+
+// withRetries выполняет запрос do к url с повторами по стратегии клиента
+// Пока circuit breaker хоста url открыт, сразу возвращает ErrSourceUnavailable;
+// результат запроса со всеми повторами учитывается breaker как одна попытка
+func (c *CBRClient) withRetries(ctx context.Context, url string, do func() (*http.Response, error)) (*http.Response, error) {
+	breaker := c.breakers.forURL(url)
+	if err := breaker.allow(); err != nil {
+		return nil, err
+	}
+	resp, err := c.retryLoop(ctx, do)
+	breaker.done(err)
+	return resp, err
+}
+
+// retryLoop выполняет запрос do с повторами по стратегии клиента
+// Ошибка, которую стратегия не повторяет, возвращается как есть;
+// после исчерпания попыток - *RetryError с ошибками всех попыток
+func (c *CBRClient) retryLoop(ctx context.Context, do func() (*http.Response, error)) (*http.Response, error) {
+	start := time.Now()
+	var attempts []error
+	for attempt := 1; ; attempt++ {
+		resp, err := do()
 		if err == nil {
-			return resp.Body, nil
+			return resp, nil
 		}
 
-		lastErr = err
-
 		// Если контекст отменён — выходим немедленно
 		if ctx.Err() != nil {
 			return nil, ctx.Err()
@@ -79,51 +222,59 @@ func fetchXML(ctx context.Context, url string) (io.ReadCloser, error) {
 
 		// Не повторяем запрос при ошибках клиента (4xx) — повторный запрос вернет тот же результат
 		// Возвращаем оригинальную ошибку без обёртки ErrMaxRetries
-		if errors.Is(err, ErrInvalidStatus) {
-			return nil, lastErr
+		if !c.retry.Retryable(err) {
+			return nil, err
 		}
 
-		// Если это не последняя попытка, ждем с exponential backoff
-		// Attempt 1: 1s, Attempt 2: 2s, Attempt 3: 4s (как в Python версии)
-		if attempt < MaxRetries {
-			delay := BaseRetryDelay * time.Duration(1<<uint(attempt-1))
-			if err := sleepWithContext(ctx, delay); err != nil {
-				return nil, err
-			}
+		attempts = append(attempts, err)
+		delay, ok := c.retry.NextDelay(attempt, time.Since(start), err)
+		if !ok {
+			return nil, &RetryError{Attempts: attempts}
+		}
+		if err := sleepWithContext(ctx, delay); err != nil {
+			return nil, err
 		}
 	}
-
-	return nil, fmt.Errorf("%w after %d attempts: %w", ErrMaxRetries, MaxRetries, lastErr)
 }
 
 // doRequest выполняет одиночный HTTP запрос
 // ctx - контекст для отмены запроса
-// client - HTTP клиент
 // url - URL для запроса
-func doRequest(ctx context.Context, client *http.Client, url string) (*http.Response, error) {
+// cached - снимок с валидаторами ETag/Last-Modified для условного запроса (nil - безусловный запрос)
+func (c *CBRClient) doRequest(ctx context.Context, url string, cached *models.RateData) (*http.Response, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Устанавливаем User-Agent для идентификации
-	req.Header.Set("User-Agent", UserAgent)
+	req.Header.Set("User-Agent", c.userAgent)
+
+	// Условный запрос: если документ не изменился, сервер ответит 304 без тела
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
 
-	resp, err := client.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrHTTPFailed, err)
 	}
 
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		return resp, nil
+	}
+
 	// Проверяем статус код
 	if resp.StatusCode != http.StatusOK {
 		resp.Body.Close()
-		// Разделяем клиентские (4xx) и серверные (5xx) ошибки:
-		// 4xx — не ретраим (проблема в запросе, повторный вызов не поможет)
-		// 5xx и прочие — ретраим (временная проблема сервера)
-		if resp.StatusCode >= 400 && resp.StatusCode < 500 {
-			return nil, fmt.Errorf("%w: %d %s", ErrInvalidStatus, resp.StatusCode, resp.Status)
-		}
-		return nil, fmt.Errorf("%w: %d %s", ErrHTTPFailed, resp.StatusCode, resp.Status)
+		// StatusError разделяет клиентские (3xx, 4xx - ErrInvalidStatus) и серверные (ErrHTTPFailed) ошибки
+		// и сохраняет Retry-After: повторять ли запрос, решает стратегия клиента
+		return nil, newStatusError(resp)
 	}
 
 	return resp, nil
@@ -145,11 +296,33 @@ func newHTTPClient() *http.Client {
 	}
 }
 
+// newTransport создает transport с прокси и настройками TLS
+// Остальные параметры (пул соединений, таймауты TLS handshake) - как у http.DefaultTransport
+func newTransport(proxy *url.URL, tlsConfig *tls.Config) *http.Transport {
+	base, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		base = &http.Transport{Proxy: http.ProxyFromEnvironment}
+	}
+	transport := base.Clone()
+	if proxy != nil {
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig.Clone()
+	}
+	return transport
+}
+
 // buildURL строит URL для запроса курсов на определенную дату из XML API
 // date - дата курсов
 func buildURL(date time.Time) string {
+	return dailyURL(CBRURL, date)
+}
+
+// dailyURL строит URL запроса курсов на дату к XML_daily.asp или его зеркалу
+func dailyURL(baseURL string, date time.Time) string {
 	// Формат даты для XML API: DD/MM/YYYY (например, 20/12/2025)
 	// Обратите внимание: слэш вместо точки, в отличие от HTML API
 	dateStr := date.Format("02/01/2006")
-	return fmt.Sprintf("%s?date_req=%s", CBRURL, dateStr)
+	return fmt.Sprintf("%s?date_req=%s", baseURL, dateStr)
 }
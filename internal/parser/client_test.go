@@ -10,6 +10,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
 )
 
 func TestFetchXML(t *testing.T) {
@@ -210,7 +212,7 @@ func TestDoRequest(t *testing.T) {
 		defer server.Close()
 
 		client := newHTTPClient()
-		resp, err := doRequest(ctx, client, server.URL)
+		resp, err := NewCBRClient(CBRClientOptions{HTTPClient: client}).doRequest(ctx, server.URL, nil)
 		if err != nil {
 			t.Fatalf("Неожиданная ошибка: %v", err)
 		}
@@ -223,7 +225,7 @@ func TestDoRequest(t *testing.T) {
 
 	t.Run("Невалидный URL", func(t *testing.T) {
 		client := newHTTPClient()
-		resp, err := doRequest(ctx, client, "://invalid-url")
+		resp, err := NewCBRClient(CBRClientOptions{HTTPClient: client}).doRequest(ctx, "://invalid-url", nil)
 		if err == nil {
 			resp.Body.Close()
 			t.Fatal("Ожидалась ошибка для невалидного URL")
@@ -239,7 +241,7 @@ func TestDoRequest(t *testing.T) {
 		defer server.Close()
 
 		client := newHTTPClient()
-		resp, err := doRequest(ctx, client, server.URL)
+		resp, err := NewCBRClient(CBRClientOptions{HTTPClient: client}).doRequest(ctx, server.URL, nil)
 		if err != nil {
 			t.Fatalf("Неожиданная ошибка: %v", err)
 		}
@@ -322,7 +324,7 @@ func TestHTTPClientRedirects(t *testing.T) {
 		defer server.Close()
 
 		client := newHTTPClient()
-		resp, err := doRequest(ctx, client, server.URL)
+		resp, err := NewCBRClient(CBRClientOptions{HTTPClient: client}).doRequest(ctx, server.URL, nil)
 		if err != nil {
 			t.Fatalf("Не ожидалась ошибка для редиректа: %v", err)
 		}
@@ -386,11 +388,11 @@ func BenchmarkDoRequest(b *testing.B) {
 	}))
 	defer server.Close()
 
-	client := newHTTPClient()
+	client := NewCBRClient(CBRClientOptions{HTTPClient: newHTTPClient()})
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		resp, err := doRequest(ctx, client, server.URL)
+		resp, err := client.doRequest(ctx, server.URL, nil)
 		if err != nil {
 			b.Fatal(err)
 		}
@@ -398,3 +400,69 @@ func BenchmarkDoRequest(b *testing.B) {
 		resp.Body.Close()
 	}
 }
+
+func TestCBRClient_AllSourcesUseClient(t *testing.T) {
+	// Клиент по умолчанию не должен использоваться
+	setTestHTTPClientFactory(t, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		t.Fatalf("Запрос клиентом по умолчанию: %s", req.URL)
+		return nil, nil
+	}))
+
+	const (
+		soapURL    = "http://soap.test/DailyInfo.asmx"
+		dynamicURL = "http://cbr.test/scripts/XML_dynamic.asp"
+		metalURL   = "http://cbr.test/scripts/xml_metall.asp"
+		mirrorURL  = "http://mirror.test/XML_daily.asp"
+	)
+	var agents []string
+	client := NewCBRClient(CBRClientOptions{
+		UserAgent:  "Test-Agent/1.0",
+		DynamicURL: dynamicURL,
+		MetalURL:   metalURL,
+		HTTPClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			agents = append(agents, req.Header.Get("User-Agent"))
+			switch {
+			case strings.HasPrefix(req.URL.String(), dynamicURL):
+				return newResponse(req, http.StatusOK, testDynamicXML), nil
+			case strings.HasPrefix(req.URL.String(), metalURL):
+				return newResponse(req, http.StatusOK, testMetalXML), nil
+			case strings.HasPrefix(req.URL.String(), mirrorURL):
+				return newResponse(req, http.StatusOK, dailyXML(time.Date(2025, 12, 19, 0, 0, 0, 0, time.UTC), "80,7220")), nil
+			case req.URL.String() == soapURL:
+				return newResponse(req, http.StatusOK, soapCursOnDateResponse), nil
+			case req.URL.Host == "www.ecb.europa.eu":
+				return newResponse(req, http.StatusOK, ecbHistXML), nil
+			default:
+				t.Errorf("Неожиданный запрос: %s", req.URL)
+				return newResponse(req, http.StatusNotFound, ""), nil
+			}
+		})},
+	})
+
+	ctx := context.Background()
+	date := time.Date(2025, 12, 19, 0, 0, 0, 0, time.UTC)
+	if _, err := client.FetchRateSeries(ctx, models.USD, date.AddDate(0, 0, -18), date); err != nil {
+		t.Errorf("FetchRateSeries() error = %v", err)
+	}
+	if _, err := client.FetchMetalRates(ctx, date); err != nil {
+		t.Errorf("FetchMetalRates() error = %v", err)
+	}
+	if _, err := NewECBProvider(models.EUR).WithClient(client).FetchRates(ctx, date); err != nil {
+		t.Errorf("ECBProvider.FetchRates() error = %v", err)
+	}
+	if _, err := NewSOAPClient(soapURL).WithClient(client).FetchRates(ctx, date); err != nil {
+		t.Errorf("SOAPClient.FetchRates() error = %v", err)
+	}
+	if _, err := client.MirrorFetcher(mirrorURL)(ctx, date); err != nil {
+		t.Errorf("MirrorFetcher() error = %v", err)
+	}
+
+	if len(agents) != 5 {
+		t.Fatalf("Запросов клиентом: %d, ожидалось 5", len(agents))
+	}
+	for i, agent := range agents {
+		if agent != "Test-Agent/1.0" {
+			t.Errorf("Запрос %d: User-Agent = %q, ожидался User-Agent клиента", i+1, agent)
+		}
+	}
+}
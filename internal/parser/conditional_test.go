@@ -0,0 +1,179 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/cache"
+	"github.com/bivlked/currate-go/internal/converter"
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+const testLastModified = "Fri, 19 Dec 2025 12:30:00 GMT"
+
+// newConditionalServer возвращает сервер, который отвечает 304 на условный запрос с актуальным ETag
+// и записывает заголовки условного запроса каждого обращения
+func newConditionalServer(t *testing.T) (*httptest.Server, *[]string) {
+	t.Helper()
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ifNoneMatch, ifModifiedSince := r.Header.Get("If-None-Match"), r.Header.Get("If-Modified-Since")
+		requests = append(requests, fmt.Sprintf("%s|%s", ifNoneMatch, ifModifiedSince))
+		if ifNoneMatch == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", testLastModified)
+		_, _ = w.Write([]byte(testDailyXML))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestCBRClient_ConditionalRequest(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("304 на снимок с валидаторами - ErrNotModified", func(t *testing.T) {
+		server, requests := newConditionalServer(t)
+		client := NewCBRClient(CBRClientOptions{BaseURL: server.URL})
+		today := time.Now()
+
+		first, err := client.FetchRates(ctx, today)
+		if err != nil {
+			t.Fatalf("FetchRates() error = %v", err)
+		}
+		if first.ETag != `"v1"` || first.LastModified != testLastModified {
+			t.Errorf("Валидаторы снимка: ETag %q, Last-Modified %q", first.ETag, first.LastModified)
+		}
+
+		second, err := client.FetchRatesConditional(ctx, today, first)
+		if !errors.Is(err, models.ErrNotModified) || second != nil {
+			t.Fatalf("FetchRates() после 304 = %v, %v; ожидалась ErrNotModified", second, err)
+		}
+		if client.breakers.forURL(server.URL).State() != BreakerClosed {
+			t.Errorf("Ответ 304 не должен считаться сбоем: breaker %v", client.breakers.forURL(server.URL).State())
+		}
+		want := []string{"|", `"v1"|` + testLastModified}
+		if fmt.Sprint(*requests) != fmt.Sprint(want) {
+			t.Errorf("Заголовки запросов = %q, ожидалось %q", *requests, want)
+		}
+	})
+
+	t.Run("Снимок без валидаторов - безусловный запрос", func(t *testing.T) {
+		server, requests := newConditionalServer(t)
+		client := NewCBRClient(CBRClientOptions{BaseURL: server.URL})
+
+		data, err := client.FetchRatesConditional(ctx, time.Now(), &models.RateData{})
+		if err != nil || data == nil {
+			t.Fatalf("FetchRates() = %v, %v", data, err)
+		}
+		if fmt.Sprint(*requests) != fmt.Sprint([]string{"|"}) {
+			t.Errorf("Заголовки запросов = %q, запрос должен быть безусловным", *requests)
+		}
+	})
+
+	t.Run("304 без условного запроса - ошибка статуса без повторов", func(t *testing.T) {
+		setTestSleep(t)
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.WriteHeader(http.StatusNotModified)
+		}))
+		defer server.Close()
+
+		client := NewCBRClient(CBRClientOptions{BaseURL: server.URL})
+		_, err := client.FetchRates(ctx, time.Now())
+		if !errors.Is(err, ErrInvalidStatus) || errors.Is(err, models.ErrNotModified) {
+			t.Errorf("FetchRates() error = %v, ожидалась ErrInvalidStatus для 304 на безусловный запрос", err)
+		}
+		if attempts != 1 {
+			t.Errorf("Запросов: %d, ответ 304 не должен повторяться", attempts)
+		}
+		if state := client.breakers.forURL(server.URL).State(); state != BreakerClosed {
+			t.Errorf("Ответ 304 не должен считаться сбоем: breaker %v", state)
+		}
+	})
+}
+
+func TestConverter_ConditionalRefresh(t *testing.T) {
+	server, requests := newConditionalServer(t)
+	client := NewCBRClient(CBRClientOptions{BaseURL: server.URL})
+
+	// Резервный источник не опрашивается: ответ 304 означает, что снимок в кэше актуален
+	fallbackCalls := 0
+	fallback := converter.FetchRatesFunc(func(context.Context, time.Time) (*models.RateData, error) {
+		fallbackCalls++
+		return nil, errors.New("резервный источник недоступен")
+	})
+	chain := converter.NewProviderChain(
+		converter.RateSource{Name: "cbr-xml", Provider: client},
+		converter.RateSource{Name: "fallback", Provider: fallback},
+	)
+
+	// Запись кэша истекает сразу: каждая конвертация обращается к источнику
+	conv := converter.NewConverter(chain, cache.NewLRUCache(10, time.Nanosecond))
+	today := time.Now()
+	for i := 0; i < 2; i++ {
+		result, err := conv.Convert(context.Background(), dec("100"), models.USD, today)
+		if err != nil {
+			t.Fatalf("Convert() error = %v", err)
+		}
+		if !result.TargetAmount.Equal(dec("8072.20")) || result.Source != "cbr-xml" {
+			t.Errorf("TargetAmount = %v, источник %q; ожидается 8072.20 из cbr-xml", result.TargetAmount, result.Source)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Второе обращение - условный запрос, 304 продлевает снимок в кэше
+	if len(*requests) != 2 || (*requests)[1] == "|" {
+		t.Errorf("Заголовки запросов = %q, второй запрос должен быть условным", *requests)
+	}
+	if fallbackCalls != 0 {
+		t.Errorf("Резервный источник опрошен %d раз, ожидалось 0", fallbackCalls)
+	}
+}
+
+func TestConverter_ConditionalRefreshAfterRestart(t *testing.T) {
+	server, requests := newConditionalServer(t)
+	client := NewCBRClient(CBRClientOptions{BaseURL: server.URL})
+	path := filepath.Join(t.TempDir(), "rates.json")
+	today := time.Now()
+
+	// Снимок с валидаторами сохраняется на диск, запись истекает сразу
+	newDisk := func() *cache.DiskCache {
+		disk, err := cache.NewDiskCache(path, cache.FixedTTL(time.Nanosecond))
+		if err != nil {
+			t.Fatalf("NewDiskCache() error = %v", err)
+		}
+		t.Cleanup(func() { _ = disk.Close() })
+		return disk
+	}
+	disk := newDisk()
+	if _, err := converter.NewConverter(client, disk).Convert(context.Background(), dec("100"), models.USD, today); err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if err := disk.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	time.Sleep(time.Millisecond)
+
+	// После перезапуска истекший снимок с диска делает запрос условным, 304 продлевает его
+	result, err := converter.NewConverter(client, newDisk()).Convert(context.Background(), dec("100"), models.USD, today)
+	if err != nil {
+		t.Fatalf("Convert() после перезапуска error = %v", err)
+	}
+	if !result.TargetAmount.Equal(dec("8072.20")) {
+		t.Errorf("TargetAmount = %v, ожидалось 8072.20", result.TargetAmount)
+	}
+	want := []string{"|", `"v1"|` + testLastModified}
+	if fmt.Sprint(*requests) != fmt.Sprint(want) {
+		t.Errorf("Заголовки запросов = %q, ожидалось %q", *requests, want)
+	}
+}
@@ -0,0 +1,167 @@
+package parser
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+// Ошибки получения динамики курса
+var (
+	ErrUnknownCBRID     = errors.New("CBR currency ID is unknown")
+	ErrInvalidDateRange = errors.New("invalid date range")
+)
+
+// ValCursDynamic представляет корневой элемент ответа XML_dynamic.asp
+// Пример: <ValCurs ID="R01235" DateRange1="01.12.2025" DateRange2="20.12.2025" name="Foreign Currency Market Dynamic">
+type ValCursDynamic struct {
+	XMLName    xml.Name `xml:"ValCurs"`
+	ID         string   `xml:"ID,attr"`
+	DateRange1 string   `xml:"DateRange1,attr"`
+	DateRange2 string   `xml:"DateRange2,attr"`
+	Records    []Record `xml:"Record"`
+}
+
+// Record представляет курс на одну дату в ответе XML_dynamic.asp
+// Пример:
+//
+//	<Record Date="02.12.2025" Id="R01235">
+//	    <Nominal>1</Nominal>
+//	    <Value>78,2284</Value>
+//	</Record>
+type Record struct {
+	Date    string `xml:"Date,attr"`
+	ID      string `xml:"Id,attr"`
+	Nominal string `xml:"Nominal"`
+	Value   string `xml:"Value"`
+}
+
+// FetchRateSeries получает динамику курса валюты за период одним запросом к XML_dynamic.asp
+// Тонкая обертка над CBRClient с настройками по умолчанию
+// ctx - контекст для отмены запроса
+// currency - валюта (ID ЦБ РФ берется из справочника models.LookupCurrency)
+// from, to - границы периода включительно
+//
+// Пример использования:
+//
+//	series, err := parser.FetchRateSeries(ctx, models.USD, from, to)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, rate := range series.Rates {
+//	    fmt.Println(rate.Date.Format("02.01.2006"), rate.Rate)
+//	}
+func FetchRateSeries(ctx context.Context, currency models.Currency, from, to time.Time) (*models.RateSeries, error) {
+	return defaultClient().FetchRateSeries(ctx, currency, from, to)
+}
+
+// FetchRateSeries получает динамику курса валюты за период с XML_dynamic.asp
+// Реализует интерфейс converter.RateSeriesProvider
+func (c *CBRClient) FetchRateSeries(ctx context.Context, currency models.Currency, from, to time.Time) (*models.RateSeries, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: %s > %s", ErrInvalidDateRange, from.Format("02.01.2006"), to.Format("02.01.2006"))
+	}
+
+	info, ok := models.LookupCurrency(currency)
+	if !ok || info.CBRID == "" {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCBRID, currency)
+	}
+
+	return c.fetchRateSeriesFromURL(ctx, buildDynamicURL(c.dynamicURL, info.CBRID, from, to), currency, from, to)
+}
+
+// fetchRateSeriesFromURL получает и парсит динамику курса с произвольного URL
+func (c *CBRClient) fetchRateSeriesFromURL(ctx context.Context, url string, currency models.Currency, from, to time.Time) (*models.RateSeries, error) {
+	body, err := c.fetchXML(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rate series from CBR: %w", err)
+	}
+	defer body.Close()
+
+	series, err := ParseDynamicXML(body, currency, from.Location())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CBR XML rate series: %w", err)
+	}
+	series.From = from
+	series.To = to
+
+	return series, nil
+}
+
+// ParseDynamicXML парсит ответ XML_dynamic.asp и возвращает динамику курса
+// r - io.Reader с XML контентом (может быть в кодировке windows-1251)
+// currency - валюта, для которой запрошена динамика (в XML есть только ID ЦБ РФ)
+// loc - часовой пояс для дат записей
+// Записи с некорректной датой, курсом или номиналом пропускаются
+func ParseDynamicXML(r io.Reader, currency models.Currency, loc *time.Location) (*models.RateSeries, error) {
+	series := &models.RateSeries{Currency: currency}
+	onRoot := func(root xml.StartElement) {
+		if from, err := time.ParseInLocation("02.01.2006", xmlAttr(root, "DateRange1"), loc); err == nil {
+			series.From = from
+		}
+		if to, err := time.ParseInLocation("02.01.2006", xmlAttr(root, "DateRange2"), loc); err == nil {
+			series.To = to
+		}
+	}
+
+	// Записи разбираются по одной: динамика за годы не загружается в память целиком
+	onRecord := func(decoder *xml.Decoder, start *xml.StartElement) error {
+		var record Record
+		if err := decoder.DecodeElement(&record, start); err != nil {
+			return err
+		}
+
+		date, err := time.ParseInLocation("02.01.2006", strings.TrimSpace(record.Date), loc)
+		if err != nil {
+			return nil
+		}
+
+		rate, err := parseXMLValue(record.Value)
+		if err != nil {
+			return nil
+		}
+
+		nominal, err := parseNominal(record.Nominal)
+		if err != nil {
+			return nil
+		}
+
+		series.Rates = append(series.Rates, models.ExchangeRate{
+			Currency: currency,
+			Rate:     rate,
+			Nominal:  nominal,
+			Date:     date,
+		})
+		return nil
+	}
+
+	if err := decodeXMLStream(r, "ValCurs", "Record", onRoot, onRecord); err != nil {
+		return nil, err
+	}
+
+	if len(series.Rates) == 0 {
+		return nil, ErrNoXMLRates
+	}
+
+	// ЦБ РФ отдает записи по возрастанию даты, но не полагаемся на это
+	sort.SliceStable(series.Rates, func(i, j int) bool {
+		return series.Rates[i].Date.Before(series.Rates[j].Date)
+	})
+
+	return series, nil
+}
+
+// buildDynamicURL строит URL для запроса динамики курса из XML API
+// cbrID - внутренний ID валюты ЦБ РФ (например, R01235 для USD)
+func buildDynamicURL(baseURL, cbrID string, from, to time.Time) string {
+	// Формат дат как в buildURL: DD/MM/YYYY
+	return fmt.Sprintf("%s?date_req1=%s&date_req2=%s&VAL_NM_RQ=%s",
+		baseURL, from.Format("02/01/2006"), to.Format("02/01/2006"), cbrID)
+}
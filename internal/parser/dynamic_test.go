@@ -0,0 +1,137 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+const testDynamicXML = `<?xml version="1.0" encoding="UTF-8"?>
+<ValCurs ID="R01235" DateRange1="01.12.2025" DateRange2="08.12.2025" name="Foreign Currency Market Dynamic">
+    <Record Date="03.12.2025" Id="R01235">
+        <Nominal>1</Nominal>
+        <Value>78,5000</Value>
+    </Record>
+    <Record Date="02.12.2025" Id="R01235">
+        <Nominal>1</Nominal>
+        <Value>78,2284</Value>
+    </Record>
+    <Record Date="04.12.2025" Id="R01235">
+        <Nominal>1</Nominal>
+        <Value>не число</Value>
+    </Record>
+    <Record Date="06.12.2025" Id="R01235">
+        <Nominal>1</Nominal>
+        <Value>79,1000</Value>
+    </Record>
+</ValCurs>`
+
+func TestParseDynamicXML(t *testing.T) {
+	series, err := ParseDynamicXML(strings.NewReader(testDynamicXML), models.USD, time.UTC)
+	if err != nil {
+		t.Fatalf("ParseDynamicXML() error = %v", err)
+	}
+
+	if series.Currency != models.USD {
+		t.Errorf("Currency = %s, want USD", series.Currency)
+	}
+	if got := series.From.Format("02.01.2006"); got != "01.12.2025" {
+		t.Errorf("From = %s, want 01.12.2025", got)
+	}
+	if got := series.To.Format("02.01.2006"); got != "08.12.2025" {
+		t.Errorf("To = %s, want 08.12.2025", got)
+	}
+
+	// Запись с некорректным курсом пропущена, остальные отсортированы по дате
+	want := []struct{ date, rate string }{
+		{"02.12.2025", "78.2284"},
+		{"03.12.2025", "78.5000"},
+		{"06.12.2025", "79.1000"},
+	}
+	if len(series.Rates) != len(want) {
+		t.Fatalf("len(Rates) = %d, want %d", len(series.Rates), len(want))
+	}
+	for i, w := range want {
+		got := series.Rates[i]
+		if got.Date.Format("02.01.2006") != w.date || !got.Rate.Equal(dec(w.rate)) || got.Currency != models.USD {
+			t.Errorf("Rates[%d] = %s %s %s, want %s %s", i, got.Currency, got.Date.Format("02.01.2006"), got.Rate, w.date, w.rate)
+		}
+	}
+}
+
+func TestParseDynamicXML_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		xml     string
+		wantErr error
+	}{
+		{
+			name:    "Нет записей за период",
+			xml:     `<ValCurs ID="R01235" DateRange1="01.01.2025" DateRange2="02.01.2025" name="Foreign Currency Market Dynamic"></ValCurs>`,
+			wantErr: ErrNoXMLRates,
+		},
+		{
+			name:    "Некорректный XML",
+			xml:     `<ValCurs><Record>`,
+			wantErr: ErrInvalidXML,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseDynamicXML(strings.NewReader(tt.xml), models.USD, time.UTC)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseDynamicXML() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFetchRateSeries(t *testing.T) {
+	from := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 12, 8, 0, 0, 0, 0, time.UTC)
+
+	var requestedURL string
+	setTestHTTPClientFactory(t, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requestedURL = req.URL.String()
+		return newResponse(req, http.StatusOK, testDynamicXML), nil
+	}))
+
+	series, err := FetchRateSeries(context.Background(), models.USD, from, to)
+	if err != nil {
+		t.Fatalf("FetchRateSeries() error = %v", err)
+	}
+
+	wantURL := CBRDynamicURL + "?date_req1=01/12/2025&date_req2=08/12/2025&VAL_NM_RQ=R01235"
+	if requestedURL != wantURL {
+		t.Errorf("URL = %s, want %s", requestedURL, wantURL)
+	}
+	if !series.From.Equal(from) || !series.To.Equal(to) {
+		t.Errorf("Период = %v..%v, want %v..%v", series.From, series.To, from, to)
+	}
+	if len(series.Rates) != 3 {
+		t.Errorf("len(Rates) = %d, want 3", len(series.Rates))
+	}
+}
+
+func TestFetchRateSeries_Errors(t *testing.T) {
+	from := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 12, 8, 0, 0, 0, 0, time.UTC)
+
+	setTestHTTPClientFactory(t, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		t.Fatalf("HTTP запрос не ожидался: %s", req.URL)
+		return nil, nil
+	}))
+
+	if _, err := FetchRateSeries(context.Background(), models.USD, to, from); !errors.Is(err, ErrInvalidDateRange) {
+		t.Errorf("FetchRateSeries(to, from) error = %v, want ErrInvalidDateRange", err)
+	}
+	if _, err := FetchRateSeries(context.Background(), models.Currency("XYZ"), from, to); !errors.Is(err, ErrUnknownCBRID) {
+		t.Errorf("FetchRateSeries(XYZ) error = %v, want ErrUnknownCBRID", err)
+	}
+}
@@ -0,0 +1,225 @@
+package parser
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+// Справочные курсы Европейского центрального банка
+const (
+	// ECBDailyURL - курсы ЕЦБ на последний рабочий день
+	ECBDailyURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+
+	// ECBHist90URL - курсы ЕЦБ за последние 90 дней
+	ECBHist90URL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist-90d.xml"
+
+	// ECBHistURL - все курсы ЕЦБ с 1999 года
+	ECBHistURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist.xml"
+
+	// ecbHist90Days - глубина ECBHist90URL с запасом на выходные
+	ecbHist90Days = 85
+
+	// maxECBXMLSize ограничивает размер ответа ЕЦБ (полная история - около 7 MB)
+	maxECBXMLSize = 32 << 20
+
+	// ecbRatePlaces - знаков после запятой в курсе относительно базовой валюты
+	// ЕЦБ публикует единицы валюты за 1 EUR, обратный курс - бесконечная дробь: 16 знаков
+	// сохраняют точность до копеек для сумм до 10^12 (4 знака, как у ЦБ РФ, дают ошибку в 3 EUR на 1 млн USD)
+	ecbRatePlaces = 16
+)
+
+// Ошибки ЕЦБ
+var (
+	ErrECBNoRates          = errors.New("no ECB reference rates on or before date")
+	ErrECBBaseNotPublished = errors.New("ECB does not publish the base currency")
+)
+
+// ecbCurrencyCodes - валюты eurofxref-daily.xml (курсы за 1 EUR)
+var ecbCurrencyCodes = []models.Currency{
+	models.USD, "JPY", "BGN", "CZK", "DKK", "GBP", "HUF", "PLN", "RON", "SEK", "CHF", "ISK", "NOK", "TRY", "AUD",
+	"BRL", "CAD", "CNY", "HKD", "IDR", "ILS", "INR", "KRW", "MXN", "MYR", "NZD", "PHP", "SGD", "THB", "ZAR",
+}
+
+// ecbCurrencies - справочник валют ЕЦБ: евро и ecbCurrencyCodes
+// Дополняется валютами загруженных курсов (в истории ЕЦБ есть валюты, курс которых больше не публикуется)
+var ecbCurrencies = newECBCurrencies()
+
+// newECBCurrencies создает справочник валют ЕЦБ
+func newECBCurrencies() *models.Registry {
+	registry := models.NewRegistry(models.CurrencyInfo{Code: models.EUR})
+	for _, code := range ecbCurrencyCodes {
+		registry.Register(models.CurrencyInfo{Code: code})
+	}
+	return registry
+}
+
+// ECBCurrencies возвращает справочник валют, курсы которых публикует ЕЦБ
+// Справочник отделен от справочника ЦБ РФ (models.LookupCurrency): конвертер курсов ЕЦБ
+// проверяет по нему валюты (converter.WithCurrencies), а курсы ЦБ РФ не принимают валют,
+// которых ЦБ РФ не публикует
+func ECBCurrencies() *models.Registry {
+	return ecbCurrencies
+}
+
+// ecbEnvelope представляет ответ eurofxref-daily.xml и eurofxref-hist*.xml
+// Пример:
+//
+//	<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+//	    <Cube>
+//	        <Cube time="2025-12-19">
+//	            <Cube currency="USD" rate="1.1722"/>
+//	        </Cube>
+//	    </Cube>
+//	</gesmes:Envelope>
+type ecbEnvelope struct {
+	Days []ecbDay `xml:"Cube>Cube"` // В истории - по убыванию даты
+}
+
+// ecbDay - курсы ЕЦБ на одну дату: единиц валюты за 1 EUR
+type ecbDay struct {
+	Time  string `xml:"time,attr"` // Формат 2006-01-02
+	Rates []struct {
+		Currency string `xml:"currency,attr"`
+		Rate     string `xml:"rate,attr"`
+	} `xml:"Cube"`
+}
+
+// ECBProvider получает справочные курсы ЕЦБ и выражает их в базовой валюте
+// Реализует converter.RateProvider: снимок с RateData.Base = base подключается к Converter
+// и кэшу так же, как курсы ЦБ РФ (кросс-конвертация между валютами ЕЦБ)
+//
+// Пример использования:
+//
+//	ecb := parser.NewECBProvider(models.EUR)
+//	conv := converter.NewConverter(ecb, cache.NewLRUCacheWithPolicy(1000, cache.DefaultDatePolicy))
+//	result, err := conv.ConvertCross(ctx, amount, models.USD, models.EUR, date)
+type ECBProvider struct {
+	base   models.Currency
+	client *CBRClient // nil - клиент по умолчанию
+}
+
+// NewECBProvider создает источник курсов ЕЦБ
+// base - базовая валюта: EUR или любая валюта из справочных курсов ЕЦБ
+func NewECBProvider(base models.Currency) *ECBProvider {
+	return &ECBProvider{base: base}
+}
+
+// WithClient возвращает источник, который загружает файлы ЕЦБ HTTP клиентом client
+// с его User-Agent, повторами и circuit breaker хоста ЕЦБ (BaseURL клиента не используется)
+func (p *ECBProvider) WithClient(client *CBRClient) *ECBProvider {
+	return &ECBProvider{base: p.base, client: client}
+}
+
+// FetchRates получает курсы ЕЦБ на дату (или последний рабочий день до неё)
+// Реализует интерфейс converter.RateProvider
+func (p *ECBProvider) FetchRates(ctx context.Context, date time.Time) (*models.RateData, error) {
+	client := p.client
+	if client == nil {
+		client = defaultClient()
+	}
+	body, err := client.fetchXML(ctx, ecbURL(date, time.Now()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rates from ECB: %w", err)
+	}
+	defer body.Close()
+
+	rateData, err := ParseECBXML(body, date, p.base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ECB XML rates: %w", err)
+	}
+	return rateData, nil
+}
+
+// ecbURL выбирает наименьший файл ЕЦБ, в котором есть курсы на дату
+func ecbURL(date, now time.Time) string {
+	day := date.Format("2006-01-02")
+	switch {
+	case day >= now.Format("2006-01-02"):
+		return ECBDailyURL
+	case day >= now.AddDate(0, 0, -ecbHist90Days).Format("2006-01-02"):
+		return ECBHist90URL
+	default:
+		return ECBHistURL
+	}
+}
+
+// ParseECBXML парсит ответ ЕЦБ и возвращает курсы на дату относительно base
+// Берутся курсы последнего дня не позже date (ЕЦБ не публикует курсы в выходные и праздники)
+// Курс валюты - единиц base за Nominal единиц валюты; номинал - наименьшая степень 10,
+// при которой курс не меньше 0,1 (как у ЦБ РФ для иены или вона): в курсе не меньше 4 значащих цифр
+func ParseECBXML(r io.Reader, date time.Time, base models.Currency) (*models.RateData, error) {
+	var envelope ecbEnvelope
+	if err := xml.NewDecoder(io.LimitReader(r, maxECBXMLSize)).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidXML, err)
+	}
+
+	// Последний день не позже запрошенной даты (порядок дней в файле не важен)
+	requested := date.Format("2006-01-02")
+	var day *ecbDay
+	for i := range envelope.Days {
+		candidate := &envelope.Days[i]
+		if candidate.Time <= requested && (day == nil || candidate.Time > day.Time) {
+			day = candidate
+		}
+	}
+	if day == nil {
+		return nil, fmt.Errorf("%w: %s", ErrECBNoRates, requested)
+	}
+	dayDate, err := time.ParseInLocation("2006-01-02", day.Time, date.Location())
+	if err != nil {
+		return nil, fmt.Errorf("%w: date %q", ErrInvalidXML, day.Time)
+	}
+
+	// Курсы ЕЦБ - единиц валюты за 1 EUR; EUR в списке нет
+	perEUR := map[models.Currency]models.Decimal{models.EUR: models.NewDecimalFromInt(1)}
+	for _, rate := range day.Rates {
+		currency, err := parseCurrency(rate.Currency)
+		if err != nil {
+			continue
+		}
+		value, err := parseXMLValue(rate.Rate)
+		if err != nil {
+			continue
+		}
+		perEUR[currency] = value
+	}
+
+	baseRate, ok := perEUR[base]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrECBBaseNotPublished, base)
+	}
+
+	rateData := models.NewRateData(dayDate)
+	rateData.Base = base
+	for currency, rate := range perEUR {
+		if currency == base {
+			continue
+		}
+
+		// Единиц base за единицу валюты = baseRate / rate
+		nominal := int64(1)
+		for baseRate.Mul(models.NewDecimalFromInt(nominal*10)).Cmp(rate) < 0 {
+			nominal *= 10
+		}
+		rateData.AddRate(models.ExchangeRate{
+			Currency: currency,
+			Rate:     baseRate.Mul(models.NewDecimalFromInt(nominal)).Div(rate, ecbRatePlaces),
+			Nominal:  int(nominal),
+			Date:     dayDate,
+		})
+
+		// Валюты из истории ЕЦБ, которых нет в справочнике, становятся ему известны
+		ecbCurrencies.Register(models.CurrencyInfo{Code: currency})
+	}
+
+	if len(rateData.Rates) == 0 {
+		return nil, ErrNoXMLRates
+	}
+	return rateData, nil
+}
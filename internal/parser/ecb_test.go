@@ -0,0 +1,206 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/converter"
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+// ECBProvider подключается к Converter без адаптера
+var _ converter.RateProvider = (*ECBProvider)(nil)
+
+// Фрагмент eurofxref-hist-90d.xml: дни по убыванию даты, 20-21.12.2025 - выходные
+const ecbHistXML = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<gesmes:Sender>
+		<gesmes:name>European Central Bank</gesmes:name>
+	</gesmes:Sender>
+	<Cube>
+		<Cube time="2025-12-22">
+			<Cube currency="USD" rate="1.1800"/>
+			<Cube currency="JPY" rate="183.50"/>
+		</Cube>
+		<Cube time="2025-12-19">
+			<Cube currency="USD" rate="1.1722"/>
+			<Cube currency="JPY" rate="182.15"/>
+			<Cube currency="IDR" rate="19568.55"/>
+			<Cube currency="XX" rate="1.0"/>
+		</Cube>
+		<Cube time="2025-12-18">
+			<Cube currency="USD" rate="1.1700"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+func TestParseECBXML(t *testing.T) {
+	sunday := time.Date(2025, 12, 21, 0, 0, 0, 0, time.UTC)
+	friday := time.Date(2025, 12, 19, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		base  models.Currency
+		want  map[models.Currency]string // Курс за номинал
+		nomin map[models.Currency]int
+	}{
+		{
+			name:  "База EUR",
+			base:  models.EUR,
+			want:  map[models.Currency]string{models.USD: "0.8530967411704487", "JPY": "0.5489980785067252", "IDR": "0.5110240666784202"},
+			nomin: map[models.Currency]int{models.USD: 1, "JPY": 100, "IDR": 10000},
+		},
+		{
+			name:  "База USD",
+			base:  models.USD,
+			want:  map[models.Currency]string{models.EUR: "1.1722", "JPY": "0.6435355476255833"},
+			nomin: map[models.Currency]int{models.EUR: 1, "JPY": 100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rateData, err := ParseECBXML(strings.NewReader(ecbHistXML), sunday, tt.base)
+			if err != nil {
+				t.Fatalf("ParseECBXML() error = %v", err)
+			}
+
+			// Выходные - курсы пятницы; курс базовой валюты не хранится
+			if !rateData.Date.Equal(friday) || rateData.BaseCurrency() != tt.base {
+				t.Errorf("Снимок: дата %v, база %s; ожидалось %v, %s", rateData.Date, rateData.BaseCurrency(), friday, tt.base)
+			}
+			if _, exists := rateData.Rates[tt.base]; exists {
+				t.Errorf("Курс базовой валюты %s не должен храниться", tt.base)
+			}
+			for currency, want := range tt.want {
+				rate := rateData.Rates[currency]
+				if !rate.Rate.Equal(dec(want)) || rate.Nominal != tt.nomin[currency] {
+					t.Errorf("%s = %s за %d, ожидалось %s за %d", currency, rate.Rate, rate.Nominal, want, tt.nomin[currency])
+				}
+			}
+		})
+	}
+}
+
+func TestParseECBXML_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		xml     string
+		date    time.Time
+		base    models.Currency
+		wantErr error
+	}{
+		{
+			name:    "Дата раньше всех курсов",
+			xml:     ecbHistXML,
+			date:    time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC),
+			base:    models.EUR,
+			wantErr: ErrECBNoRates,
+		},
+		{
+			name:    "ЕЦБ не публикует базовую валюту",
+			xml:     ecbHistXML,
+			date:    time.Date(2025, 12, 19, 0, 0, 0, 0, time.UTC),
+			base:    models.RUB,
+			wantErr: ErrECBBaseNotPublished,
+		},
+		{
+			name:    "Некорректный XML",
+			xml:     "<gesmes:Envelope",
+			date:    time.Date(2025, 12, 19, 0, 0, 0, 0, time.UTC),
+			base:    models.EUR,
+			wantErr: ErrInvalidXML,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseECBXML(strings.NewReader(tt.xml), tt.date, tt.base); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseECBXML() error = %v, ожидалась %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseECBXML_CurrencyRegistry(t *testing.T) {
+	// HRK - валюта из истории ЕЦБ (до перехода Хорватии на евро), ЦБ РФ её курс не публикует
+	const hrkXML = `<Envelope><Cube><Cube time="2022-12-30"><Cube currency="USD" rate="1.0666"/><Cube currency="HRK" rate="7.5365"/></Cube></Cube></Envelope>`
+
+	if _, err := ParseECBXML(strings.NewReader(hrkXML), time.Date(2022, 12, 30, 0, 0, 0, 0, time.UTC), models.EUR); err != nil {
+		t.Fatalf("ParseECBXML() error = %v", err)
+	}
+	if _, ok := ECBCurrencies().Lookup("HRK"); !ok {
+		t.Error("Валюта из курсов ЕЦБ должна появиться в справочнике ЕЦБ")
+	}
+	if _, ok := models.LookupCurrency("HRK"); ok {
+		t.Error("Валюта ЕЦБ не должна попадать в справочник ЦБ РФ")
+	}
+	if err := models.Currency("ISK").Validate(); !errors.Is(err, models.ErrUnsupportedCurrency) {
+		t.Errorf("ISK по справочнику ЦБ РФ: %v, ожидалась ErrUnsupportedCurrency", err)
+	}
+	if err := ECBCurrencies().Validate("ISK"); err != nil {
+		t.Errorf("ISK по справочнику ЕЦБ: %v", err)
+	}
+}
+
+func TestECBURL(t *testing.T) {
+	now := time.Date(2025, 12, 22, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		date time.Time
+		want string
+	}{
+		{"Сегодня", now, ECBDailyURL},
+		{"Последние 90 дней", now.AddDate(0, 0, -30), ECBHist90URL},
+		{"Старше 90 дней", now.AddDate(-1, 0, 0), ECBHistURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ecbURL(tt.date, now); got != tt.want {
+				t.Errorf("ecbURL() = %s, ожидалось %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestECBProvider_FetchRates(t *testing.T) {
+	var requested string
+	setTestHTTPClientFactory(t, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requested = req.URL.String()
+		return newResponse(req, http.StatusOK, ecbHistXML), nil
+	}))
+
+	rateData, err := NewECBProvider(models.EUR).FetchRates(context.Background(), time.Date(2025, 12, 19, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("FetchRates() error = %v", err)
+	}
+	if requested != ECBHistURL {
+		t.Errorf("URL = %s, ожидалась история курсов %s", requested, ECBHistURL)
+	}
+	if usd := rateData.Rates[models.USD]; !usd.Rate.Equal(dec("0.8530967411704487")) {
+		t.Errorf("USD = %s, ожидалось 0.8530967411704487", usd.Rate)
+	}
+}
+
+func TestECBProvider_ConvertLargeAmount(t *testing.T) {
+	setTestHTTPClientFactory(t, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return newResponse(req, http.StatusOK, ecbHistXML), nil
+	}))
+
+	// Курс ЕЦБ 1.1722 USD за EUR: 1 000 000 / 1.1722 = 853 096,7411... EUR
+	conv := converter.NewConverter(NewECBProvider(models.EUR), nil)
+	result, err := conv.ConvertCross(context.Background(), dec("1000000"), models.USD, models.EUR, time.Date(2025, 12, 19, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("ConvertCross() error = %v", err)
+	}
+	if !result.TargetAmount.Equal(dec("853096.74")) {
+		t.Errorf("TargetAmount = %s, ожидалось 853096.74", result.TargetAmount)
+	}
+}
@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+// Резервные источники курсов
+const (
+	// SnapshotFilePattern - имя файла снимка XML_daily.asp в каталоге снимков (дата в формате 2006-01-02)
+	SnapshotFilePattern = "XML_daily_%s.xml"
+
+	// SnapshotLookbackDays - на сколько дней назад искать снимок, если на запрошенную дату его нет
+	// ЦБ РФ не публикует курсы в выходные и праздники: действует курс последнего рабочего дня
+	SnapshotLookbackDays = 10
+)
+
+// Ошибки резервных источников
+var (
+	ErrSnapshotNotFound = errors.New("snapshot file not found")
+)
+
+// MirrorFetcher возвращает функцию получения курсов с зеркала XML API ЦБ РФ клиентом по умолчанию
+// baseURL - адрес, который отвечает как XML_daily.asp (параметр date_req=DD/MM/YYYY)
+//
+// Пример использования:
+//
+//	mirror := converter.FetchRatesFunc(parser.MirrorFetcher("https://mirror.example.org/XML_daily.asp"))
+func MirrorFetcher(baseURL string) func(ctx context.Context, date time.Time) (*models.RateData, error) {
+	return defaultClient().MirrorFetcher(baseURL)
+}
+
+// MirrorFetcher возвращает функцию получения курсов с зеркала XML API ЦБ РФ
+// Запросы к зеркалу выполняются HTTP клиентом, с повторами и User-Agent клиента c
+// (прокси и TLS корпоративной сети применяются и к зеркалу); у хоста зеркала свой circuit breaker
+//
+// Пример использования:
+//
+//	mirror := converter.FetchRatesFunc(client.MirrorFetcher("https://mirror.example.org/XML_daily.asp"))
+func (c *CBRClient) MirrorFetcher(baseURL string) func(ctx context.Context, date time.Time) (*models.RateData, error) {
+	return func(ctx context.Context, date time.Time) (*models.RateData, error) {
+		return c.fetchRatesFromURL(ctx, dailyURL(baseURL, date), date, nil)
+	}
+}
+
+// SnapshotFetcher возвращает функцию получения курсов из каталога сохраненных ответов ЦБ РФ
+// dir - каталог с файлами XML_daily_2006-01-02.xml (ответ XML_daily.asp на дату из имени)
+// Если снимка на дату нет, используется ближайший более ранний (не дальше SnapshotLookbackDays дней):
+// как и ЦБ РФ, источник отдает курс последнего рабочего дня с его фактической датой из XML
+//
+// Пример использования:
+//
+//	snapshots := converter.FetchRatesFunc(parser.SnapshotFetcher(`C:\CurRate\snapshots`))
+func SnapshotFetcher(dir string) func(ctx context.Context, date time.Time) (*models.RateData, error) {
+	return func(ctx context.Context, date time.Time) (*models.RateData, error) {
+		for day := 0; day <= SnapshotLookbackDays; day++ {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			path := filepath.Join(dir, fmt.Sprintf(SnapshotFilePattern, date.AddDate(0, 0, -day).Format("2006-01-02")))
+			rateData, err := readSnapshot(path, date)
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return rateData, err
+		}
+		return nil, fmt.Errorf("%w: %s on %s", ErrSnapshotNotFound, dir, date.Format("2006-01-02"))
+	}
+}
+
+// readSnapshot читает и парсит один файл снимка
+func readSnapshot(path string, date time.Time) (*models.RateData, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	rateData, err := ParseXML(file, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", path, err)
+	}
+	return rateData, nil
+}
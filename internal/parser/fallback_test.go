@@ -0,0 +1,120 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+// dailyXML возвращает ответ XML_daily.asp на дату с одним курсом USD
+func dailyXML(date time.Time, usd string) string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<ValCurs Date="%s" name="Foreign Currency Market">
+    <Valute ID="R01235">
+        <NumCode>840</NumCode>
+        <CharCode>USD</CharCode>
+        <Nominal>1</Nominal>
+        <Name>Доллар США</Name>
+        <Value>%s</Value>
+    </Valute>
+</ValCurs>`, formatCBRDate(date), usd)
+}
+
+// writeSnapshot сохраняет файл снимка на дату в каталог
+func writeSnapshot(t *testing.T, dir string, date time.Time, content string) {
+	t.Helper()
+	path := filepath.Join(dir, fmt.Sprintf(SnapshotFilePattern, date.Format("2006-01-02")))
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMirrorFetcher(t *testing.T) {
+	date := time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC)
+
+	var requested string
+	setTestHTTPClientFactory(t, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requested = req.URL.String()
+		return newResponse(req, http.StatusOK, dailyXML(date, "80,7220")), nil
+	}))
+
+	rateData, err := MirrorFetcher("http://mirror.test/XML_daily.asp")(context.Background(), date)
+	if err != nil {
+		t.Fatalf("MirrorFetcher() error = %v", err)
+	}
+	if want := "http://mirror.test/XML_daily.asp?date_req=20/12/2025"; requested != want {
+		t.Errorf("URL = %s, ожидалось %s", requested, want)
+	}
+	if usd := rateData.Rates[models.USD]; !usd.Rate.Equal(dec("80.7220")) {
+		t.Errorf("USD = %s, ожидалось 80.7220", usd.Rate)
+	}
+}
+
+func TestSnapshotFetcher(t *testing.T) {
+	sunday := time.Date(2025, 12, 21, 0, 0, 0, 0, time.UTC)
+	saturday := sunday.AddDate(0, 0, -1)
+
+	tests := []struct {
+		name     string
+		files    map[time.Time]string
+		wantRate string
+		wantDate time.Time
+		wantErr  error
+	}{
+		{
+			name:     "Снимок на запрошенную дату",
+			files:    map[time.Time]string{sunday: dailyXML(saturday, "80,7220"), saturday: dailyXML(saturday, "79,0000")},
+			wantRate: "80.7220",
+			wantDate: saturday,
+		},
+		{
+			name:     "Ближайший более ранний снимок",
+			files:    map[time.Time]string{saturday: dailyXML(saturday, "80,7220")},
+			wantRate: "80.7220",
+			wantDate: saturday,
+		},
+		{
+			name:    "Снимок старше SnapshotLookbackDays",
+			files:   map[time.Time]string{sunday.AddDate(0, 0, -SnapshotLookbackDays-1): dailyXML(saturday, "80,7220")},
+			wantErr: ErrSnapshotNotFound,
+		},
+		{
+			name:    "Поврежденный снимок",
+			files:   map[time.Time]string{sunday: "<ValCurs"},
+			wantErr: ErrInvalidXML,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for date, content := range tt.files {
+				writeSnapshot(t, dir, date, content)
+			}
+
+			rateData, err := SnapshotFetcher(dir)(context.Background(), sunday)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("SnapshotFetcher() error = %v, ожидалась %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SnapshotFetcher() error = %v", err)
+			}
+			if usd := rateData.Rates[models.USD]; !usd.Rate.Equal(dec(tt.wantRate)) {
+				t.Errorf("USD = %s, ожидалось %s", usd.Rate, tt.wantRate)
+			}
+			if !rateData.Date.Equal(tt.wantDate) {
+				t.Errorf("Дата = %v, ожидалась фактическая дата из XML %v", rateData.Date, tt.wantDate)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package parser
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+// Константы учетных цен драгоценных металлов
+const (
+	// CBRMetalURL - URL XML API ЦБ РФ для получения учетных цен драгоценных металлов за период
+	CBRMetalURL = "https://www.cbr.ru/scripts/xml_metall.asp"
+
+	// MetalLookbackDays - на сколько дней назад запрашиваются цены: ЦБ РФ не устанавливает
+	// их в выходные и праздники (новогодние каникулы - до 10 дней)
+	MetalLookbackDays = 14
+)
+
+// metalCodes - коды металлов в xml_metall.asp
+var metalCodes = map[string]models.Currency{
+	"1": models.XAU,
+	"2": models.XAG,
+	"3": models.XPT,
+	"4": models.XPD,
+}
+
+// Metall представляет корневой элемент ответа xml_metall.asp
+// Пример: <Metall FromDate="20251208" ToDate="20251220" name="Precious metals quotations">
+type Metall struct {
+	XMLName  xml.Name      `xml:"Metall"`
+	FromDate string        `xml:"FromDate,attr"`
+	ToDate   string        `xml:"ToDate,attr"`
+	Records  []MetalRecord `xml:"Record"`
+}
+
+// MetalRecord представляет учетную цену металла на одну дату (рублей за грамм)
+// Пример:
+//
+//	<Record Date="19.12.2025" Code="1">
+//	    <Buy>10712,04</Buy>
+//	    <Sell>10712,04</Sell>
+//	</Record>
+type MetalRecord struct {
+	Date string `xml:"Date,attr"`
+	Code string `xml:"Code,attr"` // 1 - золото, 2 - серебро, 3 - платина, 4 - палладий
+	Buy  string `xml:"Buy"`       // Учетная цена; с 2008 года Buy и Sell совпадают
+	Sell string `xml:"Sell"`
+}
+
+// FetchMetalRates получает учетные цены драгоценных металлов на дату
+// Цены возвращаются как курсы XAU, XAG, XPT, XPD (рублей за грамм, номинал 1);
+// у каждого металла своя дата установки цены - последняя не позже date
+// Реализует сигнатуру converter.FetchRatesFunc; тонкая обертка над CBRClient с настройками по умолчанию
+//
+// Пример использования:
+//
+//	metals, err := parser.FetchMetalRates(ctx, date)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(metals.Rates[models.XAU].Rate)
+func FetchMetalRates(ctx context.Context, date time.Time) (*models.RateData, error) {
+	return defaultClient().FetchMetalRates(ctx, date)
+}
+
+// FetchMetalRates получает учетные цены драгоценных металлов на дату с xml_metall.asp
+func (c *CBRClient) FetchMetalRates(ctx context.Context, date time.Time) (*models.RateData, error) {
+	return c.fetchMetalRatesFromURL(ctx, buildMetalURL(c.metalURL, date), date)
+}
+
+// fetchMetalRatesFromURL получает и парсит цены металлов с произвольного URL
+func (c *CBRClient) fetchMetalRatesFromURL(ctx context.Context, url string, date time.Time) (*models.RateData, error) {
+	body, err := c.fetchXML(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch metal prices from CBR: %w", err)
+	}
+	defer body.Close()
+
+	rateData, err := ParseMetalXML(body, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CBR XML metal prices: %w", err)
+	}
+	return rateData, nil
+}
+
+// ParseMetalXML парсит ответ xml_metall.asp и возвращает цены металлов на дату
+// r - io.Reader с XML контентом (может быть в кодировке windows-1251)
+// Для каждого металла берется последняя запись не позже date; дата снимка - самая поздняя из них
+// Записи с неизвестным кодом, некорректной датой или ценой пропускаются
+func ParseMetalXML(r io.Reader, date time.Time) (*models.RateData, error) {
+	requested := normalizeDay(date)
+	latest := make(map[models.Currency]models.ExchangeRate)
+	onRecord := func(decoder *xml.Decoder, start *xml.StartElement) error {
+		var record MetalRecord
+		if err := decoder.DecodeElement(&record, start); err != nil {
+			return err
+		}
+
+		metal, ok := metalCodes[strings.TrimSpace(record.Code)]
+		if !ok {
+			return nil
+		}
+
+		recordDate, err := time.ParseInLocation("02.01.2006", strings.TrimSpace(record.Date), date.Location())
+		if err != nil || recordDate.After(requested) {
+			return nil
+		}
+
+		price, err := parseXMLValue(record.Buy)
+		if err != nil {
+			return nil
+		}
+
+		if current, exists := latest[metal]; !exists || recordDate.After(current.Date) {
+			latest[metal] = models.ExchangeRate{Currency: metal, Rate: price, Nominal: 1, Date: recordDate}
+		}
+		return nil
+	}
+
+	// Архив цен разбирается потоком: в памяти только последняя цена каждого металла
+	if err := decodeXMLStream(r, "Metall", "Record", nil, onRecord); err != nil {
+		return nil, err
+	}
+
+	if len(latest) == 0 {
+		return nil, ErrNoXMLRates
+	}
+
+	var snapshotDate time.Time
+	for _, rate := range latest {
+		if rate.Date.After(snapshotDate) {
+			snapshotDate = rate.Date
+		}
+	}
+	rateData := models.NewRateData(snapshotDate)
+	for _, rate := range latest {
+		rateData.AddRate(rate)
+	}
+	return rateData, nil
+}
+
+// normalizeDay отбрасывает время: записи xml_metall.asp датированы полночью
+func normalizeDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+}
+
+// buildMetalURL строит URL для запроса цен металлов за MetalLookbackDays дней до даты
+func buildMetalURL(baseURL string, date time.Time) string {
+	// Формат дат как в buildURL: DD/MM/YYYY
+	from := date.AddDate(0, 0, -MetalLookbackDays)
+	return fmt.Sprintf("%s?date_req1=%s&date_req2=%s",
+		baseURL, from.Format("02/01/2006"), date.Format("02/01/2006"))
+}
@@ -0,0 +1,114 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+// Ответ xml_metall.asp: 20-21.12.2025 - выходные, палладий не установлен 19.12
+const testMetalXML = `<?xml version="1.0" encoding="windows-1251"?>
+<Metall FromDate="20251208" ToDate="20251222" name="Precious metals quotations">
+<Record Date="18.12.2025" Code="1"><Buy>10650,11</Buy><Sell>10650,11</Sell></Record>
+<Record Date="18.12.2025" Code="4"><Buy>3801,50</Buy><Sell>3801,50</Sell></Record>
+<Record Date="19.12.2025" Code="1"><Buy>10712,04</Buy><Sell>10712,04</Sell></Record>
+<Record Date="19.12.2025" Code="2"><Buy>165,32</Buy><Sell>165,32</Sell></Record>
+<Record Date="19.12.2025" Code="3"><Buy>-</Buy><Sell>-</Sell></Record>
+<Record Date="19.12.2025" Code="9"><Buy>1,00</Buy><Sell>1,00</Sell></Record>
+<Record Date="22.12.2025" Code="1"><Buy>10800,00</Buy><Sell>10800,00</Sell></Record>
+</Metall>`
+
+func TestParseMetalXML(t *testing.T) {
+	sunday := time.Date(2025, 12, 21, 15, 0, 0, 0, time.UTC)
+	friday := time.Date(2025, 12, 19, 0, 0, 0, 0, time.UTC)
+	thursday := time.Date(2025, 12, 18, 0, 0, 0, 0, time.UTC)
+
+	rateData, err := ParseMetalXML(strings.NewReader(testMetalXML), sunday)
+	if err != nil {
+		t.Fatalf("ParseMetalXML() error = %v", err)
+	}
+
+	// Дата снимка - последняя установленная цена; запись после запрошенной даты не учитывается
+	if !rateData.Date.Equal(friday) {
+		t.Errorf("Дата = %v, ожидалась %v", rateData.Date, friday)
+	}
+	if len(rateData.Rates) != 3 {
+		t.Errorf("Ожидалось 3 металла (платина с некорректной ценой пропущена), получено %d", len(rateData.Rates))
+	}
+
+	tests := []struct {
+		metal models.Currency
+		price string
+		date  time.Time
+	}{
+		{models.XAU, "10712.04", friday},
+		{models.XAG, "165.32", friday},
+		{models.XPD, "3801.50", thursday},
+	}
+	for _, tt := range tests {
+		rate := rateData.Rates[tt.metal]
+		if !rate.Rate.Equal(dec(tt.price)) || rate.Nominal != 1 || !rate.Date.Equal(tt.date) {
+			t.Errorf("%s = %+v, ожидалось %s за грамм на %v", tt.metal, rate, tt.price, tt.date)
+		}
+	}
+}
+
+func TestParseMetalXML_Errors(t *testing.T) {
+	date := time.Date(2025, 12, 21, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		xml     string
+		wantErr error
+	}{
+		{
+			name:    "Нет записей",
+			xml:     `<Metall FromDate="20251208" ToDate="20251221" name="Precious metals quotations"></Metall>`,
+			wantErr: ErrNoXMLRates,
+		},
+		{
+			name:    "Только записи после даты",
+			xml:     `<Metall><Record Date="22.12.2025" Code="1"><Buy>10800,00</Buy></Record></Metall>`,
+			wantErr: ErrNoXMLRates,
+		},
+		{
+			name:    "Некорректный XML",
+			xml:     "<Metall",
+			wantErr: ErrInvalidXML,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseMetalXML(strings.NewReader(tt.xml), date); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseMetalXML() error = %v, ожидалась %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFetchMetalRates(t *testing.T) {
+	var requested string
+	setTestHTTPClientFactory(t, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requested = req.URL.String()
+		return newResponse(req, http.StatusOK, testMetalXML), nil
+	}))
+
+	rateData, err := FetchMetalRates(context.Background(), time.Date(2025, 12, 21, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("FetchMetalRates() error = %v", err)
+	}
+
+	want := CBRMetalURL + "?date_req1=07/12/2025&date_req2=21/12/2025"
+	if requested != want {
+		t.Errorf("URL = %s, ожидался %s", requested, want)
+	}
+	if gold := rateData.Rates[models.XAU]; !gold.Rate.Equal(dec("10712.04")) {
+		t.Errorf("XAU = %s, ожидалось 10712.04", gold.Rate)
+	}
+}
@@ -4,7 +4,6 @@ package parser
 import (
 	"errors"
 	"fmt"
-	"math"
 	"strconv"
 	"strings"
 
@@ -18,23 +17,21 @@ var (
 )
 
 // parseRate парсит строку курса в формате "80,7220" (с запятой как десятичным разделителем)
-// и возвращает float64
-func parseRate(s string) (float64, error) {
+// и возвращает точное десятичное значение без промежуточного float64
+func parseRate(s string) (models.Decimal, error) {
 	s = strings.TrimSpace(s)
 	if s == "" {
-		return 0, ErrInvalidRate
+		return models.Decimal{}, ErrInvalidRate
 	}
 
-	// Заменяем запятую на точку (ЦБ РФ использует запятую как десятичный разделитель)
-	s = strings.ReplaceAll(s, ",", ".")
-
-	rate, err := strconv.ParseFloat(s, 64)
+	// ParseDecimal принимает и запятую (формат ЦБ РФ), и точку
+	rate, err := models.ParseDecimal(s)
 	if err != nil {
-		return 0, fmt.Errorf("%w: %s", ErrInvalidRate, s)
+		return models.Decimal{}, fmt.Errorf("%w: %s", ErrInvalidRate, s)
 	}
 
-	if math.IsNaN(rate) || math.IsInf(rate, 0) || rate <= 0 {
-		return 0, fmt.Errorf("%w: rate must be a finite positive number", ErrInvalidRate)
+	if rate.Sign() <= 0 {
+		return models.Decimal{}, fmt.Errorf("%w: rate must be a positive number", ErrInvalidRate)
 	}
 
 	return rate, nil
@@ -64,14 +61,15 @@ func parseNominal(s string) (int, error) {
 }
 
 // parseCurrency конвертирует трехбуквенный код валюты в тип Currency
-// Возвращает ошибку для неподдерживаемых валют
+// Принимает любой корректный код ISO 4217, в том числе ещё не известный справочнику:
+// ЦБ РФ может начать публиковать новую валюту в любой момент
+// Возвращает ошибку для некорректных кодов
 func parseCurrency(code string) (models.Currency, error) {
 	code = strings.ToUpper(strings.TrimSpace(code))
 
-	currency := models.Currency(code)
-	if err := currency.Validate(); err != nil {
+	if !models.IsCurrencyCode(code) {
 		return "", fmt.Errorf("%w: %s", models.ErrUnsupportedCurrency, code)
 	}
 
-	return currency, nil
+	return models.Currency(code), nil
 }
@@ -13,73 +13,73 @@ func TestParseRate(t *testing.T) {
 	tests := []struct {
 		name    string
 		input   string
-		want    float64
+		want    string
 		wantErr error
 	}{
 		{
 			name:    "Стандартный формат с запятой",
 			input:   "80,7220",
-			want:    80.7220,
+			want:    "80.7220",
 			wantErr: nil,
 		},
 		{
 			name:    "Формат с точкой",
 			input:   "80.7220",
-			want:    80.7220,
+			want:    "80.7220",
 			wantErr: nil,
 		},
 		{
 			name:    "Целое число",
 			input:   "100",
-			want:    100.0,
+			want:    "100.0",
 			wantErr: nil,
 		},
 		{
 			name:    "С пробелами",
 			input:   "  94,5120  ",
-			want:    94.5120,
+			want:    "94.5120",
 			wantErr: nil,
 		},
 		{
 			name:    "Пустая строка",
 			input:   "",
-			want:    0,
+			want:    "",
 			wantErr: ErrInvalidRate,
 		},
 		{
 			name:    "Некорректный формат",
 			input:   "abc",
-			want:    0,
+			want:    "",
 			wantErr: ErrInvalidRate,
 		},
 		{
 			name:    "Отрицательное значение",
 			input:   "-50,5",
-			want:    0,
+			want:    "",
 			wantErr: ErrInvalidRate,
 		},
 		{
 			name:    "Ноль",
 			input:   "0",
-			want:    0,
+			want:    "",
 			wantErr: ErrInvalidRate,
 		},
 		{
 			name:    "Infinity",
 			input:   "Inf",
-			want:    0,
+			want:    "",
 			wantErr: ErrInvalidRate,
 		},
 		{
 			name:    "Negative Infinity",
 			input:   "-Inf",
-			want:    0,
+			want:    "",
 			wantErr: ErrInvalidRate,
 		},
 		{
 			name:    "NaN",
 			input:   "NaN",
-			want:    0,
+			want:    "",
 			wantErr: ErrInvalidRate,
 		},
 	}
@@ -99,7 +99,7 @@ func TestParseRate(t *testing.T) {
 				if err != nil {
 					t.Fatalf("Неожиданная ошибка: %v", err)
 				}
-				if got != tt.want {
+				if !got.Equal(dec(tt.want)) {
 					t.Errorf("Результат: ожидалось %v, получено %v", tt.want, got)
 				}
 			}
@@ -231,8 +231,14 @@ func TestParseCurrency(t *testing.T) {
 			wantErr: nil,
 		},
 		{
-			name:    "Неподдерживаемая валюта",
+			name:    "Валюта вне встроенного справочника",
 			input:   "XDR",
+			want:    models.Currency("XDR"),
+			wantErr: nil,
+		},
+		{
+			name:    "Код с цифрами",
+			input:   "US1",
 			want:    "",
 			wantErr: models.ErrUnsupportedCurrency,
 		},
@@ -0,0 +1,162 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxRetryElapsed - общий бюджет времени на попытки и ожидания между ними по умолчанию
+const MaxRetryElapsed = 30 * time.Second
+
+// RetryStrategy - стратегия повторных запросов CBRClient
+// Реализации должны быть безопасны для конкурентного использования
+type RetryStrategy interface {
+	// Retryable сообщает, имеет ли смысл повторять запрос после ошибки err
+	// Ошибка, которую повторять бессмысленно, возвращается вызывающему как есть
+	Retryable(err error) bool
+
+	// NextDelay вызывается после неудачной попытки attempt (нумерация с 1);
+	// elapsed - время с начала первой попытки
+	// Возвращает задержку перед следующей попыткой или false, если попытки исчерпаны
+	NextDelay(attempt int, elapsed time.Duration, err error) (time.Duration, bool)
+}
+
+// RetryPolicy - стратегия по умолчанию: exponential backoff со случайным разбросом
+// Повторяются сетевые ошибки, ответы 5xx и 429; прочие клиентские ошибки (4xx) и SOAP Fault не повторяются
+// Задержку из заголовка Retry-After (429, 503) сервер задает сам - она используется без разброса
+type RetryPolicy struct {
+	MaxAttempts int           // Всего попыток, включая первую (1 - без повторов)
+	BaseDelay   time.Duration // Задержка перед второй попыткой, далее удваивается (1s, 2s, 4s)
+	Jitter      float64       // Доля случайного разброса задержки: 0.2 - ±20% (0 - без разброса)
+	MaxElapsed  time.Duration // Общий бюджет на попытки и ожидания (0 - без ограничения)
+}
+
+// DefaultRetryPolicy - MaxRetries попыток с exponential backoff от BaseRetryDelay, разбросом ±20%
+// и бюджетом MaxRetryElapsed: клиенты, ожидавшие восстановления cbr.ru, не повторяют запросы одновременно
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: MaxRetries,
+	BaseDelay:   BaseRetryDelay,
+	Jitter:      0.2,
+	MaxElapsed:  MaxRetryElapsed,
+}
+
+// retryRandom возвращает случайное число в [0, 1) для разброса задержки (подменяется в тестах)
+var retryRandom = rand.Float64
+
+// Retryable реализует интерфейс RetryStrategy
+func (p RetryPolicy) Retryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return !errors.Is(err, ErrInvalidStatus) && !errors.Is(err, ErrSOAPFault)
+}
+
+// NextDelay реализует интерфейс RetryStrategy
+func (p RetryPolicy) NextDelay(attempt int, elapsed time.Duration, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts {
+		return 0, false
+	}
+
+	delay := p.backoff(attempt)
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 {
+		delay = statusErr.RetryAfter
+	}
+
+	// Ожидание, после которого бюджет исчерпан, бессмысленно
+	if p.MaxElapsed > 0 && elapsed+delay >= p.MaxElapsed {
+		return 0, false
+	}
+	return delay, true
+}
+
+// backoff возвращает задержку после неудачной попытки attempt с учетом разброса
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay * time.Duration(1<<uint(attempt-1))
+	if p.Jitter > 0 {
+		delay = time.Duration(float64(delay) * (1 + p.Jitter*(2*retryRandom()-1)))
+	}
+	return delay
+}
+
+// StatusError - ответ сервера с кодом, отличным от 200
+// Для 3xx и 4xx соответствует ErrInvalidStatus, для 5xx - ErrHTTPFailed
+type StatusError struct {
+	StatusCode int
+	Status     string
+	RetryAfter time.Duration // Задержка из заголовка Retry-After (0 - заголовка нет)
+}
+
+// newStatusError создает ошибку по ответу сервера
+func newStatusError(resp *http.Response) *StatusError {
+	return &StatusError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+}
+
+// Error реализует интерфейс error
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%v: %d %s", e.Unwrap(), e.StatusCode, e.Status)
+}
+
+// Unwrap возвращает ErrInvalidStatus для клиентских ошибок (4xx) и ответов 3xx, которые не обработал
+// HTTP клиент (например, 304 на безусловный запрос): сервер работает, повтор вернет тот же ответ;
+// для прочих кодов - ErrHTTPFailed
+func (e *StatusError) Unwrap() error {
+	if e.StatusCode >= 300 && e.StatusCode < 500 {
+		return ErrInvalidStatus
+	}
+	return ErrHTTPFailed
+}
+
+// parseRetryAfter разбирает заголовок Retry-After: число секунд или HTTP-дата (RFC 9110)
+// Некорректное значение и дата в прошлом дают 0
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// RetryError - запрос не удался после нескольких попыток
+// Содержит ошибку каждой попытки по порядку; errors.Is находит ErrMaxRetries и ошибки попыток
+type RetryError struct {
+	Attempts []error
+}
+
+// Error реализует интерфейс error
+func (e *RetryError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v after %d attempts", ErrMaxRetries, len(e.Attempts))
+	for i, err := range e.Attempts {
+		sep := "; "
+		if i == 0 {
+			sep = ": "
+		}
+		fmt.Fprintf(&b, "%sattempt %d: %v", sep, i+1, err)
+	}
+	return b.String()
+}
+
+// Unwrap возвращает ErrMaxRetries и ошибки всех попыток
+func (e *RetryError) Unwrap() []error {
+	return append([]error{ErrMaxRetries}, e.Attempts...)
+}
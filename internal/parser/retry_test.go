@@ -0,0 +1,213 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func setTestRetryRandom(t *testing.T, value float64) {
+	t.Helper()
+	original := retryRandom
+	retryRandom = func() float64 { return value }
+	t.Cleanup(func() {
+		retryRandom = original
+	})
+}
+
+// setTestSleep подменяет ожидание между попытками и возвращает записанные задержки
+func setTestSleep(t *testing.T) *[]time.Duration {
+	t.Helper()
+	var delays []time.Duration
+	original := sleepWithContext
+	sleepWithContext = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	t.Cleanup(func() {
+		sleepWithContext = original
+	})
+	return &delays
+}
+
+func TestRetryPolicy_NextDelay(t *testing.T) {
+	serverErr := &StatusError{StatusCode: http.StatusBadGateway, Status: "502 Bad Gateway"}
+	throttled := &StatusError{StatusCode: http.StatusTooManyRequests, Status: "429 Too Many Requests", RetryAfter: 20 * time.Second}
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second}
+
+	tests := []struct {
+		name      string
+		policy    RetryPolicy
+		random    float64
+		attempt   int
+		elapsed   time.Duration
+		err       error
+		wantDelay time.Duration
+		wantRetry bool
+	}{
+		{name: "Первый повтор", policy: policy, attempt: 1, err: serverErr, wantDelay: time.Second, wantRetry: true},
+		{name: "Второй повтор - удвоенная задержка", policy: policy, attempt: 2, err: serverErr, wantDelay: 2 * time.Second, wantRetry: true},
+		{name: "Попытки исчерпаны", policy: policy, attempt: 3, err: serverErr},
+		{name: "Разброс вниз", policy: DefaultRetryPolicy, random: 0, attempt: 2, err: serverErr, wantDelay: 1600 * time.Millisecond, wantRetry: true},
+		{name: "Разброс вверх", policy: DefaultRetryPolicy, random: 1, attempt: 2, err: serverErr, wantDelay: 2400 * time.Millisecond, wantRetry: true},
+		{name: "Retry-After без разброса", policy: DefaultRetryPolicy, random: 1, attempt: 1, err: throttled, wantDelay: 20 * time.Second, wantRetry: true},
+		{name: "Retry-After за пределами бюджета", policy: DefaultRetryPolicy, attempt: 1, elapsed: 15 * time.Second, err: throttled},
+		{name: "Бюджет исчерпан", policy: DefaultRetryPolicy, attempt: 1, elapsed: MaxRetryElapsed, err: serverErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setTestRetryRandom(t, tt.random)
+			delay, retry := tt.policy.NextDelay(tt.attempt, tt.elapsed, tt.err)
+			if delay != tt.wantDelay || retry != tt.wantRetry {
+				t.Errorf("NextDelay() = %v, %v; ожидалось %v, %v", delay, retry, tt.wantDelay, tt.wantRetry)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_Retryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"Сетевая ошибка", ErrHTTPFailed, true},
+		{"503", &StatusError{StatusCode: http.StatusServiceUnavailable}, true},
+		{"429", &StatusError{StatusCode: http.StatusTooManyRequests}, true},
+		{"404", &StatusError{StatusCode: http.StatusNotFound}, false},
+		{"SOAP Fault", ErrSOAPFault, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DefaultRetryPolicy.Retryable(tt.err); got != tt.want {
+				t.Errorf("Retryable(%v) = %v, ожидалось %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 12, 20, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"120", 2 * time.Minute},
+		{" 5 ", 5 * time.Second},
+		{"-1", 0},
+		{"Sat, 20 Dec 2025 12:00:30 GMT", 30 * time.Second},
+		{"Sat, 20 Dec 2025 11:59:00 GMT", 0},
+		{"soon", 0},
+	}
+
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value, now); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, ожидалось %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestCBRClient_RetryAfter(t *testing.T) {
+	delays := setTestSleep(t)
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(testDailyXML))
+	}))
+	defer server.Close()
+
+	client := NewCBRClient(CBRClientOptions{BaseURL: server.URL})
+	if _, err := client.FetchRates(context.Background(), testPastDateUTC()); err != nil {
+		t.Fatalf("FetchRates() error = %v", err)
+	}
+	if attempts != 2 || len(*delays) != 1 || (*delays)[0] != 7*time.Second {
+		t.Errorf("Попыток %d, задержки %v; ожидалось 2 попытки и ожидание 7s из Retry-After", attempts, *delays)
+	}
+}
+
+func TestCBRClient_RetryError(t *testing.T) {
+	setTestSleep(t)
+
+	t.Run("Ошибки всех попыток", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts == 2 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		_, err := NewCBRClient(CBRClientOptions{BaseURL: server.URL}).fetchXML(context.Background(), server.URL)
+
+		var retryErr *RetryError
+		if !errors.As(err, &retryErr) || len(retryErr.Attempts) != MaxRetries {
+			t.Fatalf("fetchXML() error = %v, ожидалась RetryError с %d попытками", err, MaxRetries)
+		}
+		var statusErr *StatusError
+		if !errors.As(retryErr.Attempts[1], &statusErr) || statusErr.StatusCode != http.StatusBadGateway {
+			t.Errorf("Попытка 2: %v, ожидался ответ 502", retryErr.Attempts[1])
+		}
+		if !errors.Is(err, ErrMaxRetries) || !errors.Is(err, ErrHTTPFailed) {
+			t.Errorf("Ошибка должна соответствовать ErrMaxRetries и ErrHTTPFailed: %v", err)
+		}
+		if msg := err.Error(); !strings.Contains(msg, "attempt 2: HTTP request failed: 502") {
+			t.Errorf("Сообщение без ошибки второй попытки: %s", msg)
+		}
+	})
+
+	t.Run("Retry-After за пределами бюджета", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		_, err := NewCBRClient(CBRClientOptions{BaseURL: server.URL}).fetchXML(context.Background(), server.URL)
+		if !errors.Is(err, ErrMaxRetries) || attempts != 1 {
+			t.Errorf("Попыток %d, ошибка %v; ожидалась 1 попытка и ErrMaxRetries", attempts, err)
+		}
+	})
+}
+
+// noRetryStrategy - стратегия без повторов
+type noRetryStrategy struct{}
+
+func (noRetryStrategy) Retryable(error) bool { return true }
+
+func (noRetryStrategy) NextDelay(int, time.Duration, error) (time.Duration, bool) { return 0, false }
+
+func TestCBRClient_CustomRetryStrategy(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewCBRClient(CBRClientOptions{BaseURL: server.URL, Retry: noRetryStrategy{}})
+	if _, err := client.FetchRates(context.Background(), testPastDateUTC()); !errors.Is(err, ErrMaxRetries) {
+		t.Errorf("FetchRates() error = %v, ожидалась ErrMaxRetries", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Попыток: %d, ожидалась 1", attempts)
+	}
+}
@@ -0,0 +1,313 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+// SOAP константы
+const (
+	// CBRSOAPURL - адрес SOAP веб-сервиса ЦБ РФ DailyInfo
+	CBRSOAPURL = "https://www.cbr.ru/DailyInfoWebServ/DailyInfo.asmx"
+
+	// cbrSOAPNamespace - пространство имен методов DailyInfo (и префикс SOAPAction)
+	cbrSOAPNamespace = "http://web.cbr.ru/"
+
+	// soapEnvelopeNamespace - пространство имен конверта SOAP 1.1
+	soapEnvelopeNamespace = "http://schemas.xmlsoap.org/soap/envelope/"
+)
+
+// Ошибки SOAP клиента
+var (
+	ErrSOAPFault = errors.New("SOAP fault")
+)
+
+// SOAPClient - клиент SOAP веб-сервиса ЦБ РФ DailyInfo (DailyInfoWebServ/DailyInfo.asmx)
+// Сервис публикует те же курсы, что и XML_daily.asp, и бывает доступен, когда XML API
+// ограничивает частоту запросов, поэтому подходит как резервный источник в converter.ProviderChain
+// Реализует converter.RateProvider
+//
+// Пример использования:
+//
+//	soap := parser.NewSOAPClient(parser.CBRSOAPURL)
+//	rates, err := soap.FetchRates(ctx, date)
+type SOAPClient struct {
+	endpoint string
+	client   *CBRClient // nil - клиент по умолчанию
+}
+
+// NewSOAPClient создает SOAP клиент DailyInfo
+// endpoint - адрес сервиса (обычно CBRSOAPURL)
+func NewSOAPClient(endpoint string) *SOAPClient {
+	return &SOAPClient{endpoint: endpoint}
+}
+
+// WithClient возвращает SOAP клиент, который вызывает сервис HTTP клиентом client
+// с его User-Agent, повторами и circuit breaker хоста endpoint (BaseURL клиента не используется)
+//
+// Пример использования:
+//
+//	client := parser.NewCBRClient(parser.CBRClientOptions{Proxy: proxyURL})
+//	soap := parser.NewSOAPClient(parser.CBRSOAPURL).WithClient(client)
+func (c *SOAPClient) WithClient(client *CBRClient) *SOAPClient {
+	return &SOAPClient{endpoint: c.endpoint, client: client}
+}
+
+// soapParam - параметр метода в конверте запроса
+type soapParam struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+// soapRequest - конверт запроса SOAP 1.1
+type soapRequest struct {
+	XMLName xml.Name `xml:"soap:Envelope"`
+	SOAP    string   `xml:"xmlns:soap,attr"`
+	Method  struct {
+		XMLName xml.Name
+		Params  []soapParam
+	} `xml:"soap:Body>Method"`
+}
+
+// soapFault - ошибка, которую вернул SOAP сервис
+type soapFault struct {
+	Code   string `xml:"faultcode"`
+	String string `xml:"faultstring"`
+}
+
+// ValuteData представляет DataSet ответа GetCursOnDate
+// Пример:
+//
+//	<ValuteData xmlns="" OnDate="20251220">
+//	    <ValuteCursOnDate diffgr:id="ValuteCursOnDate1" msdata:rowOrder="0">
+//	        <Vname>Доллар США</Vname>
+//	        <Vnom>1</Vnom>
+//	        <Vcurs>80.7220</Vcurs>
+//	        <Vcode>840</Vcode>
+//	        <VchCode>USD</VchCode>
+//	    </ValuteCursOnDate>
+//	</ValuteData>
+type ValuteData struct {
+	OnDate  string             `xml:"OnDate,attr"` // Дата курсов в формате 20060102
+	Valutes []ValuteCursOnDate `xml:"ValuteCursOnDate"`
+}
+
+// ValuteCursOnDate представляет курс одной валюты в ответе GetCursOnDate
+type ValuteCursOnDate struct {
+	Name     string `xml:"Vname"` // Дополнено пробелами до ширины столбца DataSet
+	Nominal  string `xml:"Vnom"`
+	Rate     string `xml:"Vcurs"` // С точкой как десятичным разделителем
+	NumCode  string `xml:"Vcode"`
+	CharCode string `xml:"VchCode"`
+}
+
+// KeyRateData представляет DataSet ответа KeyRate
+// Пример:
+//
+//	<KeyRate xmlns="">
+//	    <KR diffgr:id="KR1" msdata:rowOrder="0">
+//	        <DT>2025-12-19T00:00:00+03:00</DT>
+//	        <Rate>16.00</Rate>
+//	    </KR>
+//	</KeyRate>
+type KeyRateData struct {
+	Rows []KeyRateRow `xml:"KR"`
+}
+
+// KeyRateRow представляет ключевую ставку на один рабочий день в ответе KeyRate
+type KeyRateRow struct {
+	Date string `xml:"DT"`   // Формат 2006-01-02T15:04:05-07:00 (время Москвы)
+	Rate string `xml:"Rate"` // Процентов годовых, с точкой как десятичным разделителем
+}
+
+// FetchRates получает курсы валют на дату методом GetCursOnDate
+// Реализует интерфейс converter.RateProvider
+func (c *SOAPClient) FetchRates(ctx context.Context, date time.Time) (*models.RateData, error) {
+	var response struct {
+		Data ValuteData `xml:"Body>GetCursOnDateResponse>GetCursOnDateResult>diffgram>ValuteData"`
+	}
+	params := []soapParam{{XMLName: xml.Name{Local: "On_date"}, Value: date.Format("2006-01-02T15:04:05")}}
+	if err := c.call(ctx, "GetCursOnDate", params, &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch rates from CBR SOAP: %w", err)
+	}
+
+	return parseValuteData(response.Data, date)
+}
+
+// FetchKeyRates получает ключевую ставку за период методом KeyRate
+// Сервис возвращает ставку на каждый рабочий день периода (ключевая ставка введена 13.09.2013)
+// Реализует интерфейс converter.KeyRateProvider
+func (c *SOAPClient) FetchKeyRates(ctx context.Context, from, to time.Time) ([]models.KeyRate, error) {
+	var response struct {
+		Data KeyRateData `xml:"Body>KeyRateResponse>KeyRateResult>diffgram>KeyRate"`
+	}
+	params := []soapParam{
+		{XMLName: xml.Name{Local: "fromDate"}, Value: from.Format("2006-01-02T15:04:05")},
+		{XMLName: xml.Name{Local: "ToDate"}, Value: to.Format("2006-01-02T15:04:05")},
+	}
+	if err := c.call(ctx, "KeyRate", params, &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch key rate from CBR SOAP: %w", err)
+	}
+
+	return parseKeyRateData(response.Data, from.Location())
+}
+
+// call вызывает метод DailyInfo и декодирует конверт ответа в result
+// result описывает путь к данным от корня конверта ("Body>...Response>...Result>...")
+func (c *SOAPClient) call(ctx context.Context, method string, params []soapParam, result any) error {
+	var envelope soapRequest
+	envelope.SOAP = soapEnvelopeNamespace
+	envelope.Method.XMLName = xml.Name{Space: cbrSOAPNamespace, Local: method}
+	// Параметры в пространстве имен метода: иначе сервис их не находит
+	for _, param := range params {
+		param.XMLName.Space = cbrSOAPNamespace
+		envelope.Method.Params = append(envelope.Method.Params, param)
+	}
+
+	body, err := xml.Marshal(envelope)
+	if err != nil {
+		return fmt.Errorf("failed to build SOAP envelope: %w", err)
+	}
+
+	client := c.client
+	if client == nil {
+		client = defaultClient()
+	}
+	body = append([]byte(xml.Header), body...)
+	resp, err := client.withRetries(ctx, c.endpoint, func() (*http.Response, error) {
+		return c.post(ctx, client, method, body)
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := readXML(resp.Body)
+	if err != nil {
+		return err
+	}
+	if err := unmarshalXML(data, result); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidXML, err)
+	}
+	return nil
+}
+
+// post выполняет одиночный запрос к сервису и возвращает ответ 200
+// Клиентские ошибки (4xx) не содержат конверта; ошибки метода приходят со статусом 500 и Fault
+// (ErrSOAPFault стратегия по умолчанию не повторяет), прочие ответы 5xx - StatusError
+func (c *SOAPClient) post(ctx context.Context, client *CBRClient, method string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/xml; charset=utf-8")
+	req.Header.Set("SOAPAction", `"`+cbrSOAPNamespace+method+`"`)
+	req.Header.Set("User-Agent", client.userAgent)
+
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrHTTPFailed, err)
+	}
+	if resp.StatusCode == http.StatusOK {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 500 {
+		var fault struct {
+			Fault *soapFault `xml:"Body>Fault"`
+		}
+		if data, err := readXML(resp.Body); err == nil && unmarshalXML(data, &fault) == nil && fault.Fault != nil {
+			return nil, fmt.Errorf("%w: %s: %s", ErrSOAPFault, strings.TrimSpace(fault.Fault.Code), strings.TrimSpace(fault.Fault.String))
+		}
+	}
+	return nil, newStatusError(resp)
+}
+
+// parseValuteData преобразует DataSet GetCursOnDate в RateData
+// Валюты с некорректным кодом, курсом или номиналом пропускаются, как в ParseXML
+func parseValuteData(data ValuteData, date time.Time) (*models.RateData, error) {
+	// Дата курсов из ответа (ЦБ РФ может вернуть прошлый рабочий день)
+	parsedDate := date
+	if data.OnDate != "" {
+		if parsed, err := time.ParseInLocation("20060102", data.OnDate, date.Location()); err == nil {
+			parsedDate = parsed
+		}
+	}
+
+	rateData := models.NewRateData(parsedDate)
+	for _, valute := range data.Valutes {
+		currency, err := parseCurrency(valute.CharCode)
+		if err != nil {
+			continue
+		}
+		rate, err := parseXMLValue(valute.Rate)
+		if err != nil {
+			continue
+		}
+		nominal, err := parseNominal(valute.Nominal)
+		if err != nil {
+			continue
+		}
+
+		rateData.AddRate(models.ExchangeRate{
+			Currency: currency,
+			Rate:     rate,
+			Nominal:  nominal,
+			Date:     parsedDate,
+		})
+
+		// Числовой код в DataSet без ведущих нулей, ID ЦБ РФ нет - дополняем справочник только названием
+		models.RegisterCurrency(models.CurrencyInfo{
+			Code:    currency,
+			Name:    strings.TrimSpace(valute.Name),
+			Nominal: nominal,
+		})
+	}
+
+	if len(rateData.Rates) == 0 {
+		return nil, ErrNoXMLRates
+	}
+	return rateData, nil
+}
+
+// parseKeyRateData преобразует DataSet KeyRate в ставки по возрастанию даты
+// Дата берется календарной (день по Москве) в часовом поясе loc, как даты курсов
+// Строки с некорректной датой или ставкой пропускаются; пустой DataSet (ставка за период
+// не устанавливалась) - не ошибка
+func parseKeyRateData(data KeyRateData, loc *time.Location) ([]models.KeyRate, error) {
+	rates := make([]models.KeyRate, 0, len(data.Rows))
+	for _, row := range data.Rows {
+		parsed, err := time.Parse(time.RFC3339, strings.TrimSpace(row.Date))
+		if err != nil {
+			continue
+		}
+		rate, err := parseXMLValue(row.Rate)
+		if err != nil {
+			continue
+		}
+		rates = append(rates, models.KeyRate{
+			Date: time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, loc),
+			Rate: rate,
+		})
+	}
+
+	if len(rates) == 0 && len(data.Rows) > 0 {
+		return nil, ErrNoXMLRates
+	}
+
+	// Сервис отдает строки по убыванию даты
+	sort.SliceStable(rates, func(i, j int) bool {
+		return rates[i].Date.Before(rates[j].Date)
+	})
+	return rates, nil
+}
@@ -0,0 +1,310 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/converter"
+	"github.com/bivlked/currate-go/internal/models"
+)
+
+// SOAPClient подключается к цепочке источников и к converter.KeyRates без адаптера
+var (
+	_ converter.RateProvider    = (*SOAPClient)(nil)
+	_ converter.KeyRateProvider = (*SOAPClient)(nil)
+)
+
+// Записанный ответ GetCursOnDate (сокращен до трех валют)
+const soapCursOnDateResponse = `<?xml version="1.0" encoding="utf-8"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns:xsd="http://www.w3.org/2001/XMLSchema">
+<soap:Body>
+<GetCursOnDateResponse xmlns="http://web.cbr.ru/">
+<GetCursOnDateResult>
+<xs:schema id="ValuteData" xmlns="" xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:msdata="urn:schemas-microsoft-com:xml-msdata">
+<xs:element name="ValuteData" msdata:IsDataSet="true"><xs:complexType><xs:choice minOccurs="0" maxOccurs="unbounded">
+<xs:element name="ValuteCursOnDate"><xs:complexType><xs:sequence>
+<xs:element name="Vname" type="xs:string" minOccurs="0" />
+<xs:element name="Vnom" type="xs:decimal" minOccurs="0" />
+<xs:element name="Vcurs" type="xs:decimal" minOccurs="0" />
+<xs:element name="Vcode" type="xs:int" minOccurs="0" />
+<xs:element name="VchCode" type="xs:string" minOccurs="0" />
+</xs:sequence></xs:complexType></xs:element>
+</xs:choice></xs:complexType></xs:element>
+</xs:schema>
+<diffgr:diffgram xmlns:msdata="urn:schemas-microsoft-com:xml-msdata" xmlns:diffgr="urn:schemas-microsoft-com:xml-diffgram-v1">
+<ValuteData xmlns="" OnDate="20251220">
+<ValuteCursOnDate diffgr:id="ValuteCursOnDate1" msdata:rowOrder="0">
+<Vname>Доллар США                                                                                                                                                                                                                                                     </Vname>
+<Vnom>1</Vnom>
+<Vcurs>80.7220</Vcurs>
+<Vcode>840</Vcode>
+<VchCode>USD</VchCode>
+</ValuteCursOnDate>
+<ValuteCursOnDate diffgr:id="ValuteCursOnDate2" msdata:rowOrder="1">
+<Vname>Японских иен                                                                                                                                                                                                                                                   </Vname>
+<Vnom>100</Vnom>
+<Vcurs>51.2345</Vcurs>
+<Vcode>392</Vcode>
+<VchCode>JPY</VchCode>
+</ValuteCursOnDate>
+<ValuteCursOnDate diffgr:id="ValuteCursOnDate3" msdata:rowOrder="2">
+<Vname>Некорректная запись</Vname>
+<Vnom>1</Vnom>
+<Vcurs>-</Vcurs>
+<Vcode>978</Vcode>
+<VchCode>EUR</VchCode>
+</ValuteCursOnDate>
+</ValuteData>
+</diffgr:diffgram>
+</GetCursOnDateResult>
+</GetCursOnDateResponse>
+</soap:Body>
+</soap:Envelope>`
+
+// Записанный ответ сервиса на некорректный параметр
+const soapFaultResponse = `<?xml version="1.0" encoding="utf-8"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+<soap:Body>
+<soap:Fault>
+<faultcode>soap:Client</faultcode>
+<faultstring>Server was unable to read request. ---&gt; There is an error in XML document (1, 250).</faultstring>
+<detail />
+</soap:Fault>
+</soap:Body>
+</soap:Envelope>`
+
+// newSOAPServer запускает сервер с записанным ответом и сохраняет полученный запрос
+func newSOAPServer(t *testing.T, status int, response string, request *http.Request, body *string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		*request = *r.Clone(context.Background())
+		*body = string(data)
+		w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, response)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestSOAPClient_FetchRates(t *testing.T) {
+	var request http.Request
+	var body string
+	server := newSOAPServer(t, http.StatusOK, soapCursOnDateResponse, &request, &body)
+
+	date := time.Date(2025, 12, 21, 0, 0, 0, 0, time.UTC) // Воскресенье
+	rateData, err := NewSOAPClient(server.URL).FetchRates(context.Background(), date)
+	if err != nil {
+		t.Fatalf("FetchRates() error = %v", err)
+	}
+
+	// Конверт запроса
+	if request.Method != http.MethodPost {
+		t.Errorf("Метод = %s, ожидался POST", request.Method)
+	}
+	if got := request.Header.Get("SOAPAction"); got != `"http://web.cbr.ru/GetCursOnDate"` {
+		t.Errorf("SOAPAction = %s", got)
+	}
+	for _, want := range []string{
+		`<GetCursOnDate xmlns="http://web.cbr.ru/">`,
+		`<On_date xmlns="http://web.cbr.ru/">2025-12-21T00:00:00</On_date>`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Конверт запроса не содержит %s:\n%s", want, body)
+		}
+	}
+
+	// Дата из OnDate, курсы с номиналом; запись с некорректным курсом пропущена
+	saturday := time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC)
+	if !rateData.Date.Equal(saturday) {
+		t.Errorf("Дата = %v, ожидалась %v из OnDate", rateData.Date, saturday)
+	}
+	if len(rateData.Rates) != 2 {
+		t.Errorf("Ожидалось 2 валюты, получено %d", len(rateData.Rates))
+	}
+	if usd := rateData.Rates[models.USD]; !usd.Rate.Equal(dec("80.7220")) || usd.Nominal != 1 {
+		t.Errorf("USD = %s за %d, ожидалось 80.7220 за 1", usd.Rate, usd.Nominal)
+	}
+	if jpy := rateData.Rates["JPY"]; !jpy.Rate.Equal(dec("51.2345")) || jpy.Nominal != 100 || !jpy.Date.Equal(saturday) {
+		t.Errorf("JPY = %+v, ожидалось 51.2345 за 100 на %v", jpy, saturday)
+	}
+	if info, ok := models.LookupCurrency(models.USD); !ok || info.Name != "Доллар США" {
+		t.Errorf("Название USD в справочнике = %q, ожидалось без пробелов DataSet", info.Name)
+	}
+}
+
+func TestSOAPClient_FetchRates_Errors(t *testing.T) {
+	setTestSleep(t)
+
+	tests := []struct {
+		name     string
+		status   int
+		response string
+		wantErr  error
+	}{
+		{
+			name:     "SOAP Fault",
+			status:   http.StatusInternalServerError,
+			response: soapFaultResponse,
+			wantErr:  ErrSOAPFault,
+		},
+		{
+			name:     "Ошибка сервера без конверта",
+			status:   http.StatusServiceUnavailable,
+			response: "Service Unavailable",
+			wantErr:  ErrHTTPFailed,
+		},
+		{
+			name:     "Клиентская ошибка",
+			status:   http.StatusNotFound,
+			response: "Not Found",
+			wantErr:  ErrInvalidStatus,
+		},
+		{
+			name:     "Некорректный XML",
+			status:   http.StatusOK,
+			response: "<soap:Envelope",
+			wantErr:  ErrInvalidXML,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var request http.Request
+			var body string
+			server := newSOAPServer(t, tt.status, tt.response, &request, &body)
+
+			_, err := NewSOAPClient(server.URL).FetchRates(context.Background(), testPastDateUTC())
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FetchRates() error = %v, ожидалась %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSOAPClient_Retries(t *testing.T) {
+	delays := setTestSleep(t)
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = io.WriteString(w, soapCursOnDateResponse)
+	}))
+	defer server.Close()
+
+	if _, err := NewSOAPClient(server.URL).FetchRates(context.Background(), testPastDateUTC()); err != nil {
+		t.Fatalf("FetchRates() error = %v", err)
+	}
+	if attempts != 2 || len(*delays) != 1 {
+		t.Errorf("Попыток %d, ожиданий %d; ожидался повтор после 503", attempts, len(*delays))
+	}
+
+	// Ошибка метода (SOAP Fault) не повторяется
+	attempts = 0
+	fault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = io.WriteString(w, soapFaultResponse)
+	}))
+	defer fault.Close()
+
+	if _, err := NewSOAPClient(fault.URL).FetchRates(context.Background(), testPastDateUTC()); !errors.Is(err, ErrSOAPFault) || attempts != 1 {
+		t.Errorf("Попыток %d, ошибка %v; ожидалась 1 попытка и ErrSOAPFault", attempts, err)
+	}
+}
+
+func TestParseValuteData_NoRates(t *testing.T) {
+	data := ValuteData{OnDate: "20251220", Valutes: []ValuteCursOnDate{{CharCode: "USD", Nominal: "1", Rate: "abc"}}}
+	if _, err := parseValuteData(data, testPastDateUTC()); !errors.Is(err, ErrNoXMLRates) {
+		t.Errorf("parseValuteData() error = %v, ожидалась ErrNoXMLRates", err)
+	}
+}
+
+// Записанный ответ KeyRate (строки по убыванию даты, как у сервиса)
+const soapKeyRateResponse = `<?xml version="1.0" encoding="utf-8"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns:xsd="http://www.w3.org/2001/XMLSchema">
+<soap:Body>
+<KeyRateResponse xmlns="http://web.cbr.ru/">
+<KeyRateResult>
+<xs:schema id="KeyRate" xmlns="" xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:msdata="urn:schemas-microsoft-com:xml-msdata">
+<xs:element name="KeyRate" msdata:IsDataSet="true"><xs:complexType><xs:choice minOccurs="0" maxOccurs="unbounded">
+<xs:element name="KR"><xs:complexType><xs:sequence>
+<xs:element name="DT" type="xs:dateTime" minOccurs="0" />
+<xs:element name="Rate" type="xs:decimal" minOccurs="0" />
+</xs:sequence></xs:complexType></xs:element>
+</xs:choice></xs:complexType></xs:element>
+</xs:schema>
+<diffgr:diffgram xmlns:msdata="urn:schemas-microsoft-com:xml-msdata" xmlns:diffgr="urn:schemas-microsoft-com:xml-diffgram-v1">
+<KeyRate xmlns="">
+<KR diffgr:id="KR1" msdata:rowOrder="0"><DT>2025-12-22T00:00:00+03:00</DT><Rate>16.00</Rate></KR>
+<KR diffgr:id="KR2" msdata:rowOrder="1"><DT>2025-12-19T00:00:00+03:00</DT><Rate>16.50</Rate></KR>
+<KR diffgr:id="KR3" msdata:rowOrder="2"><DT>2025-12-18T00:00:00+03:00</DT><Rate>-</Rate></KR>
+</KeyRate>
+</diffgr:diffgram>
+</KeyRateResult>
+</KeyRateResponse>
+</soap:Body>
+</soap:Envelope>`
+
+func TestSOAPClient_FetchKeyRates(t *testing.T) {
+	var request http.Request
+	var body string
+	server := newSOAPServer(t, http.StatusOK, soapKeyRateResponse, &request, &body)
+
+	from := time.Date(2025, 12, 15, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 12, 22, 0, 0, 0, 0, time.UTC)
+	rates, err := NewSOAPClient(server.URL).FetchKeyRates(context.Background(), from, to)
+	if err != nil {
+		t.Fatalf("FetchKeyRates() error = %v", err)
+	}
+
+	if got := request.Header.Get("SOAPAction"); got != `"http://web.cbr.ru/KeyRate"` {
+		t.Errorf("SOAPAction = %s", got)
+	}
+	for _, want := range []string{
+		`<fromDate xmlns="http://web.cbr.ru/">2025-12-15T00:00:00</fromDate>`,
+		`<ToDate xmlns="http://web.cbr.ru/">2025-12-22T00:00:00</ToDate>`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Конверт запроса не содержит %s:\n%s", want, body)
+		}
+	}
+
+	// Строка с некорректной ставкой пропущена, остальные - по возрастанию даты, день по Москве
+	want := []models.KeyRate{
+		{Date: time.Date(2025, 12, 19, 0, 0, 0, 0, time.UTC), Rate: dec("16.50")},
+		{Date: time.Date(2025, 12, 22, 0, 0, 0, 0, time.UTC), Rate: dec("16.00")},
+	}
+	if len(rates) != len(want) {
+		t.Fatalf("Получено %d ставок, ожидалось %d", len(rates), len(want))
+	}
+	for i, w := range want {
+		if !rates[i].Date.Equal(w.Date) || !rates[i].Rate.Equal(w.Rate) {
+			t.Errorf("Ставка %d = %+v, ожидалось %+v", i, rates[i], w)
+		}
+	}
+}
+
+func TestParseKeyRateData(t *testing.T) {
+	// Пустой DataSet - ставка за период не устанавливалась
+	rates, err := parseKeyRateData(KeyRateData{}, time.UTC)
+	if err != nil || len(rates) != 0 {
+		t.Errorf("parseKeyRateData(пусто) = %v, %v; ожидалось без ставок и ошибки", rates, err)
+	}
+
+	data := KeyRateData{Rows: []KeyRateRow{{Date: "2025-12-19T00:00:00+03:00", Rate: "abc"}}}
+	if _, err := parseKeyRateData(data, time.UTC); !errors.Is(err, ErrNoXMLRates) {
+		t.Errorf("parseKeyRateData() error = %v, ожидалась ErrNoXMLRates", err)
+	}
+}
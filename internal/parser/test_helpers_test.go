@@ -1,6 +1,10 @@
 package parser
 
-import "time"
+import (
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+)
 
 func testPastDateUTC() time.Time {
 	return time.Now().UTC().AddDate(0, 0, -30)
@@ -9,3 +13,8 @@ func testPastDateUTC() time.Time {
 func formatCBRDate(date time.Time) string {
 	return date.Format("02.01.2006")
 }
+
+// dec создает Decimal из строкового литерала для тестовых данных
+func dec(s string) models.Decimal {
+	return models.MustParseDecimal(s)
+}
@@ -2,11 +2,11 @@
 package parser
 
 import (
+	"bytes"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
-	"regexp"
 	"strings"
 	"time"
 
@@ -14,10 +14,6 @@ import (
 	"golang.org/x/text/encoding/charmap"
 )
 
-// windows1251Regex используется для case-insensitive замены декларации кодировки
-// Компилируется один раз при загрузке пакета для оптимизации производительности
-var windows1251Regex = regexp.MustCompile(`(?i)windows-1251`)
-
 // Ошибки XML парсинга
 var (
 	ErrInvalidXML     = errors.New("invalid XML structure")
@@ -30,6 +26,7 @@ var (
 const maxXMLSize = 4 << 20
 
 // ValCurs представляет корневой элемент XML ответа ЦБ РФ
+// ParseXML читает документ потоком и не собирает его в ValCurs; тип описывает формат ответа
 // Пример: <ValCurs Date="20.12.2025" name="Foreign Currency Market">
 type ValCurs struct {
 	XMLName xml.Name `xml:"ValCurs"`
@@ -60,107 +57,240 @@ type Valute struct {
 // ParseXML парсит XML ответ ЦБ РФ и возвращает данные о курсах валют
 // r - io.Reader с XML контентом (может быть в кодировке windows-1251)
 // date - дата курсов (используется для установки даты в ExchangeRate)
+// Документ разбирается потоком: в памяти одновременно находится только одна валюта
 func ParseXML(r io.Reader, date time.Time) (*models.RateData, error) {
-	// Читаем весь XML в память с ограничением размера
-	// Читаем maxXMLSize+1 байт: если прочитано больше лимита — явная ошибка
-	r = io.LimitReader(r, maxXMLSize+1)
-	xmlData, err := io.ReadAll(r)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read XML: %w", err)
-	}
-	if int64(len(xmlData)) > maxXMLSize {
-		return nil, fmt.Errorf("%w: received %d bytes (limit %d)", ErrXMLTooLarge, len(xmlData), maxXMLSize)
+	// Дата курсов из XML (ЦБ может вернуть прошлый рабочий день) известна из корневого элемента
+	// до первой валюты
+	var rateData *models.RateData
+	onRoot := func(root xml.StartElement) {
+		parsedDate := date
+		if value := xmlAttr(root, "Date"); value != "" {
+			if parsed, err := time.ParseInLocation("02.01.2006", value, date.Location()); err == nil {
+				parsedDate = parsed
+			}
+		}
+		// Создаём RateData через NewRateData для единообразия
+		rateData = models.NewRateData(parsedDate)
 	}
 
-	// Если XML содержит декларацию windows-1251 (case-insensitive), конвертируем в UTF-8
-	// Используем regex с флагом (?i) для case-insensitive проверки без копирования данных
-	if windows1251Regex.Match(xmlData) {
-		decoder := charmap.Windows1251.NewDecoder()
-		xmlData, err = decoder.Bytes(xmlData)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode windows-1251: %w", err)
+	onValute := func(decoder *xml.Decoder, start *xml.StartElement) error {
+		var valute Valute
+		if err := decoder.DecodeElement(&valute, start); err != nil {
+			return err
 		}
-		// Заменяем декларацию кодировки на UTF-8 (case-insensitive)
-		// Используем regex для полностью регистронезависимой замены
-		// Это обрабатывает все возможные варианты регистра (windows-1251, Windows-1251, WINDOWS-1251, WinDows-1251 и т.д.)
-		xmlData = windows1251Regex.ReplaceAll(xmlData, []byte("UTF-8"))
+		addValute(rateData, valute)
+		return nil
 	}
 
-	// Декодируем XML в структуру
-	var valCurs ValCurs
-	if err := xml.Unmarshal(xmlData, &valCurs); err != nil {
-		return nil, fmt.Errorf("%w: %w", ErrInvalidXML, err)
+	if err := decodeXMLStream(r, "ValCurs", "Valute", onRoot, onValute); err != nil {
+		return nil, err
 	}
 
 	// Проверяем, что есть данные о валютах
-	if len(valCurs.Valutes) == 0 {
+	if len(rateData.Rates) == 0 {
 		return nil, ErrNoXMLRates
 	}
 
-	// Определяем дату курсов из XML (ЦБ может вернуть прошлый рабочий день)
-	parsedDate := date
-	if valCurs.Date != "" {
-		if parsed, err := time.ParseInLocation("02.01.2006", valCurs.Date, date.Location()); err == nil {
-			parsedDate = parsed
-		}
+	return rateData, nil
+}
+
+// addValute добавляет курс валюты из XML в снимок и пополняет справочник валют
+// Валюты с некорректным кодом, курсом или номиналом пропускаются
+func addValute(rateData *models.RateData, valute Valute) {
+	// Парсим код валюты
+	currency, err := parseCurrency(valute.CharCode)
+	if err != nil {
+		// Пропускаем записи с некорректным кодом валюты
+		return
 	}
 
-	// Создаём RateData через NewRateData для единообразия
-	rateData := models.NewRateData(parsedDate)
+	// Парсим значение курса (с запятой)
+	rate, err := parseXMLValue(valute.Value)
+	if err != nil {
+		// Пропускаем валюты с некорректным значением
+		return
+	}
 
-	for _, valute := range valCurs.Valutes {
-		// Парсим код валюты
-		currency, err := parseCurrency(valute.CharCode)
-		if err != nil {
-			// Пропускаем неподдерживаемые валюты (например, SDR)
-			continue
-		}
+	// Парсим номинал с обработкой ошибок
+	// valute.Nominal - string, что позволяет обработать некорректные значения
+	// без падения всего парсинга XML
+	nominal, err := parseNominal(valute.Nominal)
+	if err != nil {
+		// Пропускаем валюту с некорректным номиналом
+		return
+	}
+
+	// Добавляем курс через AddRate для единообразия
+	rateData.AddRate(models.ExchangeRate{
+		Currency: currency,
+		Rate:     rate,
+		Nominal:  nominal,
+		Date:     rateData.Date,
+	})
+
+	// Пополняем справочник валют данными из XML (название, коды, номинал)
+	models.RegisterCurrency(models.CurrencyInfo{
+		Code:    currency,
+		NumCode: strings.TrimSpace(valute.NumCode),
+		CBRID:   strings.TrimSpace(valute.ID),
+		Name:    strings.TrimSpace(valute.Name),
+		Nominal: nominal,
+	})
+}
+
+// parseXMLValue парсит строку значения из XML в формате "80,7220" (с запятой)
+// и возвращает точное десятичное значение
+var parseRateFunc = parseRate
+
+func parseXMLValue(s string) (models.Decimal, error) {
+	rate, err := parseRateFunc(s)
+	if err == nil {
+		return rate, nil
+	}
+	if errors.Is(err, ErrInvalidRate) {
+		return models.Decimal{}, fmt.Errorf("%w: %s", ErrInvalidXMLRate, strings.TrimSpace(s))
+	}
+
+	return models.Decimal{}, err
+}
 
-		// Парсим значение курса (с запятой)
-		rate, err := parseXMLValue(valute.Value)
+// decodeXMLStream разбирает XML ответ ЦБ РФ потоком, не загружая документ в память целиком
+// rootName - ожидаемый корневой элемент; onRoot получает его (с атрибутами) до первого дочернего
+// Дочерние элементы itemName передаются в onItem по одному (onItem декодирует элемент через
+// decoder.DecodeElement), остальные дочерние элементы пропускаются
+// Ошибки: ErrXMLTooLarge - документ больше maxXMLSize, "failed to read XML" - ошибка чтения r,
+// ErrInvalidXML - некорректный XML или другой корневой элемент
+func decodeXMLStream(r io.Reader, rootName, itemName string, onRoot func(xml.StartElement), onItem func(decoder *xml.Decoder, start *xml.StartElement) error) error {
+	decoder := newXMLDecoder(r)
+	inRoot := false
+	for {
+		token, err := decoder.Token()
 		if err != nil {
-			// Пропускаем валюты с некорректным значением
-			continue
+			return xmlDecodeError(err)
 		}
 
-		// Парсим номинал с обработкой ошибок
-		// Теперь valute.Nominal - string, что позволяет обработать некорректные значения
-		// без падения всего парсинга XML
-		nominal, err := parseNominal(valute.Nominal)
-		if err != nil {
-			// Пропускаем валюту с некорректным номиналом
-			continue
+		switch t := token.(type) {
+		case xml.StartElement:
+			if !inRoot {
+				if t.Name.Local != rootName {
+					return fmt.Errorf("%w: expected element type <%s> but have <%s>", ErrInvalidXML, rootName, t.Name.Local)
+				}
+				inRoot = true
+				if onRoot != nil {
+					onRoot(t)
+				}
+				continue
+			}
+
+			if t.Name.Local != itemName {
+				if err := decoder.Skip(); err != nil {
+					return xmlDecodeError(err)
+				}
+				continue
+			}
+			if err := onItem(decoder, &t); err != nil {
+				return xmlDecodeError(err)
+			}
+		case xml.EndElement:
+			// Дочерние элементы читаются целиком, поэтому это конец корневого элемента:
+			// данные после него не читаются
+			return nil
 		}
+	}
+}
 
-		// Добавляем курс через AddRate для единообразия
-		exchangeRate := models.ExchangeRate{
-			Currency: currency,
-			Rate:     rate,
-			Nominal:  nominal,
-			Date:     parsedDate,
+// xmlAttr возвращает значение атрибута элемента (пусто - атрибута нет)
+func xmlAttr(start xml.StartElement, name string) string {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == name {
+			return strings.TrimSpace(attr.Value)
 		}
-		rateData.AddRate(exchangeRate)
 	}
+	return ""
+}
 
-	if len(rateData.Rates) == 0 {
-		return nil, ErrNoXMLRates
+// newXMLDecoder создает потоковый декодер XML ответа ЦБ РФ
+// Читается не больше maxXMLSize байт; документ в windows-1251 перекодируется в UTF-8 по мере чтения
+func newXMLDecoder(r io.Reader) *xml.Decoder {
+	decoder := xml.NewDecoder(&xmlLimitReader{r: r, remaining: maxXMLSize})
+	decoder.CharsetReader = charsetReader
+	return decoder
+}
+
+// unmarshalXML декодирует XML, прочитанный readXML, с поддержкой windows-1251
+func unmarshalXML(data []byte, v any) error {
+	return newXMLDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// charsetReader перекодирует XML в кодировке из декларации в UTF-8
+// ЦБ РФ отдает XML в windows-1251; UTF-8 xml.Decoder поддерживает сам
+// Название кодировки регистронезависимо (windows-1251, Windows-1251, WINDOWS-1251)
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(strings.TrimSpace(charset)) {
+	case "windows-1251", "cp1251":
+		return charmap.Windows1251.NewDecoder().Reader(input), nil
+	default:
+		return nil, fmt.Errorf("unsupported XML encoding %q", charset)
 	}
+}
 
-	return rateData, nil
+// xmlReadError - ошибка чтения исходного reader (в отличие от ошибок разбора XML)
+type xmlReadError struct {
+	err error
 }
 
-// parseXMLValue парсит строку значения из XML в формате "80,7220" (с запятой)
-// и возвращает float64
-var parseRateFunc = parseRate
+func (e *xmlReadError) Error() string { return e.err.Error() }
 
-func parseXMLValue(s string) (float64, error) {
-	rate, err := parseRateFunc(s)
-	if err == nil {
-		return rate, nil
+func (e *xmlReadError) Unwrap() error { return e.err }
+
+// xmlLimitReader ограничивает размер XML: чтение больше remaining байт завершается ErrXMLTooLarge
+// Ошибки исходного reader оборачиваются в xmlReadError
+type xmlLimitReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *xmlLimitReader) Read(p []byte) (int, error) {
+	// Читаем на байт больше лимита: если он прочитан - документ слишком большой
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
 	}
-	if errors.Is(err, ErrInvalidRate) {
-		return 0, fmt.Errorf("%w: %s", ErrInvalidXMLRate, strings.TrimSpace(s))
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n + int(l.remaining), fmt.Errorf("%w: more than %d bytes", ErrXMLTooLarge, maxXMLSize)
+	}
+	if err != nil && err != io.EOF {
+		return n, &xmlReadError{err: err}
 	}
+	return n, err
+}
+
+// xmlDecodeError преобразует ошибку xml.Decoder в ошибку пакета
+func xmlDecodeError(err error) error {
+	var readErr *xmlReadError
+	switch {
+	case errors.Is(err, ErrXMLTooLarge):
+		return err
+	case errors.As(err, &readErr):
+		return fmt.Errorf("failed to read XML: %w", readErr.err)
+	default:
+		return fmt.Errorf("%w: %w", ErrInvalidXML, err)
+	}
+}
 
-	return 0, err
+// readXML читает XML ответ целиком с ограничением размера
+// Нужен, когда ответ разбирается несколько раз (SOAP: сначала Fault, затем результат);
+// кодировка обрабатывается при декодировании (unmarshalXML)
+func readXML(r io.Reader) ([]byte, error) {
+	// Читаем maxXMLSize+1 байт: если прочитано больше лимита — явная ошибка
+	r = io.LimitReader(r, maxXMLSize+1)
+	xmlData, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read XML: %w", err)
+	}
+	if int64(len(xmlData)) > maxXMLSize {
+		return nil, fmt.Errorf("%w: received %d bytes (limit %d)", ErrXMLTooLarge, len(xmlData), maxXMLSize)
+	}
+	return xmlData, nil
 }
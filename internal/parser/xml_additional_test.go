@@ -48,12 +48,12 @@ func TestParseXML_Windows1251Encoding(t *testing.T) {
 	if !ok {
 		t.Fatal("USD должен присутствовать в результатах")
 	}
-	if usdRate.Rate != 80.7220 {
+	if !usdRate.Rate.Equal(dec("80.7220")) {
 		t.Errorf("USD курс: ожидалось 80.7220, получено %v", usdRate.Rate)
 	}
 }
 
-func TestParseXML_SkipsUnsupportedAndInvalidRates(t *testing.T) {
+func TestParseXML_SkipsInvalidRates(t *testing.T) {
 	date := testPastDateUTC()
 	dateStr := formatCBRDate(date)
 	xmlData := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
@@ -93,13 +93,17 @@ func TestParseXML_SkipsUnsupportedAndInvalidRates(t *testing.T) {
 		t.Fatalf("ParseXML() ошибка: %v", err)
 	}
 
-	if len(data.Rates) != 1 {
-		t.Fatalf("Ожидалась только 1 валюта после фильтрации, получено %d", len(data.Rates))
+	if len(data.Rates) != 2 {
+		t.Fatalf("Ожидалось 2 валюты после фильтрации, получено %d", len(data.Rates))
 	}
 
 	if _, ok := data.Rates[models.USD]; !ok {
 		t.Fatal("USD должен остаться после фильтрации")
 	}
+
+	if _, ok := data.Rates[models.Currency("XDR")]; !ok {
+		t.Fatal("XDR публикуется ЦБ РФ и должен остаться после фильтрации")
+	}
 }
 
 func TestParseXML_NoRates(t *testing.T) {
@@ -80,7 +80,7 @@ func TestParseXML_Windows1251CaseInsensitive(t *testing.T) {
 			}
 
 			// Проверяем курс
-			if usdRate.Rate != 80.7220 {
+			if !usdRate.Rate.Equal(dec("80.7220")) {
 				t.Errorf("USD rate = %v, want 80.7220", usdRate.Rate)
 			}
 		})
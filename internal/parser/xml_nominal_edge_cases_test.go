@@ -154,7 +154,7 @@ func TestParseXML_MixedValidAndInvalidNominals(t *testing.T) {
 	if !exists {
 		t.Error("ParseXML() EUR rate not found, but should be valid")
 	}
-	if eurRate.Rate != 90.1234 {
+	if !eurRate.Rate.Equal(dec("90.1234")) {
 		t.Errorf("ParseXML() EUR rate = %v, want 90.1234", eurRate.Rate)
 	}
 	if eurRate.Nominal != 1 {
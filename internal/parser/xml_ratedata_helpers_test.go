@@ -88,7 +88,7 @@ func TestParseXML_UsesAddRate(t *testing.T) {
 	if !exists {
 		t.Error("ParseXML() USD rate not found, AddRate should have added it")
 	}
-	if usdRate.Rate != 80.7220 {
+	if !usdRate.Rate.Equal(dec("80.7220")) {
 		t.Errorf("ParseXML() USD rate = %v, want 80.7220", usdRate.Rate)
 	}
 
@@ -97,7 +97,7 @@ func TestParseXML_UsesAddRate(t *testing.T) {
 	if !exists {
 		t.Error("ParseXML() EUR rate not found, AddRate should have added it")
 	}
-	if eurRate.Rate != 90.1234 {
+	if !eurRate.Rate.Equal(dec("90.1234")) {
 		t.Errorf("ParseXML() EUR rate = %v, want 90.1234", eurRate.Rate)
 	}
 }
@@ -129,7 +129,7 @@ func TestParseXML_RateDataHelpersIntegration(t *testing.T) {
 	if !found {
 		t.Error("GetRate() USD not found, but should be added via AddRate")
 	}
-	if usdRate.Rate != 80.7220 {
+	if !usdRate.Rate.Equal(dec("80.7220")) {
 		t.Errorf("GetRate() USD rate = %v, want 80.7220", usdRate.Rate)
 	}
 	if usdRate.Currency != models.USD {
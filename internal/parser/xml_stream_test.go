@@ -0,0 +1,135 @@
+package parser
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/models"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// TestParseXML_StopsAfterRoot проверяет, что документ читается только до конца корневого элемента
+func TestParseXML_StopsAfterRoot(t *testing.T) {
+	xmlData := `<ValCurs Date="20.12.2025"><Valute><CharCode>USD</CharCode><Nominal>1</Nominal><Value>80,7220</Value></Valute></ValCurs>`
+	r := io.MultiReader(strings.NewReader(xmlData), &errorReader{err: errors.New("connection reset")})
+
+	data, err := ParseXML(r, testPastDateUTC())
+	if err != nil {
+		t.Fatalf("ParseXML() error = %v, данные после корневого элемента не должны читаться", err)
+	}
+	if len(data.Rates) != 1 {
+		t.Errorf("Курсов: %d, ожидался 1", len(data.Rates))
+	}
+}
+
+func TestParseXML_StreamErrors(t *testing.T) {
+	date := testPastDateUTC()
+
+	tests := []struct {
+		name    string
+		reader  io.Reader
+		wantErr error
+	}{
+		{
+			name:    "Пустой ответ",
+			reader:  strings.NewReader(""),
+			wantErr: ErrInvalidXML,
+		},
+		{
+			name:    "Другой корневой элемент",
+			reader:  strings.NewReader(`<html><body>Service Unavailable</body></html>`),
+			wantErr: ErrInvalidXML,
+		},
+		{
+			name:    "Неподдерживаемая кодировка",
+			reader:  strings.NewReader(`<?xml version="1.0" encoding="koi8-r"?><ValCurs></ValCurs>`),
+			wantErr: ErrInvalidXML,
+		},
+		{
+			name:    "Обрыв документа",
+			reader:  strings.NewReader(`<ValCurs Date="20.12.2025"><Valute><CharCode>USD`),
+			wantErr: ErrInvalidXML,
+		},
+		{
+			name:    "Обрыв соединения внутри документа",
+			reader:  io.MultiReader(strings.NewReader(`<ValCurs Date="20.12.2025"><Valute>`), &errorReader{err: io.ErrUnexpectedEOF}),
+			wantErr: io.ErrUnexpectedEOF,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseXML(tt.reader, date); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseXML() error = %v, ожидалась %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestParseDynamicXML_Windows1251Stream проверяет потоковый разбор большой динамики в windows-1251
+func TestParseDynamicXML_Windows1251Stream(t *testing.T) {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="windows-1251"?>
+<ValCurs ID="R01235" DateRange1="01.01.2015" DateRange2="31.12.2024" name="Динамика курса">`)
+	start := time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+	const days = 3650
+	for i := 0; i < days; i++ {
+		fmt.Fprintf(&b, `<Record Date="%s" Id="R01235"><Nominal>1</Nominal><Value>%d,5000</Value></Record>`,
+			start.AddDate(0, 0, i).Format("02.01.2006"), 50+i%50)
+	}
+	b.WriteString(`</ValCurs>`)
+
+	encoded, err := charmap.Windows1251.NewEncoder().Bytes([]byte(b.String()))
+	if err != nil {
+		t.Fatalf("Ошибка кодирования Windows-1251: %v", err)
+	}
+
+	series, err := ParseDynamicXML(bytes.NewReader(encoded), models.USD, time.UTC)
+	if err != nil {
+		t.Fatalf("ParseDynamicXML() error = %v", err)
+	}
+	if len(series.Rates) != days || !series.From.Equal(start) {
+		t.Errorf("Записей: %d, начало %v; ожидалось %d с %v", len(series.Rates), series.From, days, start)
+	}
+	if last := series.Rates[days-1]; !last.Rate.Equal(dec("99.5000")) {
+		t.Errorf("Последняя запись: %s, ожидалось 99.5000", last.Rate)
+	}
+}
+
+func TestParseDynamicXML_TooLarge(t *testing.T) {
+	record := `<Record Date="02.12.2025"><Nominal>1</Nominal><Value>78,2284</Value></Record>`
+	xmlData := `<ValCurs ID="R01235">` + record + strings.Repeat(" ", maxXMLSize) + record + `</ValCurs>`
+
+	if _, err := ParseDynamicXML(strings.NewReader(xmlData), models.USD, time.UTC); !errors.Is(err, ErrXMLTooLarge) {
+		t.Errorf("ParseDynamicXML() error = %v, ожидалась ErrXMLTooLarge", err)
+	}
+}
+
+func TestXMLLimitReader(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		limit   int64
+		wantErr error
+	}{
+		{"Ровно по лимиту", "12345", 5, nil},
+		{"Больше лимита", "123456", 5, ErrXMLTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := io.ReadAll(&xmlLimitReader{r: strings.NewReader(tt.data), remaining: tt.limit})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ReadAll() error = %v, ожидалась %v", err, tt.wantErr)
+			}
+			if int64(len(data)) > tt.limit {
+				t.Errorf("Прочитано %d байт, лимит %d", len(data), tt.limit)
+			}
+		})
+	}
+}
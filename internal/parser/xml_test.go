@@ -62,8 +62,8 @@ func TestParseXML_Success(t *testing.T) {
 	if usdRate.Currency != models.USD {
 		t.Errorf("USD currency = %v, want %v", usdRate.Currency, models.USD)
 	}
-	if usdRate.Rate != 80.7220 {
-		t.Errorf("USD rate = %f, want %f", usdRate.Rate, 80.7220)
+	if !usdRate.Rate.Equal(dec("80.7220")) {
+		t.Errorf("USD rate = %s, want 80.7220", usdRate.Rate)
 	}
 	if usdRate.Nominal != 1 {
 		t.Errorf("USD nominal = %d, want 1", usdRate.Nominal)
@@ -74,8 +74,8 @@ func TestParseXML_Success(t *testing.T) {
 	if !ok {
 		t.Fatal("EUR rate not found")
 	}
-	if eurRate.Rate != 88.1234 {
-		t.Errorf("EUR rate = %f, want %f", eurRate.Rate, 88.1234)
+	if !eurRate.Rate.Equal(dec("88.1234")) {
+		t.Errorf("EUR rate = %s, want 88.1234", eurRate.Rate)
 	}
 }
 
@@ -85,31 +85,31 @@ func TestParseXML_DifferentNominals(t *testing.T) {
 		name    string
 		nominal int
 		value   string
-		want    float64
+		want    string
 	}{
 		{
 			name:    "Nominal 1 (USD)",
 			nominal: 1,
 			value:   "80,7220",
-			want:    80.7220,
+			want:    "80.7220",
 		},
 		{
 			name:    "Nominal 10 (DKK)",
 			nominal: 10,
 			value:   "118,3456",
-			want:    118.3456,
+			want:    "118.3456",
 		},
 		{
 			name:    "Nominal 100 (HUF)",
 			nominal: 100,
 			value:   "24,4161",
-			want:    24.4161,
+			want:    "24.4161",
 		},
 		{
 			name:    "Nominal 10000 (VND)",
 			nominal: 10000,
 			value:   "32,0988",
-			want:    32.0988,
+			want:    "32.0988",
 		},
 	}
 
@@ -143,8 +143,8 @@ func TestParseXML_DifferentNominals(t *testing.T) {
 				t.Errorf("nominal = %d, want %d", usdRate.Nominal, tt.nominal)
 			}
 
-			if usdRate.Rate != tt.want {
-				t.Errorf("rate = %f, want %f", usdRate.Rate, tt.want)
+			if !usdRate.Rate.Equal(dec(tt.want)) {
+				t.Errorf("rate = %s, want %s", usdRate.Rate, tt.want)
 			}
 		})
 	}
@@ -196,8 +196,9 @@ func TestParseXML_InvalidXML(t *testing.T) {
 	}
 }
 
-// TestParseXML_UnsupportedCurrency тестирует пропуск неподдерживаемых валют
-func TestParseXML_UnsupportedCurrency(t *testing.T) {
+// TestParseXML_AllPublishedCurrencies проверяет, что в результат попадают все валюты из XML,
+// а справочник валют пополняется данными ЦБ РФ
+func TestParseXML_AllPublishedCurrencies(t *testing.T) {
 	date := testPastDateUTC()
 	dateStr := formatCBRDate(date)
 	xmlData := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
@@ -225,19 +226,29 @@ func TestParseXML_UnsupportedCurrency(t *testing.T) {
 		t.Fatalf("ParseXML() error = %v, want nil", err)
 	}
 
-	// XDR должен быть пропущен
-	if _, ok := result.Rates[models.Currency("XDR")]; ok {
-		t.Error("XDR should be skipped")
+	// XDR публикуется ЦБ РФ и должен присутствовать наравне с USD
+	if _, ok := result.Rates[models.Currency("XDR")]; !ok {
+		t.Error("XDR should be present")
 	}
 
-	// USD должен присутствовать
 	if _, ok := result.Rates[models.USD]; !ok {
 		t.Error("USD should be present")
 	}
 
-	// Должна быть только 1 валюта (USD)
-	if len(result.Rates) != 1 {
-		t.Errorf("len(result.Rates) = %d, want 1", len(result.Rates))
+	if len(result.Rates) != 2 {
+		t.Errorf("len(result.Rates) = %d, want 2", len(result.Rates))
+	}
+
+	// Справочник пополнен данными из XML
+	info, ok := models.LookupCurrency(models.Currency("XDR"))
+	if !ok {
+		t.Fatal("XDR should be registered")
+	}
+	if info.CBRID != "R01589" || info.NumCode != "960" || info.Nominal != 1 {
+		t.Errorf("XDR info = %+v, want CBRID=R01589 NumCode=960 Nominal=1", info)
+	}
+	if info.Name != "СДР (специальные права заимствования)" {
+		t.Errorf("XDR name = %q", info.Name)
 	}
 }
 
@@ -281,7 +292,7 @@ func TestParseXML_NoSupportedRates(t *testing.T) {
 <ValCurs Date="%s" name="Foreign Currency Market">
     <Valute ID="R01589">
         <NumCode>960</NumCode>
-        <CharCode>XDR</CharCode>
+        <CharCode></CharCode>
         <Nominal>1</Nominal>
         <Name>Special Drawing Rights</Name>
         <Value>12,3456</Value>
@@ -330,49 +341,49 @@ func TestParseXMLValue(t *testing.T) {
 	tests := []struct {
 		name    string
 		input   string
-		want    float64
+		want    string
 		wantErr bool
 	}{
 		{
 			name:    "Valid value with comma",
 			input:   "80,7220",
-			want:    80.7220,
+			want:    "80.7220",
 			wantErr: false,
 		},
 		{
 			name:    "Valid value with dot",
 			input:   "80.7220",
-			want:    80.7220,
+			want:    "80.7220",
 			wantErr: false,
 		},
 		{
 			name:    "Valid value with spaces",
 			input:   "  80,7220  ",
-			want:    80.7220,
+			want:    "80.7220",
 			wantErr: false,
 		},
 		{
 			name:    "Empty string",
 			input:   "",
-			want:    0,
+			want:    "",
 			wantErr: true,
 		},
 		{
 			name:    "Invalid format",
 			input:   "abc",
-			want:    0,
+			want:    "",
 			wantErr: true,
 		},
 		{
 			name:    "Negative value",
 			input:   "-80,7220",
-			want:    0,
+			want:    "",
 			wantErr: true,
 		},
 		{
 			name:    "Zero value",
 			input:   "0",
-			want:    0,
+			want:    "",
 			wantErr: true,
 		},
 	}
@@ -384,7 +395,7 @@ func TestParseXMLValue(t *testing.T) {
 				t.Errorf("parseXMLValue() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !tt.wantErr && got != tt.want {
+			if !tt.wantErr && !got.Equal(dec(tt.want)) {
 				t.Errorf("parseXMLValue() = %v, want %v", got, tt.want)
 			}
 		})
@@ -455,8 +466,8 @@ func TestParseXMLValue_NonErrInvalidRateError(t *testing.T) {
 	})
 
 	sentinelErr := errors.New("sentinel parse rate error")
-	parseRateFunc = func(string) (float64, error) {
-		return 0, sentinelErr
+	parseRateFunc = func(string) (models.Decimal, error) {
+		return models.Decimal{}, sentinelErr
 	}
 
 	_, err := parseXMLValue("80,7220")
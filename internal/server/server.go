@@ -0,0 +1,295 @@
+// Package server предоставляет HTTP REST API с курсами ЦБ РФ и конвертацией
+// для сервисов, которые не могут встроить GUI приложение
+//
+// Эндпоинты (все ответы - JSON):
+//
+//	GET /health
+//	GET /v1/rates?date=2025-12-20
+//	GET /v1/rates/{code}?date=2025-12-20
+//	GET /v1/convert?amount=1000&from=USD&to=RUB&date=2025-12-20
+//
+// Курсы и валюта конвертации по умолчанию - в базовой валюте источника конвертера
+// (рубли у ЦБ РФ, евро у ЕЦБ: currate serve --source ecb)
+//
+// Дата принимается в форматах YYYY-MM-DD и DD.MM.YYYY, по умолчанию - сегодня
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/converter"
+	"github.com/bivlked/currate-go/internal/models"
+	"github.com/bivlked/currate-go/internal/parser"
+)
+
+// dateLayout - формат дат в ответах API
+const dateLayout = "2006-01-02"
+
+// Ошибки разбора параметров запроса (HTTP 400)
+var (
+	ErrInvalidParam = errors.New("некорректный параметр запроса")
+)
+
+// Server - HTTP обработчик REST API поверх Converter
+// Реализует http.Handler, поэтому подключается к http.Server или httptest.NewServer
+type Server struct {
+	converter *converter.Converter
+	mux       *http.ServeMux
+}
+
+// New создает Server с маршрутами API
+//
+// Пример использования:
+//
+//	conv := converter.NewConverter(converter.FetchRatesFunc(parser.FetchRates), cache.NewLRUCache(1000, 24*time.Hour))
+//	srv := &http.Server{Addr: "127.0.0.1:8080", Handler: server.New(conv)}
+//	log.Fatal(srv.ListenAndServe())
+func New(conv *converter.Converter) *Server {
+	if conv == nil {
+		panic("server.New: converter must not be nil")
+	}
+
+	s := &Server{
+		converter: conv,
+		mux:       http.NewServeMux(),
+	}
+	s.mux.HandleFunc("GET /health", s.handleHealth)
+	s.mux.HandleFunc("GET /v1/rates", s.handleRates)
+	s.mux.HandleFunc("GET /v1/rates/{code}", s.handleRate)
+	s.mux.HandleFunc("GET /v1/convert", s.handleConvert)
+	return s
+}
+
+// ServeHTTP реализует http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// HealthResponse - ответ /health
+type HealthResponse struct {
+	Status string `json:"status"`
+}
+
+// RateItem - курс валюты в ответе /v1/rates
+type RateItem struct {
+	Code     models.Currency `json:"code"`
+	Name     string          `json:"name"`
+	Nominal  int             `json:"nominal"`
+	Rate     models.Decimal  `json:"rate"`     // Единиц базовой валюты за Nominal единиц, как в XML ЦБ РФ
+	UnitRate models.Decimal  `json:"unitRate"` // Единиц базовой валюты за единицу валюты
+}
+
+// RatesResponse - ответ /v1/rates
+type RatesResponse struct {
+	Date   string          `json:"date"`             // Фактическая дата курсов ЦБ РФ
+	Base   models.Currency `json:"base"`             // Базовая валюта курсов (RUB у ЦБ РФ, EUR у ЕЦБ)
+	Source string          `json:"source,omitempty"` // Источник курсов ("cbr-xml", "file" и т.д.)
+	Rates  []RateItem      `json:"rates"`
+}
+
+// RateResponse - ответ /v1/rates/{code}
+type RateResponse struct {
+	Code models.Currency `json:"code"`
+	Date string          `json:"date"` // Фактическая дата курса (в выходные - последнего рабочего дня)
+	Base models.Currency `json:"base"` // Базовая валюта курса (RUB у ЦБ РФ, EUR у ЕЦБ)
+	Rate models.Decimal  `json:"rate"` // Единиц базовой валюты за единицу валюты
+}
+
+// ConvertResponse - ответ /v1/convert
+type ConvertResponse struct {
+	From          models.Currency `json:"from"`
+	To            models.Currency `json:"to"`
+	Amount        models.Decimal  `json:"amount"`
+	Result        models.Decimal  `json:"result"`
+	Rate          models.Decimal  `json:"rate"` // Рублей за единицу, для кросс-конвертации - кросс-курс
+	Date          string          `json:"date"` // Фактическая дата курса ЦБ РФ
+	Formatted     string          `json:"formatted"`
+	AmountInWords string          `json:"amountInWords"`
+	Source        string          `json:"source,omitempty"` // Источник курса ("cbr-xml", "file" и т.д.)
+}
+
+// ErrorResponse - ответ с ошибкой
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, HealthResponse{Status: "ok"})
+}
+
+func (s *Server) handleRates(w http.ResponseWriter, r *http.Request) {
+	date, err := parseDateParam(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	rateData, err := s.converter.GetRates(r.Context(), date)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	items := make([]RateItem, 0, len(rateData.Rates))
+	for _, rate := range rateData.Rates {
+		items = append(items, RateItem{
+			Code:     rate.Currency,
+			Name:     rate.Currency.Name(),
+			Nominal:  rate.Nominal,
+			Rate:     rate.Rate,
+			UnitRate: converter.UnitRate(rate),
+		})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Code < items[j].Code })
+
+	writeJSON(w, http.StatusOK, RatesResponse{
+		Date:   rateData.Date.Format(dateLayout),
+		Base:   rateData.BaseCurrency(),
+		Source: rateData.Source,
+		Rates:  items,
+	})
+}
+
+func (s *Server) handleRate(w http.ResponseWriter, r *http.Request) {
+	currency, err := s.converter.ParseCurrency(r.PathValue("code"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	date, err := parseDateParam(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	// Курс в базовой валюте источника: у ЕЦБ рублевых курсов нет
+	quote, err := s.converter.GetRateInBase(r.Context(), currency, date)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, RateResponse{Code: currency, Date: quote.Date.Format(dateLayout), Base: quote.Base, Rate: quote.Rate})
+}
+
+func (s *Server) handleConvert(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	amount, err := models.ParseDecimal(query.Get("amount"))
+	if err != nil {
+		writeError(w, fmt.Errorf("%w: amount=%q", converter.ErrInvalidAmount, query.Get("amount")))
+		return
+	}
+	from, err := s.converter.ParseCurrency(query.Get("from"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var to models.Currency
+	if query.Get("to") != "" {
+		if to, err = s.converter.ParseCurrency(query.Get("to")); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+	date, err := parseDateParam(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	// По умолчанию - в базовую валюту источника (рубли у ЦБ РФ, евро у ЕЦБ); снимок остается в кэше
+	if to == "" {
+		rateData, err := s.converter.GetRates(r.Context(), date)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		to = rateData.BaseCurrency()
+	}
+
+	// Направление определяется парой валют, как в консольной утилите
+	var result *models.ConversionResult
+	switch {
+	case to == models.RUB:
+		result, err = s.converter.Convert(r.Context(), amount, from, date)
+	case from == models.RUB:
+		result, err = s.converter.ConvertFromRUB(r.Context(), amount, to, date)
+	default:
+		result, err = s.converter.ConvertCross(r.Context(), amount, from, to, date)
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ConvertResponse{
+		From:          result.SourceCurrency,
+		To:            result.TargetCurrency,
+		Amount:        result.SourceAmount,
+		Result:        result.TargetAmount,
+		Rate:          result.Rate,
+		Date:          result.Date.Format(dateLayout),
+		Formatted:     result.FormattedStr,
+		AmountInWords: result.AmountInWords,
+		Source:        result.Source,
+	})
+}
+
+// parseDateParam разбирает параметр date: YYYY-MM-DD или DD.MM.YYYY, по умолчанию - сегодня
+// Дата разбирается в локальной временной зоне для сохранения календарной даты
+func parseDateParam(r *http.Request) (time.Time, error) {
+	value := r.URL.Query().Get("date")
+	if value == "" {
+		return time.Now(), nil
+	}
+	for _, layout := range []string{dateLayout, "02.01.2006"} {
+		if date, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: date=%q, используйте YYYY-MM-DD или DD.MM.YYYY", ErrInvalidParam, value)
+}
+
+// statusCode сопоставляет ошибку HTTP статусу
+// Использует errors.Is, поэтому работает и с обёрнутыми ошибками
+func statusCode(err error) int {
+	switch {
+	case errors.Is(err, ErrInvalidParam),
+		errors.Is(err, converter.ErrInvalidAmount),
+		errors.Is(err, converter.ErrDateInFuture),
+		errors.Is(err, models.ErrUnsupportedCurrency):
+		return http.StatusBadRequest
+	case errors.Is(err, converter.ErrRateNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, parser.ErrHTTPFailed),
+		errors.Is(err, parser.ErrInvalidStatus),
+		errors.Is(err, parser.ErrMaxRetries),
+		errors.Is(err, converter.ErrAllSourcesFailed):
+		return http.StatusBadGateway
+	case errors.Is(err, parser.ErrSourceUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// writeError отправляет ошибку с подходящим HTTP статусом
+func writeError(w http.ResponseWriter, err error) {
+	writeJSON(w, statusCode(err), ErrorResponse{Error: err.Error()})
+}
+
+// writeJSON отправляет JSON ответ
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("server: failed to write response: %v", err)
+	}
+}
@@ -0,0 +1,283 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bivlked/currate-go/internal/cache"
+	"github.com/bivlked/currate-go/internal/converter"
+	"github.com/bivlked/currate-go/internal/models"
+	"github.com/bivlked/currate-go/internal/parser"
+)
+
+// fakeRateProvider - фейковый RateProvider с фиксированными курсами
+type fakeRateProvider struct {
+	date      time.Time
+	err       error
+	callCount int
+}
+
+func (f *fakeRateProvider) FetchRates(_ context.Context, _ time.Time) (*models.RateData, error) {
+	f.callCount++
+	if f.err != nil {
+		return nil, f.err
+	}
+	rateData := models.NewRateData(f.date)
+	rateData.AddRate(models.ExchangeRate{Currency: models.USD, Rate: models.MustParseDecimal("80.7220"), Nominal: 1, Date: f.date})
+	rateData.AddRate(models.ExchangeRate{Currency: models.EUR, Rate: models.MustParseDecimal("88.1234"), Nominal: 1, Date: f.date})
+	rateData.AddRate(models.ExchangeRate{Currency: "JPY", Rate: models.MustParseDecimal("51.2345"), Nominal: 100, Date: f.date})
+	return rateData, nil
+}
+
+// newTestServer запускает httptest сервер с фейковым источником курсов на 20.12.2025
+func newTestServer(t *testing.T, providerErr error) (*httptest.Server, *fakeRateProvider) {
+	t.Helper()
+	provider := &fakeRateProvider{date: time.Date(2025, 12, 20, 0, 0, 0, 0, time.Local), err: providerErr}
+	conv := converter.NewConverter(provider, cache.NewLRUCache(100, time.Hour))
+
+	ts := httptest.NewServer(New(conv))
+	t.Cleanup(ts.Close)
+	return ts, provider
+}
+
+// getJSON выполняет GET запрос и декодирует JSON ответ в v
+func getJSON(t *testing.T, url string, v any) int {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Errorf("GET %s: Content-Type = %q, want application/json", url, ct)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("GET %s: чтение ответа: %v", url, err)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		t.Fatalf("GET %s: некорректный JSON %q: %v", url, body, err)
+	}
+	return resp.StatusCode
+}
+
+func TestNew_NilConverter(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("New(nil) should panic")
+		}
+	}()
+	New(nil)
+}
+
+func TestServer_Health(t *testing.T) {
+	ts, _ := newTestServer(t, nil)
+
+	var got HealthResponse
+	if status := getJSON(t, ts.URL+"/health", &got); status != http.StatusOK {
+		t.Errorf("status = %d, want 200", status)
+	}
+	if got.Status != "ok" {
+		t.Errorf("status = %q, want ok", got.Status)
+	}
+}
+
+func TestServer_Rates(t *testing.T) {
+	ts, provider := newTestServer(t, nil)
+
+	var got struct {
+		Date  string `json:"date"`
+		Rates []struct {
+			Code     string  `json:"code"`
+			Name     string  `json:"name"`
+			Nominal  int     `json:"nominal"`
+			Rate     float64 `json:"rate"`
+			UnitRate float64 `json:"unitRate"`
+		} `json:"rates"`
+	}
+	if status := getJSON(t, ts.URL+"/v1/rates?date=21.12.2025", &got); status != http.StatusOK {
+		t.Fatalf("status = %d, want 200", status)
+	}
+
+	if got.Date != "2025-12-20" {
+		t.Errorf("date = %s, want 2025-12-20 (фактическая дата ЦБ РФ)", got.Date)
+	}
+	if len(got.Rates) != 3 {
+		t.Fatalf("len(rates) = %d, want 3", len(got.Rates))
+	}
+	jpy := got.Rates[1]
+	if jpy.Code != "JPY" || jpy.Nominal != 100 || jpy.Rate != 51.2345 || jpy.UnitRate != 0.512345 || jpy.Name == "" {
+		t.Errorf("rates[1] = %+v, want JPY 100 51.2345 (0.512345 за единицу)", jpy)
+	}
+
+	// Курсы закэшированы: курс валюты на ту же дату - без обращения к ЦБ РФ
+	var rate RateResponse
+	if status := getJSON(t, ts.URL+"/v1/rates/usd?date=2025-12-21", &rate); status != http.StatusOK {
+		t.Fatalf("status = %d, want 200", status)
+	}
+	if provider.callCount != 1 {
+		t.Errorf("FetchRates вызван %d раз, want 1", provider.callCount)
+	}
+}
+
+func TestServer_Rate(t *testing.T) {
+	ts, _ := newTestServer(t, nil)
+
+	var got struct {
+		Code string  `json:"code"`
+		Date string  `json:"date"`
+		Rate float64 `json:"rate"`
+	}
+	// На воскресенье действует курс субботы: в ответе фактическая дата курса
+	if status := getJSON(t, ts.URL+"/v1/rates/JPY?date=2025-12-21", &got); status != http.StatusOK {
+		t.Fatalf("status = %d, want 200", status)
+	}
+	if got.Code != "JPY" || got.Date != "2025-12-20" || got.Rate != 0.512345 {
+		t.Errorf("ответ = %+v, want JPY 2025-12-20 0.512345", got)
+	}
+}
+
+func TestServer_Convert(t *testing.T) {
+	ts, _ := newTestServer(t, nil)
+
+	tests := []struct {
+		name       string
+		query      string
+		wantResult float64
+		wantTo     string
+	}{
+		{"Валюта → рубли (to по умолчанию)", "amount=1000&from=USD&date=2025-12-20", 80722, "RUB"},
+		{"Рубли → валюта", "amount=80722&from=RUB&to=USD&date=20.12.2025", 1000, "USD"},
+		{"Кросс-конвертация", "amount=1000&from=USD&to=EUR&date=2025-12-20", 916.01, "EUR"},
+		{"Сумма с запятой", "amount=1000,5&from=usd&to=rub&date=2025-12-20", 80762.36, "RUB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got struct {
+				To            string  `json:"to"`
+				Result        float64 `json:"result"`
+				Date          string  `json:"date"`
+				Formatted     string  `json:"formatted"`
+				AmountInWords string  `json:"amountInWords"`
+			}
+			if status := getJSON(t, ts.URL+"/v1/convert?"+tt.query, &got); status != http.StatusOK {
+				t.Fatalf("status = %d, want 200", status)
+			}
+			if got.Result != tt.wantResult || got.To != tt.wantTo {
+				t.Errorf("result = %v %s, want %v %s", got.Result, got.To, tt.wantResult, tt.wantTo)
+			}
+			if got.Date != "2025-12-20" || got.Formatted == "" || got.AmountInWords == "" {
+				t.Errorf("ответ = %+v, want дату 2025-12-20, formatted и amountInWords", got)
+			}
+		})
+	}
+}
+
+// ecbRateProvider - фейковый источник справочных курсов ЕЦБ (в евро, рублевого курса нет)
+type ecbRateProvider struct {
+	date time.Time
+}
+
+func (f ecbRateProvider) FetchRates(_ context.Context, _ time.Time) (*models.RateData, error) {
+	rateData := models.NewRateData(f.date)
+	rateData.Base = models.EUR
+	rateData.AddRate(models.ExchangeRate{Currency: models.USD, Rate: models.MustParseDecimal("0.8530967411704487"), Nominal: 1, Date: f.date})
+	rateData.AddRate(models.ExchangeRate{Currency: "JPY", Rate: models.MustParseDecimal("0.5489980785067252"), Nominal: 100, Date: f.date})
+	return rateData, nil
+}
+
+func TestServer_ECBSource(t *testing.T) {
+	conv := converter.NewConverter(ecbRateProvider{date: time.Date(2025, 12, 19, 0, 0, 0, 0, time.Local)}, cache.NewLRUCache(100, time.Hour)).
+		WithCurrencies(parser.ECBCurrencies())
+	ts := httptest.NewServer(New(conv))
+	t.Cleanup(ts.Close)
+
+	var rate RateResponse
+	if status := getJSON(t, ts.URL+"/v1/rates/USD?date=2025-12-19", &rate); status != http.StatusOK {
+		t.Fatalf("/v1/rates/USD: status = %d, want 200", status)
+	}
+	if rate.Base != models.EUR || !rate.Rate.Equal(models.MustParseDecimal("0.8530967411704487")) {
+		t.Errorf("/v1/rates/USD = %+v, want курс в EUR", rate)
+	}
+
+	var rates RatesResponse
+	if status := getJSON(t, ts.URL+"/v1/rates?date=2025-12-19", &rates); status != http.StatusOK || rates.Base != models.EUR {
+		t.Errorf("/v1/rates: status = %d, base = %s; want 200, EUR", status, rates.Base)
+	}
+
+	// Без to - в базовую валюту источника
+	var converted ConvertResponse
+	if status := getJSON(t, ts.URL+"/v1/convert?amount=1000000&from=USD&date=2025-12-19", &converted); status != http.StatusOK {
+		t.Fatalf("/v1/convert: status = %d, want 200", status)
+	}
+	if converted.To != models.EUR || !converted.Result.Equal(models.MustParseDecimal("853096.74")) {
+		t.Errorf("/v1/convert = %s %s, want 853096.74 EUR", converted.Result, converted.To)
+	}
+
+	// Рубля в справочнике ЕЦБ нет
+	var errResp ErrorResponse
+	if status := getJSON(t, ts.URL+"/v1/convert?amount=100&from=RUB&to=EUR&date=2025-12-19", &errResp); status != http.StatusBadRequest {
+		t.Errorf("/v1/convert from=RUB: status = %d, want 400", status)
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	future := time.Now().AddDate(0, 0, 2).Format("2006-01-02")
+
+	tests := []struct {
+		name        string
+		providerErr error
+		path        string
+		wantStatus  int
+	}{
+		{"Некорректная сумма", nil, "/v1/convert?amount=abc&from=USD", http.StatusBadRequest},
+		{"Отрицательная сумма", nil, "/v1/convert?amount=-5&from=USD&date=2025-12-20", http.StatusBadRequest},
+		{"Нет валюты", nil, "/v1/convert?amount=100", http.StatusBadRequest},
+		{"Неподдерживаемая валюта", nil, "/v1/rates/XYZ", http.StatusBadRequest},
+		{"Неподдерживаемая целевая валюта", nil, "/v1/convert?amount=100&from=USD&to=XYZ", http.StatusBadRequest},
+		{"Неверный формат даты", nil, "/v1/rates?date=20/12/2025", http.StatusBadRequest},
+		{"Дата в будущем", nil, "/v1/rates/USD?date=" + future, http.StatusBadRequest},
+		{"Курс не опубликован", nil, "/v1/rates/CNY?date=2025-12-20", http.StatusNotFound},
+		{"ЦБ РФ недоступен", fmt.Errorf("%w: timeout", parser.ErrMaxRetries), "/v1/rates?date=2025-12-20", http.StatusBadGateway},
+		{"Circuit breaker открыт", fmt.Errorf("%w: circuit breaker open", parser.ErrSourceUnavailable), "/v1/rates?date=2025-12-20", http.StatusServiceUnavailable},
+		{"Прочая ошибка", errors.New("boom"), "/v1/rates/USD?date=2025-12-20", http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, _ := newTestServer(t, tt.providerErr)
+
+			var got ErrorResponse
+			if status := getJSON(t, ts.URL+tt.path, &got); status != tt.wantStatus {
+				t.Errorf("status = %d, want %d (error: %s)", status, tt.wantStatus, got.Error)
+			}
+			if got.Error == "" {
+				t.Error("error пуст, want сообщение об ошибке")
+			}
+		})
+	}
+}
+
+func TestServer_MethodNotAllowed(t *testing.T) {
+	ts, _ := newTestServer(t, nil)
+
+	resp, err := http.Post(ts.URL+"/v1/convert", "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("POST: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("status = %d, want 405", resp.StatusCode)
+	}
+}
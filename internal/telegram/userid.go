@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"github.com/bivlked/currate-go/internal/appdir"
 )
 
 // UserData хранит данные пользователя
@@ -17,21 +19,10 @@ type UserData struct {
 // GetOrCreateUserID получает или создает уникальный ID пользователя
 // ID сохраняется в %APPDATA%/CurRate/user.json
 func GetOrCreateUserID() (string, error) {
-	// Получаем путь к APPDATA
-	appData := os.Getenv("APPDATA")
-	if appData == "" {
-		// Fallback для не-Windows систем
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return "", fmt.Errorf("не удалось определить домашнюю директорию: %w", err)
-		}
-		appData = filepath.Join(homeDir, ".config")
-	}
-
-	// Создаем директорию приложения (0700 — доступ только владельцу)
-	appDir := filepath.Join(appData, "CurRate")
-	if err := os.MkdirAll(appDir, 0700); err != nil {
-		return "", fmt.Errorf("не удалось создать директорию: %w", err)
+	// Директория приложения (создается при первом запуске)
+	appDir, err := appdir.Dir()
+	if err != nil {
+		return "", err
 	}
 
 	// Путь к файлу с данными пользователя
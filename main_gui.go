@@ -4,6 +4,8 @@ import (
 	"context"
 	"embed"
 	"log"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/wailsapp/wails/v2"
@@ -14,21 +16,76 @@ import (
 	"github.com/bivlked/currate-go/internal/app"
 	"github.com/bivlked/currate-go/internal/cache"
 	"github.com/bivlked/currate-go/internal/converter"
+	"github.com/bivlked/currate-go/internal/models"
 	"github.com/bivlked/currate-go/internal/parser"
 )
 
 //go:embed all:frontend
 var assets embed.FS
 
+// cbrTimeout - время на ответ сервиса ЦБ РФ, после которого запрос передается следующему источнику
+const cbrTimeout = 20 * time.Second
+
 func main() {
 	// Создаем кэш для курсов валют
-	cacheStorage := cache.NewLRUCache(100, 24*time.Hour)
+	// Динамика курса за период заполняет по записи на каждый день, поэтому кэш с запасом
+	// Курсы прошедших дат не меняются и хранятся бессрочно, курс на сегодня - час
+	var cacheStorage converter.CacheStorage = cache.NewLRUCacheWithPolicy(1000, cache.DefaultDatePolicy)
+	var disk *cache.DiskCache
+
+	// Источники курсов по порядку: XML API ЦБ РФ, SOAP сервис ЦБ РФ (доступен, когда XML API
+	// ограничивает частоту запросов), затем - по желанию пользователя - сохраненные ответы ЦБ РФ
+	// Все запросы к ЦБ РФ и ЕЦБ выполняет один клиент: общий пул соединений, User-Agent,
+	// повторы и circuit breaker по хосту; прокси - из переменных окружения HTTPS_PROXY, NO_PROXY
+	cbr := parser.NewCBRClient(parser.CBRClientOptions{Timeout: parser.DefaultTimeout})
+	soap := parser.NewSOAPClient(parser.CBRSOAPURL).WithClient(cbr)
+	sources := []converter.RateSource{
+		{Name: "cbr-xml", Provider: cbr, Timeout: cbrTimeout},
+		{Name: "cbr-soap", Provider: soap, Timeout: cbrTimeout},
+	}
+
+	// Второй уровень - кэш на диске: курсы, загруженные в прошлых запусках,
+	// доступны без сети. Без него приложение работает только с кэшем в памяти
+	if path, err := cache.DefaultDiskCachePath(); err != nil {
+		log.Println("Кэш на диске недоступен:", err)
+	} else {
+		// Сохраненные ответы ЦБ РФ (XML_daily_2025-12-20.xml) подключаются, только если пользователь
+		// создал каталог snapshots рядом с кэшем и положил их туда сам: приложение снимки не пишет,
+		// курсы прошлых запусков и так доступны без сети из кэша на диске
+		if dir := filepath.Join(filepath.Dir(path), "snapshots"); isDir(dir) {
+			sources = append(sources, converter.RateSource{
+				Name:     "file",
+				Provider: converter.FetchRatesFunc(parser.SnapshotFetcher(dir)),
+			})
+		}
+		if disk, err = cache.NewDiskCache(path, cache.DefaultDatePolicy); err != nil {
+			log.Println("Кэш на диске недоступен:", err)
+		} else {
+			cacheStorage = cache.NewTieredCache(cacheStorage, disk)
+		}
+	}
 
-	// Создаем конвертер с парсером ЦБ РФ и кэшем
-	conv := converter.NewConverter(converter.FetchRatesFunc(parser.FetchRates), cacheStorage)
+	// Создаем конвертер с цепочкой источников и кэшем
+	// Курсы валют дополняются учетными ценами драгоценных металлов (xml_metall.asp) в том же снимке
+	// Динамика курсов за период (XML_dynamic.asp) запрашивается одним запросом
+	// Истекшие записи кэша отдаются сразу и обновляются в фоне: без сети конвертация
+	// продолжает работать по последним загруженным курсам (с предупреждением в GUI)
+	provider := converter.NewMergeProvider(converter.NewProviderChain(sources...), converter.FetchRatesFunc(cbr.FetchMetalRates))
+	conv := converter.NewConverter(provider, cacheStorage).
+		WithSeriesProvider(cbr).
+		WithStaleWhileRevalidate()
+
+	// Справочные курсы ЕЦБ (в евро) - второй источник для сверки с европейскими контрагентами
+	// Frontend выбирает его полем source запроса; кэш отдельный: снимки ЕЦБ и ЦБ РФ не смешиваются
+	// Валюты проверяются по справочнику ЕЦБ: у ЕЦБ и ЦБ РФ разные наборы валют
+	ecb := converter.NewConverter(parser.NewECBProvider(models.EUR).WithClient(cbr), cache.NewLRUCacheWithPolicy(1000, cache.DefaultDatePolicy)).
+		WithCurrencies(parser.ECBCurrencies())
+
+	// Ключевая ставка ЦБ РФ (SOAP метод KeyRate) для расчета неустойки; кэш ставок по дням - свой
+	keyRates := converter.NewKeyRates(soap)
 
 	// Создаем App instance для GUI
-	appInstance := app.NewApp(conv)
+	appInstance := app.NewApp(conv, app.WithSource("ecb", ecb), app.WithKeyRates(keyRates))
 
 	// Запускаем Wails приложение
 	err := wails.Run(&options.App{
@@ -47,6 +104,14 @@ func main() {
 		OnStartup: func(ctx context.Context) {
 			appInstance.Startup(ctx)
 		},
+		OnShutdown: func(ctx context.Context) {
+			// Кэш на диске пишет файл с задержкой - сохраняем курсы, загруженные перед закрытием
+			if disk != nil {
+				if err := disk.Close(); err != nil {
+					log.Println("Не удалось сохранить кэш на диске:", err)
+				}
+			}
+		},
 		Bind: []interface{}{
 			appInstance,
 		},
@@ -61,3 +126,9 @@ func main() {
 		log.Fatal("Ошибка запуска приложения:", err)
 	}
 }
+
+// isDir сообщает, существует ли каталог path
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}